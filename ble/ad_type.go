@@ -0,0 +1,145 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"fmt"
+)
+
+// ADType represents an advertising data type.
+type ADType uint8
+
+const (
+	// ADTypeFlags represents the flags.
+	ADTypeFlags ADType = 0x01
+	// ADTypeIncompleteServiceUUIDs16 represents an incomplete list of 16-bit service UUIDs.
+	ADTypeIncompleteServiceUUIDs16 ADType = 0x02
+	// ADTypeCompleteServiceUUIDs16 represents a complete list of 16-bit service UUIDs.
+	ADTypeCompleteServiceUUIDs16 ADType = 0x03
+	// ADTypeIncompleteServiceUUIDs32 represents an incomplete list of 32-bit service UUIDs.
+	ADTypeIncompleteServiceUUIDs32 ADType = 0x04
+	// ADTypeCompleteServiceUUIDs32 represents a complete list of 32-bit service UUIDs.
+	ADTypeCompleteServiceUUIDs32 ADType = 0x05
+	// ADTypeIncompleteServiceUUIDs128 represents an incomplete list of 128-bit service UUIDs.
+	ADTypeIncompleteServiceUUIDs128 ADType = 0x06
+	// ADTypeCompleteServiceUUIDs128 represents a complete list of 128-bit service UUIDs.
+	ADTypeCompleteServiceUUIDs128 ADType = 0x07
+	// ADTypeShortenedLocalName represents a shortened local name.
+	ADTypeShortenedLocalName ADType = 0x08
+	// ADTypeCompleteLocalName represents a complete local name.
+	ADTypeCompleteLocalName ADType = 0x09
+	// ADTypeTxPowerLevel represents a TX power level.
+	ADTypeTxPowerLevel ADType = 0x0A
+	// ADTypePeripheralConnectionIntervalRange represents a peripheral connection interval range.
+	ADTypePeripheralConnectionIntervalRange ADType = 0x12
+	// ADTypeSolicitationUUIDs16 represents a list of 16-bit service solicitation UUIDs.
+	ADTypeSolicitationUUIDs16 ADType = 0x14
+	// ADTypeSolicitationUUIDs128 represents a list of 128-bit service solicitation UUIDs.
+	ADTypeSolicitationUUIDs128 ADType = 0x15
+	// ADTypeServiceData16 represents service data with a 16-bit UUID.
+	ADTypeServiceData16 ADType = 0x16
+	// ADTypeAppearance represents an appearance.
+	ADTypeAppearance ADType = 0x19
+	// ADTypeAdvertisingInterval represents an advertising interval.
+	ADTypeAdvertisingInterval ADType = 0x1A
+	// ADTypeLERole represents an LE role.
+	ADTypeLERole ADType = 0x1C
+	// ADTypeSolicitationUUIDs32 represents a list of 32-bit service solicitation UUIDs.
+	ADTypeSolicitationUUIDs32 ADType = 0x1F
+	// ADTypeServiceData32 represents service data with a 32-bit UUID.
+	ADTypeServiceData32 ADType = 0x20
+	// ADTypeServiceData128 represents service data with a 128-bit UUID.
+	ADTypeServiceData128 ADType = 0x21
+	// ADTypeURI represents a URI.
+	ADTypeURI ADType = 0x24
+	// ADTypeAdvertisingIntervalLong represents a long advertising interval.
+	ADTypeAdvertisingIntervalLong ADType = 0x2F
+	// ADTypeManufacturerData represents manufacturer specific data.
+	ADTypeManufacturerData ADType = 0xFF
+)
+
+// Name returns the name of the advertising data type in the Bluetooth SIG assigned numbers.
+func (t ADType) Name() string {
+	adType, ok := DefaultDatabase().LookupADType(int(t))
+	if !ok {
+		return ""
+	}
+	return adType.Name()
+}
+
+// String returns a string representation of the advertising data type.
+func (t ADType) String() string {
+	name := t.Name()
+	if len(name) == 0 {
+		return fmt.Sprintf("0x%02X", uint8(t))
+	}
+	return name
+}
+
+const (
+	// LegacyAdvertisingDataMaxSize is the maximum size of legacy advertising data and scan response data.
+	LegacyAdvertisingDataMaxSize = 31
+)
+
+// ADFlags represents the flags in advertising data.
+type ADFlags uint8
+
+const (
+	// ADFlagLELimitedDiscoverable indicates the LE limited discoverable mode.
+	ADFlagLELimitedDiscoverable ADFlags = 0x01
+	// ADFlagLEGeneralDiscoverable indicates the LE general discoverable mode.
+	ADFlagLEGeneralDiscoverable ADFlags = 0x02
+	// ADFlagBREDRNotSupported indicates that BR/EDR is not supported.
+	ADFlagBREDRNotSupported ADFlags = 0x04
+	// ADFlagSimultaneousLEBREDRController indicates simultaneous LE and BR/EDR to the same device capable controller.
+	ADFlagSimultaneousLEBREDRController ADFlags = 0x08
+	// ADFlagSimultaneousLEBREDRHost indicates simultaneous LE and BR/EDR to the same device capable host.
+	ADFlagSimultaneousLEBREDRHost ADFlags = 0x10
+)
+
+// Has returns whether all the specified flags are set.
+func (flags ADFlags) Has(other ADFlags) bool {
+	return flags&other == other
+}
+
+// LERole represents the LE role in advertising data.
+type LERole uint8
+
+const (
+	// LERolePeripheralOnly indicates that only the peripheral role is supported.
+	LERolePeripheralOnly LERole = 0x00
+	// LERoleCentralOnly indicates that only the central role is supported.
+	LERoleCentralOnly LERole = 0x01
+	// LERolePeripheralPreferred indicates that both roles are supported and the peripheral role is preferred for connection establishment.
+	LERolePeripheralPreferred LERole = 0x02
+	// LERoleCentralPreferred indicates that both roles are supported and the central role is preferred for connection establishment.
+	LERoleCentralPreferred LERole = 0x03
+)
+
+// String returns a string representation of the LE role.
+func (role LERole) String() string {
+	switch role {
+	case LERolePeripheralOnly:
+		return "PeripheralOnly"
+	case LERoleCentralOnly:
+		return "CentralOnly"
+	case LERolePeripheralPreferred:
+		return "PeripheralPreferred"
+	case LERoleCentralPreferred:
+		return "CentralPreferred"
+	default:
+		return fmt.Sprintf("0x%02X", uint8(role))
+	}
+}
@@ -16,6 +16,7 @@ package ble
 
 import (
 	"encoding/hex"
+	"fmt"
 	"strings"
 
 	"github.com/google/uuid"
@@ -32,3 +33,15 @@ func (addr Address) String() string {
 	}
 	return strings.ToUpper(hex.EncodeToString(addr))
 }
+
+// NewAddressFromString returns the Bluetooth address from the string representation returned by String.
+func NewAddressFromString(s string) (Address, error) {
+	if _, err := uuid.Parse(s); err == nil {
+		return Address(s), nil
+	}
+	b, err := hex.DecodeString(strings.ReplaceAll(s, ":", ""))
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("%w address: %s", ErrInvalid, s)
+	}
+	return Address(b), nil
+}
@@ -0,0 +1,564 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	advertisingIntervalUnit = 625 * time.Microsecond
+	connectionIntervalUnit  = 1250 * time.Microsecond
+	connectionIntervalNone  = 0xFFFF
+)
+
+// bluetoothBaseUUIDSuffix represents the last 12 bytes of the Bluetooth base UUID 00000000-0000-1000-8000-00805F9B34FB.
+var bluetoothBaseUUIDSuffix = []byte{0x00, 0x00, 0x10, 0x00, 0x80, 0x00, 0x00, 0x80, 0x5F, 0x9B, 0x34, 0xFB}
+
+// uriSchemes represents the URI scheme name string encodings in the Bluetooth SIG assigned numbers.
+var uriSchemes = map[byte]string{
+	0x01: "",
+	0x02: "aaa:",
+	0x03: "aaas:",
+	0x04: "about:",
+	0x05: "acap:",
+	0x06: "acct:",
+	0x07: "cap:",
+	0x08: "cid:",
+	0x09: "coap:",
+	0x0A: "coaps:",
+	0x0B: "crid:",
+	0x0C: "data:",
+	0x0D: "dav:",
+	0x0E: "dict:",
+	0x0F: "dns:",
+	0x10: "file:",
+	0x11: "ftp:",
+	0x12: "geo:",
+	0x13: "go:",
+	0x14: "gopher:",
+	0x15: "h323:",
+	0x16: "http:",
+	0x17: "https:",
+}
+
+// ADStructure represents a raw AD structure in advertising data.
+type ADStructure interface {
+	// Type returns the AD type.
+	Type() ADType
+	// Data returns the AD data.
+	Data() []byte
+	// MarshalObject returns an object suitable for marshaling to JSON.
+	MarshalObject() any
+	// String returns a string representation of the AD structure.
+	String() string
+}
+
+// Advertisement represents advertising data or scan response data parsed from raw bytes.
+type Advertisement interface {
+	// Flags returns the flags if they are present.
+	Flags() (ADFlags, bool)
+	// LocalName returns the complete local name, or the shortened local name if only it is present.
+	LocalName() string
+	// IsShortenedLocalName returns whether the local name is shortened.
+	IsShortenedLocalName() bool
+	// ServiceUUIDs returns the service UUIDs of the complete and incomplete lists.
+	ServiceUUIDs() []UUID
+	// SolicitationUUIDs returns the service solicitation UUIDs.
+	SolicitationUUIDs() []UUID
+	// ServiceData returns the service data elements.
+	ServiceData() []ServiceData
+	// ManufacturerData returns the manufacturer specific data elements.
+	ManufacturerData() []Manufacturer
+	// TxPower returns the TX power level in dBm if it is present.
+	TxPower() (int, bool)
+	// Appearance returns the appearance if it is present.
+	Appearance() (uint16, bool)
+	// URI returns the URI if it is present.
+	URI() (string, bool)
+	// LERole returns the LE role if it is present.
+	LERole() (LERole, bool)
+	// AdvertisingInterval returns the advertising interval if it is present.
+	AdvertisingInterval() (time.Duration, bool)
+	// ConnectionIntervalRange returns the peripheral connection interval range if it is present. An unspecified bound is returned as zero.
+	ConnectionIntervalRange() (time.Duration, time.Duration, bool)
+	// Structures returns all AD structures in the order they appear.
+	Structures() []ADStructure
+	// UnknownStructures returns the AD structures which are not decoded or are malformed.
+	UnknownStructures() []ADStructure
+	// Bytes returns the raw advertising data.
+	Bytes() []byte
+	// MarshalObject returns an object suitable for marshaling to JSON.
+	MarshalObject() any
+	// String returns a string representation of the advertisement.
+	String() string
+}
+
+type adStructure struct {
+	adType ADType
+	data   []byte
+}
+
+// Type returns the AD type.
+func (s *adStructure) Type() ADType {
+	return s.adType
+}
+
+// Data returns the AD data.
+func (s *adStructure) Data() []byte {
+	return s.data
+}
+
+// MarshalObject returns an object suitable for marshaling to JSON.
+func (s *adStructure) MarshalObject() any {
+	return struct {
+		Type int    `json:"type"`
+		Name string `json:"name"`
+		Data string `json:"data"`
+	}{
+		Type: int(s.adType),
+		Name: s.adType.Name(),
+		Data: strings.ToUpper(hex.EncodeToString(s.data)),
+	}
+}
+
+// String returns a string representation of the AD structure.
+func (s *adStructure) String() string {
+	b, err := json.Marshal(s.MarshalObject())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+type advertisement struct {
+	raw                 []byte
+	structures          []ADStructure
+	unknowns            []ADStructure
+	flags               *ADFlags
+	localName           string
+	shortened           bool
+	serviceUUIDs        []UUID
+	solicitationUUIDs   []UUID
+	serviceData         []ServiceData
+	manufacturerData    []Manufacturer
+	txPower             *int
+	appearance          *uint16
+	uri                 *string
+	leRole              *LERole
+	advertisingInterval *time.Duration
+	connIntervalMin     *time.Duration
+	connIntervalMax     *time.Duration
+}
+
+func newAdvertisement(b []byte) *advertisement {
+	return &advertisement{
+		raw:                 b,
+		structures:          []ADStructure{},
+		unknowns:            []ADStructure{},
+		flags:               nil,
+		localName:           "",
+		shortened:           false,
+		serviceUUIDs:        []UUID{},
+		solicitationUUIDs:   []UUID{},
+		serviceData:         []ServiceData{},
+		manufacturerData:    []Manufacturer{},
+		txPower:             nil,
+		appearance:          nil,
+		uri:                 nil,
+		leRole:              nil,
+		advertisingInterval: nil,
+		connIntervalMin:     nil,
+		connIntervalMax:     nil,
+	}
+}
+
+// ParseAdvertisement parses the raw advertising data or scan response data.
+// If the data is truncated, the structures parsed so far are returned with the error.
+func ParseAdvertisement(b []byte) (Advertisement, error) {
+	adv := newAdvertisement(b)
+	for offset := 0; offset < len(b); {
+		length := int(b[offset])
+		if length == 0 {
+			// The remaining data is padding.
+			break
+		}
+		if len(b) < offset+1+length {
+			return adv, fmt.Errorf("%w AD structure length: %d > %d", ErrInvalid, length, len(b)-offset-1)
+		}
+		s := &adStructure{
+			adType: ADType(b[offset+1]),
+			data:   b[offset+2 : offset+1+length],
+		}
+		adv.structures = append(adv.structures, s)
+		if !adv.decodeStructure(s) {
+			adv.unknowns = append(adv.unknowns, s)
+		}
+		offset += 1 + length
+	}
+	return adv, nil
+}
+
+// newAdvertisementFromScanResult returns the advertisement of the scan result.
+// If the backend does not provide the raw advertising data, it is rebuilt from the decoded fields.
+func newAdvertisementFromScanResult(scanResult ScanResult) Advertisement {
+	b := scanResult.Bytes()
+	if b == nil {
+		opts := []AdvertisingDataOption{
+			WithADLocalName(scanResult.LocalName()),
+			WithADServiceUUIDs(scanResult.ServiceUUIDs()...),
+		}
+		for _, sd := range scanResult.ServiceData() {
+			opts = append(opts, WithADServiceData(sd.UUID(), sd.Data()))
+		}
+		for _, md := range scanResult.ManufacturerData() {
+			opts = append(opts, WithADManufacturerData(md.ID(), md.Data()))
+		}
+		ad, ok := NewAdvertisingData(opts...).(*advertisingData)
+		if ok {
+			b, _ = ad.encode()
+		}
+	}
+	adv, _ := ParseAdvertisement(b)
+	return adv
+}
+
+// decodeStructure decodes the AD structure and returns false if the structure is unknown or malformed.
+func (adv *advertisement) decodeStructure(s ADStructure) bool {
+	data := s.Data()
+	switch s.Type() {
+	case ADTypeFlags:
+		if len(data) < 1 {
+			return false
+		}
+		flags := ADFlags(data[0])
+		adv.flags = &flags
+	case ADTypeShortenedLocalName:
+		if len(adv.localName) == 0 || adv.shortened {
+			adv.localName = string(data)
+			adv.shortened = true
+		}
+	case ADTypeCompleteLocalName:
+		adv.localName = string(data)
+		adv.shortened = false
+	case ADTypeIncompleteServiceUUIDs16, ADTypeCompleteServiceUUIDs16:
+		return adv.decodeUUIDs(data, 2, &adv.serviceUUIDs)
+	case ADTypeIncompleteServiceUUIDs32, ADTypeCompleteServiceUUIDs32:
+		return adv.decodeUUIDs(data, 4, &adv.serviceUUIDs)
+	case ADTypeIncompleteServiceUUIDs128, ADTypeCompleteServiceUUIDs128:
+		return adv.decodeUUIDs(data, 16, &adv.serviceUUIDs)
+	case ADTypeSolicitationUUIDs16:
+		return adv.decodeUUIDs(data, 2, &adv.solicitationUUIDs)
+	case ADTypeSolicitationUUIDs32:
+		return adv.decodeUUIDs(data, 4, &adv.solicitationUUIDs)
+	case ADTypeSolicitationUUIDs128:
+		return adv.decodeUUIDs(data, 16, &adv.solicitationUUIDs)
+	case ADTypeServiceData16:
+		return adv.decodeServiceData(data, 2)
+	case ADTypeServiceData32:
+		return adv.decodeServiceData(data, 4)
+	case ADTypeServiceData128:
+		return adv.decodeServiceData(data, 16)
+	case ADTypeManufacturerData:
+		if len(data) < 2 {
+			return false
+		}
+		id := int(binary.LittleEndian.Uint16(data))
+		adv.manufacturerData = append(adv.manufacturerData, NewManufacturer(id, data[2:]))
+	case ADTypeTxPowerLevel:
+		if len(data) != 1 {
+			return false
+		}
+		txPower := int(int8(data[0]))
+		adv.txPower = &txPower
+	case ADTypeAppearance:
+		if len(data) != 2 {
+			return false
+		}
+		appearance := binary.LittleEndian.Uint16(data)
+		adv.appearance = &appearance
+	case ADTypeURI:
+		if len(data) < 1 {
+			return false
+		}
+		scheme, ok := uriSchemes[data[0]]
+		if !ok {
+			return false
+		}
+		uri := scheme + string(data[1:])
+		adv.uri = &uri
+	case ADTypeLERole:
+		if len(data) != 1 {
+			return false
+		}
+		role := LERole(data[0])
+		adv.leRole = &role
+	case ADTypeAdvertisingInterval:
+		if len(data) != 2 {
+			return false
+		}
+		interval := time.Duration(binary.LittleEndian.Uint16(data)) * advertisingIntervalUnit
+		adv.advertisingInterval = &interval
+	case ADTypeAdvertisingIntervalLong:
+		if len(data) != 3 && len(data) != 4 {
+			return false
+		}
+		var v uint32
+		for n := len(data) - 1; 0 <= n; n-- {
+			v = v<<8 | uint32(data[n])
+		}
+		interval := time.Duration(v) * advertisingIntervalUnit
+		adv.advertisingInterval = &interval
+	case ADTypePeripheralConnectionIntervalRange:
+		if len(data) != 4 {
+			return false
+		}
+		toInterval := func(v uint16) *time.Duration {
+			interval := time.Duration(0)
+			if v != connectionIntervalNone {
+				interval = time.Duration(v) * connectionIntervalUnit
+			}
+			return &interval
+		}
+		adv.connIntervalMin = toInterval(binary.LittleEndian.Uint16(data[0:2]))
+		adv.connIntervalMax = toInterval(binary.LittleEndian.Uint16(data[2:4]))
+	default:
+		return false
+	}
+	return true
+}
+
+func (adv *advertisement) decodeUUIDs(data []byte, size int, uuids *[]UUID) bool {
+	if len(data)%size != 0 {
+		return false
+	}
+	for n := 0; n < len(data); n += size {
+		*uuids = append(*uuids, newUUIDFromADBytes(data[n:n+size]))
+	}
+	return true
+}
+
+func (adv *advertisement) decodeServiceData(data []byte, size int) bool {
+	if len(data) < size {
+		return false
+	}
+	uuid := newUUIDFromADBytes(data[:size])
+	adv.serviceData = append(adv.serviceData, NewServiceData(uuid, data[size:]))
+	return true
+}
+
+// Flags returns the flags if they are present.
+func (adv *advertisement) Flags() (ADFlags, bool) {
+	if adv.flags == nil {
+		return 0, false
+	}
+	return *adv.flags, true
+}
+
+// LocalName returns the complete local name, or the shortened local name if only it is present.
+func (adv *advertisement) LocalName() string {
+	return adv.localName
+}
+
+// IsShortenedLocalName returns whether the local name is shortened.
+func (adv *advertisement) IsShortenedLocalName() bool {
+	return adv.shortened
+}
+
+// ServiceUUIDs returns the service UUIDs of the complete and incomplete lists.
+func (adv *advertisement) ServiceUUIDs() []UUID {
+	return adv.serviceUUIDs
+}
+
+// SolicitationUUIDs returns the service solicitation UUIDs.
+func (adv *advertisement) SolicitationUUIDs() []UUID {
+	return adv.solicitationUUIDs
+}
+
+// ServiceData returns the service data elements.
+func (adv *advertisement) ServiceData() []ServiceData {
+	return adv.serviceData
+}
+
+// ManufacturerData returns the manufacturer specific data elements.
+func (adv *advertisement) ManufacturerData() []Manufacturer {
+	return adv.manufacturerData
+}
+
+// TxPower returns the TX power level in dBm if it is present.
+func (adv *advertisement) TxPower() (int, bool) {
+	if adv.txPower == nil {
+		return 0, false
+	}
+	return *adv.txPower, true
+}
+
+// Appearance returns the appearance if it is present.
+func (adv *advertisement) Appearance() (uint16, bool) {
+	if adv.appearance == nil {
+		return 0, false
+	}
+	return *adv.appearance, true
+}
+
+// URI returns the URI if it is present.
+func (adv *advertisement) URI() (string, bool) {
+	if adv.uri == nil {
+		return "", false
+	}
+	return *adv.uri, true
+}
+
+// LERole returns the LE role if it is present.
+func (adv *advertisement) LERole() (LERole, bool) {
+	if adv.leRole == nil {
+		return 0, false
+	}
+	return *adv.leRole, true
+}
+
+// AdvertisingInterval returns the advertising interval if it is present.
+func (adv *advertisement) AdvertisingInterval() (time.Duration, bool) {
+	if adv.advertisingInterval == nil {
+		return 0, false
+	}
+	return *adv.advertisingInterval, true
+}
+
+// ConnectionIntervalRange returns the peripheral connection interval range if it is present. An unspecified bound is returned as zero.
+func (adv *advertisement) ConnectionIntervalRange() (time.Duration, time.Duration, bool) {
+	if adv.connIntervalMin == nil || adv.connIntervalMax == nil {
+		return 0, 0, false
+	}
+	return *adv.connIntervalMin, *adv.connIntervalMax, true
+}
+
+// Structures returns all AD structures in the order they appear.
+func (adv *advertisement) Structures() []ADStructure {
+	return adv.structures
+}
+
+// UnknownStructures returns the AD structures which are not decoded or are malformed.
+func (adv *advertisement) UnknownStructures() []ADStructure {
+	return adv.unknowns
+}
+
+// Bytes returns the raw advertising data.
+func (adv *advertisement) Bytes() []byte {
+	return adv.raw
+}
+
+// MarshalObject returns an object suitable for marshaling to JSON.
+func (adv *advertisement) MarshalObject() any {
+	uuidStrings := func(uuids []UUID) []string {
+		strs := make([]string, 0, len(uuids))
+		for _, uuid := range uuids {
+			strs = append(strs, uuid.String())
+		}
+		return strs
+	}
+	serviceDataObjs := make([]any, 0, len(adv.serviceData))
+	for _, sd := range adv.serviceData {
+		serviceDataObjs = append(serviceDataObjs, sd.MarshalObject())
+	}
+	manufacturerObjs := make([]any, 0, len(adv.manufacturerData))
+	for _, md := range adv.manufacturerData {
+		manufacturerObjs = append(manufacturerObjs, md.MarshalObject())
+	}
+	unknownObjs := make([]any, 0, len(adv.unknowns))
+	for _, s := range adv.unknowns {
+		unknownObjs = append(unknownObjs, s.MarshalObject())
+	}
+	var leRole *string
+	if role, ok := adv.LERole(); ok {
+		roleStr := role.String()
+		leRole = &roleStr
+	}
+	var advInterval *string
+	if interval, ok := adv.AdvertisingInterval(); ok {
+		intervalStr := interval.String()
+		advInterval = &intervalStr
+	}
+	var connInterval *[]string
+	if minInterval, maxInterval, ok := adv.ConnectionIntervalRange(); ok {
+		connInterval = &[]string{minInterval.String(), maxInterval.String()}
+	}
+	return struct {
+		Flags                   *ADFlags  `json:"flags,omitempty"`
+		LocalName               string    `json:"localName,omitempty"`
+		ServiceUUIDs            []string  `json:"serviceUUIDs"`
+		SolicitationUUIDs       []string  `json:"solicitationUUIDs"`
+		ServiceData             []any     `json:"serviceData"`
+		ManufacturerData        []any     `json:"manufacturerData"`
+		TxPower                 *int      `json:"txPower,omitempty"`
+		Appearance              *uint16   `json:"appearance,omitempty"`
+		URI                     *string   `json:"uri,omitempty"`
+		LERole                  *string   `json:"leRole,omitempty"`
+		AdvertisingInterval     *string   `json:"advertisingInterval,omitempty"`
+		ConnectionIntervalRange *[]string `json:"connectionIntervalRange,omitempty"`
+		Unknowns                []any     `json:"unknowns"`
+	}{
+		Flags:                   adv.flags,
+		LocalName:               adv.localName,
+		ServiceUUIDs:            uuidStrings(adv.serviceUUIDs),
+		SolicitationUUIDs:       uuidStrings(adv.solicitationUUIDs),
+		ServiceData:             serviceDataObjs,
+		ManufacturerData:        manufacturerObjs,
+		TxPower:                 adv.txPower,
+		Appearance:              adv.appearance,
+		URI:                     adv.uri,
+		LERole:                  leRole,
+		AdvertisingInterval:     advInterval,
+		ConnectionIntervalRange: connInterval,
+		Unknowns:                unknownObjs,
+	}
+}
+
+// String returns a string representation of the advertisement.
+func (adv *advertisement) String() string {
+	b, err := json.Marshal(adv.MarshalObject())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// newUUIDFromADBytes returns the UUID from the little-endian representation used in advertising data.
+func newUUIDFromADBytes(b []byte) UUID {
+	switch len(b) {
+	case 2:
+		return NewUUIDFromUUID16(binary.LittleEndian.Uint16(b))
+	case 4:
+		return NewUUIDFromUUID32(binary.LittleEndian.Uint32(b))
+	}
+	be := make([]byte, len(b))
+	for n := range b {
+		be[n] = b[len(b)-1-n]
+	}
+	uuid, err := NewUUIDFromBytes(be)
+	if err != nil {
+		return NewNilUUID()
+	}
+	// Normalize UUIDs based on the Bluetooth base UUID to the 16-bit or 32-bit representation.
+	if string(be[4:]) == string(bluetoothBaseUUIDSuffix) {
+		return NewUUIDFromUUID32(binary.BigEndian.Uint32(be[0:4]))
+	}
+	return uuid
+}
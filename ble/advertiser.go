@@ -0,0 +1,68 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"time"
+)
+
+const (
+	// DefaultAdvertisingInterval is the default advertising interval.
+	DefaultAdvertisingInterval = time.Duration(100 * time.Millisecond)
+)
+
+// Advertiser represents a Bluetooth advertiser which broadcasts legacy advertisements.
+type Advertiser interface {
+	// StartAdvertising starts advertising the specified advertising data.
+	StartAdvertising(data AdvertisingData, opts ...AdvertiserOption) error
+	// StopAdvertising stops advertising.
+	StopAdvertising() error
+	// IsAdvertising returns whether the advertiser is advertising.
+	IsAdvertising() bool
+}
+
+// AdvertisingParameters represents the parameters of legacy advertising.
+type AdvertisingParameters struct {
+	// Interval is the advertising interval.
+	Interval time.Duration
+	// Connectable indicates whether centrals can connect to the advertiser.
+	Connectable bool
+	// ScanResponse is the scan response data, or nil if the advertiser does not respond to scan requests.
+	ScanResponse AdvertisingData
+}
+
+// AdvertiserOption represents an option for the advertiser.
+type AdvertiserOption func(*AdvertisingParameters)
+
+// WithAdvertisingInterval sets the advertising interval.
+func WithAdvertisingInterval(interval time.Duration) AdvertiserOption {
+	return func(params *AdvertisingParameters) {
+		params.Interval = interval
+	}
+}
+
+// WithAdvertisingConnectable sets whether centrals can connect to the advertiser.
+func WithAdvertisingConnectable(connectable bool) AdvertiserOption {
+	return func(params *AdvertisingParameters) {
+		params.Connectable = connectable
+	}
+}
+
+// WithAdvertisingScanResponse sets the scan response data.
+func WithAdvertisingScanResponse(data AdvertisingData) AdvertiserOption {
+	return func(params *AdvertisingParameters) {
+		params.ScanResponse = data
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"sync"
+)
+
+type backendAdvertiser struct {
+	sync.Mutex
+	backend     AdvertiserBackend
+	enabled     bool
+	advertising bool
+}
+
+// NewAdvertiser creates a new Bluetooth advertiser with the default backend.
+func NewAdvertiser() Advertiser {
+	return NewAdvertiserWithBackend(sharedBackend)
+}
+
+// NewAdvertiserWithBackend creates a new Bluetooth advertiser with the specified backend.
+func NewAdvertiserWithBackend(backend AdvertiserBackend) Advertiser {
+	return &backendAdvertiser{
+		Mutex:       sync.Mutex{},
+		backend:     backend,
+		enabled:     false,
+		advertising: false,
+	}
+}
+
+// StartAdvertising starts advertising the specified advertising data.
+func (adv *backendAdvertiser) StartAdvertising(data AdvertisingData, opts ...AdvertiserOption) error {
+	params := AdvertisingParameters{
+		Interval:     DefaultAdvertisingInterval,
+		Connectable:  true,
+		ScanResponse: nil,
+	}
+	for _, opt := range opts {
+		opt(&params)
+	}
+
+	// Validate the payloads before passing them to the backend.
+	if _, err := data.Bytes(); err != nil {
+		return err
+	}
+	if params.ScanResponse != nil {
+		if _, err := params.ScanResponse.Bytes(); err != nil {
+			return err
+		}
+	}
+
+	adv.Lock()
+	defer adv.Unlock()
+
+	if !adv.enabled {
+		if err := adv.backend.Enable(); err != nil {
+			return err
+		}
+		adv.enabled = true
+	}
+
+	if adv.advertising {
+		if err := adv.backend.StopAdvertising(); err != nil {
+			return err
+		}
+		adv.advertising = false
+	}
+
+	if err := adv.backend.StartAdvertising(data, params); err != nil {
+		return err
+	}
+	adv.advertising = true
+	return nil
+}
+
+// StopAdvertising stops advertising.
+func (adv *backendAdvertiser) StopAdvertising() error {
+	adv.Lock()
+	defer adv.Unlock()
+	if !adv.advertising {
+		return nil
+	}
+	if err := adv.backend.StopAdvertising(); err != nil {
+		return err
+	}
+	adv.advertising = false
+	return nil
+}
+
+// IsAdvertising returns whether the advertiser is advertising.
+func (adv *backendAdvertiser) IsAdvertising() bool {
+	adv.Lock()
+	defer adv.Unlock()
+	return adv.advertising
+}
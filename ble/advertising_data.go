@@ -0,0 +1,346 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+)
+
+// AdvertisingData represents legacy advertising data or scan response data assembled from typed elements.
+type AdvertisingData interface {
+	// Flags returns the flags if they are set.
+	Flags() (ADFlags, bool)
+	// LocalName returns the local name.
+	LocalName() string
+	// IsShortenedLocalName returns whether the local name is shortened.
+	IsShortenedLocalName() bool
+	// ServiceUUIDs returns the complete list of service UUIDs.
+	ServiceUUIDs() []UUID
+	// ServiceData returns the service data elements.
+	ServiceData() []ServiceData
+	// ManufacturerData returns the manufacturer specific data elements.
+	ManufacturerData() []Manufacturer
+	// TxPower returns the TX power level in dBm if it is set.
+	TxPower() (int, bool)
+	// Appearance returns the appearance if it is set.
+	Appearance() (uint16, bool)
+	// Bytes returns the encoded advertising data.
+	Bytes() ([]byte, error)
+	// MarshalObject returns an object suitable for marshaling to JSON.
+	MarshalObject() any
+	// String returns a string representation of the advertising data.
+	String() string
+}
+
+// AdvertisingDataOption represents an option for advertising data.
+type AdvertisingDataOption func(*advertisingData)
+
+// WithADFlags sets the flags of the advertising data.
+func WithADFlags(flags ADFlags) AdvertisingDataOption {
+	return func(ad *advertisingData) {
+		ad.flags = &flags
+	}
+}
+
+// WithADLocalName sets the complete local name of the advertising data.
+func WithADLocalName(name string) AdvertisingDataOption {
+	return func(ad *advertisingData) {
+		ad.localName = name
+		ad.shortened = false
+	}
+}
+
+// WithADShortenedLocalName sets the shortened local name of the advertising data.
+func WithADShortenedLocalName(name string) AdvertisingDataOption {
+	return func(ad *advertisingData) {
+		ad.localName = name
+		ad.shortened = true
+	}
+}
+
+// WithADServiceUUIDs adds the service UUIDs to the advertising data.
+func WithADServiceUUIDs(uuids ...UUID) AdvertisingDataOption {
+	return func(ad *advertisingData) {
+		ad.serviceUUIDs = append(ad.serviceUUIDs, uuids...)
+	}
+}
+
+// WithADServiceData adds or replaces the service data element of the advertising data.
+func WithADServiceData(uuid UUID, data []byte) AdvertisingDataOption {
+	return func(ad *advertisingData) {
+		elems := make([]ServiceData, 0, len(ad.serviceData)+1)
+		for _, sd := range ad.serviceData {
+			if !sd.UUID().Equal(uuid) {
+				elems = append(elems, sd)
+			}
+		}
+		ad.serviceData = append(elems, NewServiceData(uuid, data))
+	}
+}
+
+// WithADManufacturerData adds or replaces the manufacturer specific data element of the advertising data keyed by the company ID.
+func WithADManufacturerData(id int, data []byte) AdvertisingDataOption {
+	return func(ad *advertisingData) {
+		elems := make([]Manufacturer, 0, len(ad.manufacturerData)+1)
+		for _, md := range ad.manufacturerData {
+			if md.ID() != id {
+				elems = append(elems, md)
+			}
+		}
+		ad.manufacturerData = append(elems, NewManufacturer(id, data))
+	}
+}
+
+// WithADTxPower sets the TX power level in dBm of the advertising data.
+func WithADTxPower(dbm int) AdvertisingDataOption {
+	return func(ad *advertisingData) {
+		ad.txPower = &dbm
+	}
+}
+
+// WithADAppearance sets the appearance of the advertising data.
+func WithADAppearance(appearance uint16) AdvertisingDataOption {
+	return func(ad *advertisingData) {
+		ad.appearance = &appearance
+	}
+}
+
+type advertisingData struct {
+	flags            *ADFlags
+	localName        string
+	shortened        bool
+	serviceUUIDs     []UUID
+	serviceData      []ServiceData
+	manufacturerData []Manufacturer
+	txPower          *int
+	appearance       *uint16
+}
+
+// NewAdvertisingData returns new advertising data with the specified options.
+func NewAdvertisingData(opts ...AdvertisingDataOption) AdvertisingData {
+	ad := &advertisingData{
+		flags:            nil,
+		localName:        "",
+		shortened:        false,
+		serviceUUIDs:     []UUID{},
+		serviceData:      []ServiceData{},
+		manufacturerData: []Manufacturer{},
+		txPower:          nil,
+		appearance:       nil,
+	}
+	for _, opt := range opts {
+		opt(ad)
+	}
+	return ad
+}
+
+// Flags returns the flags if they are set.
+func (ad *advertisingData) Flags() (ADFlags, bool) {
+	if ad.flags == nil {
+		return 0, false
+	}
+	return *ad.flags, true
+}
+
+// LocalName returns the local name.
+func (ad *advertisingData) LocalName() string {
+	return ad.localName
+}
+
+// IsShortenedLocalName returns whether the local name is shortened.
+func (ad *advertisingData) IsShortenedLocalName() bool {
+	return ad.shortened
+}
+
+// ServiceUUIDs returns the complete list of service UUIDs.
+func (ad *advertisingData) ServiceUUIDs() []UUID {
+	return ad.serviceUUIDs
+}
+
+// ServiceData returns the service data elements.
+func (ad *advertisingData) ServiceData() []ServiceData {
+	return ad.serviceData
+}
+
+// ManufacturerData returns the manufacturer specific data elements.
+func (ad *advertisingData) ManufacturerData() []Manufacturer {
+	return ad.manufacturerData
+}
+
+// TxPower returns the TX power level in dBm if it is set.
+func (ad *advertisingData) TxPower() (int, bool) {
+	if ad.txPower == nil {
+		return 0, false
+	}
+	return *ad.txPower, true
+}
+
+// Appearance returns the appearance if it is set.
+func (ad *advertisingData) Appearance() (uint16, bool) {
+	if ad.appearance == nil {
+		return 0, false
+	}
+	return *ad.appearance, true
+}
+
+// Bytes returns the encoded advertising data.
+func (ad *advertisingData) Bytes() ([]byte, error) {
+	b, err := ad.encode()
+	if err != nil {
+		return nil, err
+	}
+	if LegacyAdvertisingDataMaxSize < len(b) {
+		return nil, fmt.Errorf("%w size: %d > %d", ErrInvalid, len(b), LegacyAdvertisingDataMaxSize)
+	}
+	return b, nil
+}
+
+// encode returns the AD structures of the advertising data without checking the legacy size limit.
+func (ad *advertisingData) encode() ([]byte, error) {
+	b := []byte{}
+	appendElement := func(adType ADType, data []byte) {
+		b = append(b, byte(len(data)+1), byte(adType))
+		b = append(b, data...)
+	}
+
+	if flags, ok := ad.Flags(); ok {
+		appendElement(ADTypeFlags, []byte{byte(flags)})
+	}
+
+	uuids16 := []byte{}
+	uuids32 := []byte{}
+	uuids128 := []byte{}
+	for _, uuid := range ad.serviceUUIDs {
+		switch {
+		case uuid.IsUUID16():
+			uuids16 = append(uuids16, uuidToADBytes(uuid)...)
+		case uuid.IsUUID32():
+			uuids32 = append(uuids32, uuidToADBytes(uuid)...)
+		default:
+			uuids128 = append(uuids128, uuidToADBytes(uuid)...)
+		}
+	}
+	if 0 < len(uuids16) {
+		appendElement(ADTypeCompleteServiceUUIDs16, uuids16)
+	}
+	if 0 < len(uuids32) {
+		appendElement(ADTypeCompleteServiceUUIDs32, uuids32)
+	}
+	if 0 < len(uuids128) {
+		appendElement(ADTypeCompleteServiceUUIDs128, uuids128)
+	}
+
+	if 0 < len(ad.localName) {
+		if ad.shortened {
+			appendElement(ADTypeShortenedLocalName, []byte(ad.localName))
+		} else {
+			appendElement(ADTypeCompleteLocalName, []byte(ad.localName))
+		}
+	}
+
+	if txPower, ok := ad.TxPower(); ok {
+		if txPower < -127 || 127 < txPower {
+			return nil, fmt.Errorf("%w TX power: %d", ErrInvalid, txPower)
+		}
+		appendElement(ADTypeTxPowerLevel, []byte{byte(int8(txPower))})
+	}
+
+	if appearance, ok := ad.Appearance(); ok {
+		appendElement(ADTypeAppearance, binary.LittleEndian.AppendUint16(nil, appearance))
+	}
+
+	for _, sd := range ad.serviceData {
+		uuid := sd.UUID()
+		data := append(uuidToADBytes(uuid), sd.Data()...)
+		switch {
+		case uuid.IsUUID16():
+			appendElement(ADTypeServiceData16, data)
+		case uuid.IsUUID32():
+			appendElement(ADTypeServiceData32, data)
+		default:
+			appendElement(ADTypeServiceData128, data)
+		}
+	}
+
+	for _, md := range ad.manufacturerData {
+		if md.ID() < 0 || 0xFFFF < md.ID() {
+			return nil, fmt.Errorf("%w company ID: %d", ErrInvalid, md.ID())
+		}
+		data := binary.LittleEndian.AppendUint16(nil, uint16(md.ID()))
+		appendElement(ADTypeManufacturerData, append(data, md.Data()...))
+	}
+
+	return b, nil
+}
+
+// MarshalObject returns an object suitable for marshaling to JSON.
+func (ad *advertisingData) MarshalObject() any {
+	uuids := make([]string, 0, len(ad.serviceUUIDs))
+	for _, uuid := range ad.serviceUUIDs {
+		uuids = append(uuids, uuid.String())
+	}
+	serviceDataObjs := make([]any, 0, len(ad.serviceData))
+	for _, sd := range ad.serviceData {
+		serviceDataObjs = append(serviceDataObjs, sd.MarshalObject())
+	}
+	manufacturerObjs := make([]any, 0, len(ad.manufacturerData))
+	for _, md := range ad.manufacturerData {
+		manufacturerObjs = append(manufacturerObjs, md.MarshalObject())
+	}
+	return struct {
+		Flags            *ADFlags `json:"flags,omitempty"`
+		LocalName        string   `json:"localName,omitempty"`
+		ServiceUUIDs     []string `json:"serviceUUIDs"`
+		ServiceData      []any    `json:"serviceData"`
+		ManufacturerData []any    `json:"manufacturerData"`
+		TxPower          *int     `json:"txPower,omitempty"`
+		Appearance       *uint16  `json:"appearance,omitempty"`
+	}{
+		Flags:            ad.flags,
+		LocalName:        ad.localName,
+		ServiceUUIDs:     uuids,
+		ServiceData:      serviceDataObjs,
+		ManufacturerData: manufacturerObjs,
+		TxPower:          ad.txPower,
+		Appearance:       ad.appearance,
+	}
+}
+
+// String returns a string representation of the advertising data.
+func (ad *advertisingData) String() string {
+	b, err := json.Marshal(ad.MarshalObject())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// uuidToADBytes returns the little-endian representation of the UUID used in advertising data.
+func uuidToADBytes(uuid UUID) []byte {
+	if u16, ok := uuid.UUID16(); ok {
+		return binary.LittleEndian.AppendUint16(nil, u16)
+	}
+	if u32, ok := uuid.UUID32(); ok {
+		return binary.LittleEndian.AppendUint32(nil, u32)
+	}
+	b := uuid.Bytes()
+	le := make([]byte, len(b))
+	for n := range b {
+		le[n] = b[len(b)-1-n]
+	}
+	return le
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// ATTErrorCode represents an ATT error code returned in an Error Response.
+type ATTErrorCode uint8
+
+const (
+	// ATTErrorInvalidHandle represents the invalid handle error code.
+	ATTErrorInvalidHandle ATTErrorCode = 0x01
+	// ATTErrorReadNotPermitted represents the read not permitted error code.
+	ATTErrorReadNotPermitted ATTErrorCode = 0x02
+	// ATTErrorWriteNotPermitted represents the write not permitted error code.
+	ATTErrorWriteNotPermitted ATTErrorCode = 0x03
+	// ATTErrorInvalidPDU represents the invalid PDU error code.
+	ATTErrorInvalidPDU ATTErrorCode = 0x04
+	// ATTErrorInsufficientAuthentication represents the insufficient authentication error code.
+	ATTErrorInsufficientAuthentication ATTErrorCode = 0x05
+	// ATTErrorRequestNotSupported represents the request not supported error code.
+	ATTErrorRequestNotSupported ATTErrorCode = 0x06
+	// ATTErrorInvalidOffset represents the invalid offset error code.
+	ATTErrorInvalidOffset ATTErrorCode = 0x07
+	// ATTErrorInsufficientAuthorization represents the insufficient authorization error code.
+	ATTErrorInsufficientAuthorization ATTErrorCode = 0x08
+	// ATTErrorPrepareQueueFull represents the prepare queue full error code.
+	ATTErrorPrepareQueueFull ATTErrorCode = 0x09
+	// ATTErrorAttributeNotFound represents the attribute not found error code.
+	ATTErrorAttributeNotFound ATTErrorCode = 0x0A
+	// ATTErrorAttributeNotLong represents the attribute not long error code.
+	ATTErrorAttributeNotLong ATTErrorCode = 0x0B
+	// ATTErrorInsufficientEncryptionKeySize represents the insufficient encryption key size error code.
+	ATTErrorInsufficientEncryptionKeySize ATTErrorCode = 0x0C
+	// ATTErrorInvalidAttributeValueLength represents the invalid attribute value length error code.
+	ATTErrorInvalidAttributeValueLength ATTErrorCode = 0x0D
+	// ATTErrorUnlikely represents the unlikely error code.
+	ATTErrorUnlikely ATTErrorCode = 0x0E
+	// ATTErrorInsufficientEncryption represents the insufficient encryption error code.
+	ATTErrorInsufficientEncryption ATTErrorCode = 0x0F
+	// ATTErrorUnsupportedGroupType represents the unsupported group type error code.
+	ATTErrorUnsupportedGroupType ATTErrorCode = 0x10
+	// ATTErrorInsufficientResources represents the insufficient resources error code.
+	ATTErrorInsufficientResources ATTErrorCode = 0x11
+	// ATTErrorDatabaseOutOfSync represents the database out of sync error code.
+	ATTErrorDatabaseOutOfSync ATTErrorCode = 0x12
+	// ATTErrorValueNotAllowed represents the value not allowed error code.
+	ATTErrorValueNotAllowed ATTErrorCode = 0x13
+	// ATTErrorWriteRequestRejected represents the write request rejected error code.
+	ATTErrorWriteRequestRejected ATTErrorCode = 0xFC
+	// ATTErrorCCCDImproperlyConfigured represents the CCCD improperly configured error code.
+	ATTErrorCCCDImproperlyConfigured ATTErrorCode = 0xFD
+	// ATTErrorProcedureAlreadyInProgress represents the procedure already in progress error code.
+	ATTErrorProcedureAlreadyInProgress ATTErrorCode = 0xFE
+	// ATTErrorOutOfRange represents the out of range error code.
+	ATTErrorOutOfRange ATTErrorCode = 0xFF
+)
+
+var attErrorCodeNames = map[ATTErrorCode]string{
+	ATTErrorInvalidHandle:                 "invalid handle",
+	ATTErrorReadNotPermitted:              "read not permitted",
+	ATTErrorWriteNotPermitted:             "write not permitted",
+	ATTErrorInvalidPDU:                    "invalid PDU",
+	ATTErrorInsufficientAuthentication:    "insufficient authentication",
+	ATTErrorRequestNotSupported:           "request not supported",
+	ATTErrorInvalidOffset:                 "invalid offset",
+	ATTErrorInsufficientAuthorization:     "insufficient authorization",
+	ATTErrorPrepareQueueFull:              "prepare queue full",
+	ATTErrorAttributeNotFound:             "attribute not found",
+	ATTErrorAttributeNotLong:              "attribute not long",
+	ATTErrorInsufficientEncryptionKeySize: "insufficient encryption key size",
+	ATTErrorInvalidAttributeValueLength:   "invalid attribute value length",
+	ATTErrorUnlikely:                      "unlikely error",
+	ATTErrorInsufficientEncryption:        "insufficient encryption",
+	ATTErrorUnsupportedGroupType:          "unsupported group type",
+	ATTErrorInsufficientResources:         "insufficient resources",
+	ATTErrorDatabaseOutOfSync:             "database out of sync",
+	ATTErrorValueNotAllowed:               "value not allowed",
+	ATTErrorWriteRequestRejected:          "write request rejected",
+	ATTErrorCCCDImproperlyConfigured:      "CCCD improperly configured",
+	ATTErrorProcedureAlreadyInProgress:    "procedure already in progress",
+	ATTErrorOutOfRange:                    "out of range",
+}
+
+// IsApplicationError returns whether the code is in the range reserved for application errors.
+func (code ATTErrorCode) IsApplicationError() bool {
+	return 0x80 <= code && code <= 0x9F
+}
+
+// String returns the name of the ATT error code.
+func (code ATTErrorCode) String() string {
+	if name, ok := attErrorCodeNames[code]; ok {
+		return name
+	}
+	if code.IsApplicationError() {
+		return "application error"
+	}
+	return "reserved"
+}
+
+// ATTError represents an ATT Error Response returned by the peer for a request.
+type ATTError struct {
+	// Code is the ATT error code.
+	Code ATTErrorCode
+	// Err is the error reported by the backend, or nil.
+	Err error
+}
+
+// NewATTError returns a new ATT error with the specified code and the backend error.
+func NewATTError(code ATTErrorCode, err error) *ATTError {
+	return &ATTError{
+		Code: code,
+		Err:  err,
+	}
+}
+
+// Error returns the string representation of the ATT error.
+func (e *ATTError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("ATT error 0x%02X (%s)", uint8(e.Code), e.Code.String())
+	}
+	return fmt.Sprintf("ATT error 0x%02X (%s): %s", uint8(e.Code), e.Code.String(), e.Err.Error())
+}
+
+// Unwrap returns the generic error which corresponds to the ATT error code and the backend error,
+// so that errors.Is matches, for example, ErrNotPermitted for ATTErrorWriteNotPermitted.
+func (e *ATTError) Unwrap() []error {
+	errs := []error{}
+	switch e.Code {
+	case ATTErrorReadNotPermitted, ATTErrorWriteNotPermitted,
+		ATTErrorInsufficientAuthentication, ATTErrorInsufficientAuthorization,
+		ATTErrorInsufficientEncryption, ATTErrorInsufficientEncryptionKeySize,
+		ATTErrorWriteRequestRejected:
+		errs = append(errs, ErrNotPermitted)
+	case ATTErrorRequestNotSupported, ATTErrorAttributeNotLong, ATTErrorUnsupportedGroupType:
+		errs = append(errs, ErrNotSupported)
+	case ATTErrorInvalidHandle, ATTErrorAttributeNotFound:
+		errs = append(errs, ErrNotFound)
+	case ATTErrorInvalidPDU, ATTErrorInvalidOffset, ATTErrorInvalidAttributeValueLength,
+		ATTErrorValueNotAllowed, ATTErrorOutOfRange:
+		errs = append(errs, ErrInvalid)
+	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+var attErrorMessageRegexp = regexp.MustCompile(`ATT error: 0x([0-9A-Fa-f]{1,2})`)
+
+// newATTErrorFromMessage returns the ATT error if the error message reports an ATT error code such as "ATT error: 0x03".
+func newATTErrorFromMessage(err error) (*ATTError, bool) {
+	var attErr *ATTError
+	if errors.As(err, &attErr) {
+		return attErr, true
+	}
+	m := attErrorMessageRegexp.FindStringSubmatch(err.Error())
+	if m == nil {
+		return nil, false
+	}
+	code, perr := strconv.ParseUint(m[1], 16, 8)
+	if perr != nil {
+		return nil, false
+	}
+	return NewATTError(ATTErrorCode(code), err), true
+}
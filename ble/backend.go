@@ -0,0 +1,141 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+)
+
+// BackendScanHandler defines a handler function for advertisements received by a backend.
+type BackendScanHandler func(ScanResult)
+
+// Backend represents a Bluetooth backend which provides access to a Bluetooth adapter.
+type Backend interface {
+	// Enable enables the Bluetooth adapter.
+	Enable() error
+	// Scan scans for advertisements and calls the handler for each received advertisement until StopScan is called.
+	Scan(handler BackendScanHandler) error
+	// StopScan stops scanning.
+	StopScan() error
+	// Connect connects to the device with the specified address using the parameters.
+	Connect(ctx context.Context, addr Address, params ConnectionParameters) (BackendConnection, error)
+}
+
+// ScanResult represents an advertisement received by a backend.
+type ScanResult interface {
+	// Address returns the Bluetooth address of the advertiser.
+	Address() Address
+	// RSSI returns the received signal strength indicator of the advertisement.
+	RSSI() int
+	// LocalName returns the local name in the advertisement.
+	LocalName() string
+	// ServiceUUIDs returns the service UUIDs in the advertisement.
+	ServiceUUIDs() []UUID
+	// ServiceData returns the service data elements in the advertisement.
+	ServiceData() []ServiceData
+	// ManufacturerData returns the manufacturer specific data elements in the advertisement.
+	ManufacturerData() []Manufacturer
+	// Bytes returns the raw advertising data, or nil if the backend does not provide it.
+	Bytes() []byte
+}
+
+// BackendConnection represents a connection to a remote device provided by a backend.
+type BackendConnection interface {
+	// Disconnect disconnects from the remote device.
+	Disconnect() error
+	// MTU returns the negotiated ATT MTU of the connection.
+	MTU() int
+	// DiscoverServices discovers the specified primary and secondary services. All services are returned if no UUIDs are specified.
+	DiscoverServices(uuids []UUID) ([]BackendService, error)
+}
+
+// BackendService represents a remote service provided by a backend.
+type BackendService interface {
+	// UUID returns the service UUID.
+	UUID() UUID
+	// IsPrimary returns whether the service is a primary service. Backends which do not report it return true.
+	IsPrimary() bool
+	// IncludedServiceUUIDs returns the UUIDs of the services included by the service, or nil if the backend does not report them.
+	IncludedServiceUUIDs() []UUID
+	// DiscoverCharacteristics discovers the specified characteristics. All characteristics are returned if no UUIDs are specified.
+	DiscoverCharacteristics(uuids []UUID) ([]BackendCharacteristic, error)
+}
+
+// BackendCharacteristic represents a remote characteristic provided by a backend.
+type BackendCharacteristic interface {
+	// UUID returns the characteristic UUID.
+	UUID() UUID
+	// Properties returns the characteristic properties, or zero if the backend does not report them.
+	Properties() CharacteristicProperties
+	// Read reads the characteristic value. Backends which implement BackendBlobReader may return only the first part of a long value.
+	Read() ([]byte, error)
+	// Write writes the characteristic value with response, using Prepare Write and Execute Write requests if the value exceeds the ATT MTU.
+	Write(data []byte) (int, error)
+	// WriteWithoutResponse writes the characteristic value without waiting for a response. The value must fit in the ATT MTU.
+	WriteWithoutResponse(data []byte) (int, error)
+	// EnableNotifications enables notifications with the specified callback, or disables them if the callback is nil.
+	// Backends which cannot disable notifications return ErrNotSupported for the nil callback, and the callback is replaced with one which drops the values instead.
+	EnableNotifications(callback func(buf []byte)) error
+	// EnableIndications enables indications with the specified callback, or disables them if the callback is nil.
+	// The backend confirms each indication after the callback returns. Backends which cannot select indications return ErrNotSupported.
+	EnableIndications(callback func(buf []byte)) error
+	// DiscoverDescriptors discovers the specified descriptors. All descriptors are returned if no UUIDs are specified.
+	// Backends which cannot discover descriptors return ErrNotSupported.
+	DiscoverDescriptors(uuids []UUID) ([]BackendDescriptor, error)
+}
+
+// BackendDescriptor represents a remote characteristic descriptor provided by a backend.
+type BackendDescriptor interface {
+	// UUID returns the descriptor UUID.
+	UUID() UUID
+	// Read reads the descriptor value.
+	Read() ([]byte, error)
+	// Write writes the descriptor value with response.
+	Write(data []byte) (int, error)
+}
+
+// BackendBlobReader represents a backend characteristic which reads long values part by part.
+// Backends whose Read already returns the full value do not need to implement it.
+type BackendBlobReader interface {
+	// ReadBlob reads the part of the characteristic value starting at the offset with a Read Blob request.
+	ReadBlob(offset int) ([]byte, error)
+}
+
+// PeripheralBackend represents a Bluetooth backend which supports the peripheral role.
+type PeripheralBackend interface {
+	// Enable enables the Bluetooth adapter.
+	Enable() error
+	// AddService registers the local service and returns a backend handle for each characteristic in the same order as the service characteristics.
+	// The backend calls LocalCharacteristic.ReadValue and LocalCharacteristic.WriteValue to serve requests from centrals.
+	AddService(service LocalService) ([]BackendLocalCharacteristic, error)
+}
+
+// BackendLocalCharacteristic represents a local characteristic registered to a backend.
+type BackendLocalCharacteristic interface {
+	// SetValue updates the characteristic value served by the backend.
+	SetValue(data []byte) error
+	// Notify sends the characteristic value to the subscribed centrals by notification or indication.
+	Notify(data []byte) error
+}
+
+// AdvertiserBackend represents a Bluetooth backend which supports legacy advertising.
+type AdvertiserBackend interface {
+	// Enable enables the Bluetooth adapter.
+	Enable() error
+	// StartAdvertising starts advertising the specified advertising data with the parameters.
+	StartAdvertising(data AdvertisingData, params AdvertisingParameters) error
+	// StopAdvertising stops advertising.
+	StopAdvertising() error
+}
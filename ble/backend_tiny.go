@@ -0,0 +1,276 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"tinygo.org/x/bluetooth"
+)
+
+var sharedBackend = newTinyBackend(bluetooth.DefaultAdapter)
+
+// DefaultBackend returns the default backend which is based on the TinyGo Bluetooth package.
+func DefaultBackend() Backend {
+	return sharedBackend
+}
+
+type tinyBackend struct {
+	adapter *bluetooth.Adapter
+}
+
+func newTinyBackend(adapter *bluetooth.Adapter) *tinyBackend {
+	return &tinyBackend{
+		adapter: adapter,
+	}
+}
+
+// Enable enables the Bluetooth adapter.
+func (backend *tinyBackend) Enable() error {
+	return backend.adapter.Enable()
+}
+
+// Scan scans for advertisements and calls the handler for each received advertisement until StopScan is called.
+func (backend *tinyBackend) Scan(handler BackendScanHandler) error {
+	return backend.adapter.Scan(func(adapter *bluetooth.Adapter, scanRes bluetooth.ScanResult) {
+		if handler == nil {
+			return
+		}
+		handler(newTinyScanResult(scanRes))
+	})
+}
+
+// StopScan stops scanning.
+func (backend *tinyBackend) StopScan() error {
+	return backend.adapter.StopScan()
+}
+
+// Connect connects to the device with the specified address using the parameters.
+// The TinyGo Bluetooth package cannot request an MTU, so the MTU is negotiated automatically by the platform and the requested MTU only caps the reported MTU.
+// The context deadline is passed to the platforms which support a connection timeout, and a connection established after the context ends is disconnected.
+func (backend *tinyBackend) Connect(ctx context.Context, addr Address, params ConnectionParameters) (BackendConnection, error) {
+	tinyAddr, err := addressToTiny(addr)
+	if err != nil {
+		return nil, err
+	}
+	connParams := bluetooth.ConnectionParams{} // nolint: exhaustruct
+	if deadline, ok := ctx.Deadline(); ok {
+		connParams.ConnectionTimeout = tinyConnectionTimeout(time.Until(deadline))
+	}
+	tinyDev, _, err := doStateContext(ctx, func() (bluetooth.Device, error) {
+		return backend.adapter.Connect(tinyAddr, connParams)
+	}, func(tinyDev bluetooth.Device, err error) {
+		if err == nil {
+			_ = tinyDev.Disconnect()
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tinyConnection{
+		Mutex:        sync.Mutex{},
+		tinyDev:      tinyDev,
+		requestedMTU: params.MTU,
+		mtuChar:      nil,
+	}, nil
+}
+
+// tinyConnectionTimeout returns the connection timeout in the 0.625 ms units of the TinyGo Bluetooth package, capped to the largest one.
+func tinyConnectionTimeout(timeout time.Duration) bluetooth.Duration {
+	const unit = 625 * time.Microsecond
+	return bluetooth.Duration(min(max(timeout/unit, 1), math.MaxUint16))
+}
+
+type tinyScanResult struct {
+	bluetooth.ScanResult
+}
+
+func newTinyScanResult(scanRes bluetooth.ScanResult) *tinyScanResult {
+	return &tinyScanResult{
+		ScanResult: scanRes,
+	}
+}
+
+// Address returns the Bluetooth address of the advertiser.
+func (scanRes *tinyScanResult) Address() Address {
+	addr, _ := newAddressFromTiny(scanRes.ScanResult.Address)
+	return addr
+}
+
+// RSSI returns the received signal strength indicator of the advertisement.
+func (scanRes *tinyScanResult) RSSI() int {
+	return int(scanRes.ScanResult.RSSI)
+}
+
+// ServiceUUIDs returns the service UUIDs in the advertisement.
+func (scanRes *tinyScanResult) ServiceUUIDs() []UUID {
+	tinyUUIDs := scanRes.ScanResult.ServiceUUIDs()
+	uuids := make([]UUID, 0, len(tinyUUIDs))
+	for _, tinyUUID := range tinyUUIDs {
+		uuids = append(uuids, newUUIDFromTiny(tinyUUID))
+	}
+	return uuids
+}
+
+// ServiceData returns the service data elements in the advertisement.
+func (scanRes *tinyScanResult) ServiceData() []ServiceData {
+	tinyElems := scanRes.ScanResult.ServiceData()
+	elems := make([]ServiceData, 0, len(tinyElems))
+	for _, sd := range tinyElems {
+		elems = append(elems, NewServiceData(newUUIDFromTiny(sd.UUID), sd.Data))
+	}
+	return elems
+}
+
+// ManufacturerData returns the manufacturer specific data elements in the advertisement.
+func (scanRes *tinyScanResult) ManufacturerData() []Manufacturer {
+	tinyElems := scanRes.ScanResult.ManufacturerData()
+	elems := make([]Manufacturer, 0, len(tinyElems))
+	for _, md := range tinyElems {
+		elems = append(elems, NewManufacturer(int(md.CompanyID), md.Data))
+	}
+	return elems
+}
+
+type tinyConnection struct {
+	sync.Mutex
+	tinyDev      bluetooth.Device
+	requestedMTU int
+	mtuChar      *bluetooth.DeviceCharacteristic
+}
+
+// Disconnect disconnects from the remote device.
+func (conn *tinyConnection) Disconnect() error {
+	return conn.tinyDev.Disconnect()
+}
+
+// MTU returns the negotiated ATT MTU of the connection.
+// The platforms report the MTU per characteristic, so DefaultATTMTU is returned until a characteristic is discovered.
+// The MTU is read from the platform every time so that an MTU exchange after the discovery is reflected.
+func (conn *tinyConnection) MTU() int {
+	conn.Lock()
+	mtuChar := conn.mtuChar
+	conn.Unlock()
+	mtu := DefaultATTMTU
+	if mtuChar != nil {
+		if n, err := mtuChar.GetMTU(); err == nil && 0 < n {
+			mtu = max(tinyMTU(n), DefaultATTMTU)
+		}
+	}
+	if 0 < conn.requestedMTU && conn.requestedMTU < mtu {
+		mtu = max(conn.requestedMTU, DefaultATTMTU)
+	}
+	return mtu
+}
+
+// setMTUCharacteristic sets the characteristic whose MTU is reported as the MTU of the connection if it is not set yet.
+func (conn *tinyConnection) setMTUCharacteristic(char bluetooth.DeviceCharacteristic) {
+	conn.Lock()
+	defer conn.Unlock()
+	if conn.mtuChar != nil {
+		return
+	}
+	conn.mtuChar = &char
+}
+
+// DiscoverServices discovers the specified services. All services are returned if no UUIDs are specified.
+func (conn *tinyConnection) DiscoverServices(uuids []UUID) ([]BackendService, error) {
+	tinyServices, err := conn.tinyDev.DiscoverServices(uuidsToTiny(uuids))
+	if err != nil {
+		return nil, err
+	}
+	services := make([]BackendService, 0, len(tinyServices))
+	for _, ts := range tinyServices {
+		services = append(services, &tinyService{
+			conn:        conn,
+			tinyService: ts,
+			platform:    tinyServicePlatform{}, // nolint: exhaustruct
+		})
+	}
+	return services, nil
+}
+
+type tinyService struct {
+	conn        *tinyConnection
+	tinyService bluetooth.DeviceService
+	platform    tinyServicePlatform
+}
+
+// UUID returns the service UUID.
+func (s *tinyService) UUID() UUID {
+	return newUUIDFromTiny(s.tinyService.UUID())
+}
+
+// DiscoverCharacteristics discovers the specified characteristics. All characteristics are returned if no UUIDs are specified.
+func (s *tinyService) DiscoverCharacteristics(uuids []UUID) ([]BackendCharacteristic, error) {
+	tinyChars, err := s.tinyService.DiscoverCharacteristics(uuidsToTiny(uuids))
+	if err != nil {
+		return nil, err
+	}
+	chars := make([]BackendCharacteristic, 0, len(tinyChars))
+	for n, tinyChar := range tinyChars {
+		s.conn.setMTUCharacteristic(tinyChar)
+		// The platforms discover characteristics with the same UUID in the attribute order, so the count of the preceding ones identifies the characteristic.
+		uuidIndex := 0
+		for _, prevChar := range tinyChars[:n] {
+			if prevChar.UUID() == tinyChar.UUID() {
+				uuidIndex++
+			}
+		}
+		chars = append(chars, newTinyCharacteristic(s, tinyChar, uuidIndex))
+	}
+	return chars, nil
+}
+
+type tinyCharacteristic struct {
+	service   *tinyService
+	tinyChar  bluetooth.DeviceCharacteristic
+	uuidIndex int
+	platform  tinyCharacteristicPlatform
+}
+
+func newTinyCharacteristic(service *tinyService, char bluetooth.DeviceCharacteristic, uuidIndex int) *tinyCharacteristic {
+	return &tinyCharacteristic{
+		service:   service,
+		tinyChar:  char,
+		uuidIndex: uuidIndex,
+		platform:  tinyCharacteristicPlatform{}, // nolint: exhaustruct
+	}
+}
+
+// UUID returns the characteristic UUID.
+func (char *tinyCharacteristic) UUID() UUID {
+	return newUUIDFromTiny(char.tinyChar.UUID())
+}
+
+// Read reads the characteristic value.
+// The platforms perform long reads with Read Blob requests and report the full value length,
+// so the value is read again with a larger buffer if it did not fit.
+func (char *tinyCharacteristic) Read() ([]byte, error) {
+	buf := make([]byte, MaxAttributeValueSize)
+	for {
+		n, err := char.tinyChar.Read(buf)
+		if err != nil {
+			return nil, tinyATTError(err, ATTErrorReadNotPermitted)
+		}
+		if n <= len(buf) {
+			return buf[:n], nil
+		}
+		buf = make([]byte, n)
+	}
+}
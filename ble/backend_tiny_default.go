@@ -0,0 +1,102 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux && !windows
+
+package ble
+
+import (
+	"errors"
+	"fmt"
+)
+
+type tinyServicePlatform struct{}
+
+type tinyCharacteristicPlatform struct{}
+
+// IsPrimary returns true since the platform does not report secondary services.
+func (s *tinyService) IsPrimary() bool {
+	return true
+}
+
+// IncludedServiceUUIDs returns nil since the platform does not report included services.
+func (s *tinyService) IncludedServiceUUIDs() []UUID {
+	return nil
+}
+
+// Properties returns zero since the platform does not report the characteristic properties.
+func (char *tinyCharacteristic) Properties() CharacteristicProperties {
+	return 0
+}
+
+// EnableNotifications enables notifications with the specified callback.
+// It returns ErrNotSupported if the callback is nil since the platform cannot disable notifications.
+func (char *tinyCharacteristic) EnableNotifications(callback func(buf []byte)) error {
+	if callback == nil {
+		return fmt.Errorf("disabling notification %w", ErrNotSupported)
+	}
+	return char.tinyChar.EnableNotifications(callback)
+}
+
+// EnableIndications returns ErrNotSupported since the platform selects notifications or indications by itself and cannot disable them.
+func (char *tinyCharacteristic) EnableIndications(callback func(buf []byte)) error {
+	if callback == nil {
+		return char.EnableNotifications(nil)
+	}
+	return fmt.Errorf("indication %w", ErrNotSupported)
+}
+
+// DiscoverDescriptors returns ErrNotSupported since the platform does not discover descriptors.
+func (char *tinyCharacteristic) DiscoverDescriptors(uuids []UUID) ([]BackendDescriptor, error) {
+	return nil, fmt.Errorf("descriptor discovery %w", ErrNotSupported)
+}
+
+// Write writes the characteristic value with response.
+// Since the platform does not report the characteristic properties, it falls back to a write without response
+// if the peripheral rejects the write request as not permitted or not supported, which is the case for characteristics lacking the write property.
+// The fallback write is not acknowledged, so a characteristic which accepts neither write is not reported as failed.
+func (char *tinyCharacteristic) Write(data []byte) (int, error) {
+	n, err := char.tinyChar.Write(data)
+	if err == nil {
+		return n, nil
+	}
+	err = tinyATTError(err, ATTErrorWriteNotPermitted)
+	var attErr *ATTError
+	if errors.As(err, &attErr) && (attErr.Code == ATTErrorWriteNotPermitted || attErr.Code == ATTErrorRequestNotSupported) {
+		return char.tinyChar.WriteWithoutResponse(data)
+	}
+	return n, err
+}
+
+// WriteWithoutResponse writes the characteristic value without waiting for a response.
+func (char *tinyCharacteristic) WriteWithoutResponse(data []byte) (int, error) {
+	return char.tinyChar.WriteWithoutResponse(data)
+}
+
+// tinyATTError returns the ATT error reported in the backend error, or the backend error as is.
+// CoreBluetooth reports rejected requests with the ATT error code as the error code, but the error domain is not exposed,
+// so a CoreBluetooth error in the ATT code range such as a disconnection is also returned as an ATT error which keeps the original error.
+// notPermitted is not used since the platform reports the not permitted errors by itself.
+func tinyATTError(err error, _ ATTErrorCode) error {
+	if attErr, ok := newATTErrorFromMessage(err); ok {
+		return attErr
+	}
+	var codedErr interface{ Code() int }
+	if errors.As(err, &codedErr) {
+		if code := codedErr.Code(); int(ATTErrorInvalidHandle) <= code && code <= int(ATTErrorInsufficientResources) {
+			return NewATTError(ATTErrorCode(code), err)
+		}
+	}
+	return err
+}
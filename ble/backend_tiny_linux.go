@@ -0,0 +1,392 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package ble
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	bluezService               = "org.bluez"
+	bluezGattService1          = "org.bluez.GattService1"
+	bluezGattCharacteristic1   = "org.bluez.GattCharacteristic1"
+	bluezGattDescriptor1       = "org.bluez.GattDescriptor1"
+	bluezWriteValue            = "org.bluez.GattCharacteristic1.WriteValue"
+	bluezDescReadValue         = "org.bluez.GattDescriptor1.ReadValue"
+	bluezDescWriteValue        = "org.bluez.GattDescriptor1.WriteValue"
+	bluezGetManagedObjects     = "org.freedesktop.DBus.ObjectManager.GetManagedObjects"
+	bluezWriteTypeRequest      = "request"
+	bluezWriteTypeCommand      = "command"
+	bluezFlagWrite             = "write"
+	bluezFlagWriteWithoutResp  = "write-without-response"
+	bluezErrorNotPermitted     = "org.bluez.Error.NotPermitted"
+	bluezErrorNotAuthorized    = "org.bluez.Error.NotAuthorized"
+	bluezErrorInvalidOffset    = "org.bluez.Error.InvalidOffset"
+	bluezErrorInvalidValueLen  = "org.bluez.Error.InvalidValueLength"
+	bluezErrorNotSupported     = "org.bluez.Error.NotSupported"
+	bluezErrorInProgress       = "org.bluez.Error.InProgress"
+	bluezDevicePathPrefix      = "/dev_"
+	bluezCharacteristicPathSep = "/char"
+	bluezDescriptorPathSep     = "/desc"
+)
+
+// tinyServicePlatform holds the BlueZ service properties which TinyGo does not expose.
+// The properties are looked up again after a failed lookup.
+type tinyServicePlatform struct {
+	sync.Mutex
+	found    bool
+	path     dbus.ObjectPath
+	primary  bool
+	includes []UUID
+}
+
+// tinyCharacteristicPlatform holds the BlueZ characteristic object which TinyGo does not expose.
+// The object is looked up again after a failed lookup.
+type tinyCharacteristicPlatform struct {
+	sync.Mutex
+	obj   dbus.BusObject
+	flags []string
+}
+
+// IsPrimary returns whether the service is primary from the BlueZ service object, or true if the service is not found.
+func (s *tinyService) IsPrimary() bool {
+	p, err := s.bluezService()
+	if err != nil {
+		return true
+	}
+	return p.primary
+}
+
+// IncludedServiceUUIDs returns the UUIDs of the included services from the BlueZ service object, or nil if the service is not found.
+func (s *tinyService) IncludedServiceUUIDs() []UUID {
+	p, err := s.bluezService()
+	if err != nil {
+		return nil
+	}
+	return p.includes
+}
+
+// bluezService returns the path and the properties of the BlueZ service object.
+// Found properties are cached, and a failed lookup is retried on the next call.
+func (s *tinyService) bluezService() (*tinyServicePlatform, error) {
+	return s.bluezServiceFromObjects(nil)
+}
+
+// bluezServiceFromObjects returns the path and the properties of the BlueZ service object like bluezService,
+// looking it up in the specified managed objects, or in the managed objects queried from BlueZ if they are nil.
+func (s *tinyService) bluezServiceFromObjects(objects map[dbus.ObjectPath]map[string]map[string]dbus.Variant) (*tinyServicePlatform, error) {
+	p := &s.platform
+	p.Lock()
+	defer p.Unlock()
+	if p.found {
+		return p, nil
+	}
+	if objects == nil {
+		var err error
+		_, objects, err = bluezManagedObjects()
+		if err != nil {
+			return nil, err
+		}
+	}
+	servicePath, err := s.bluezServicePath(objects)
+	if err != nil {
+		return nil, err
+	}
+	props := objects[servicePath][bluezGattService1]
+	primary, ok := props["Primary"].Value().(bool)
+	p.path = servicePath
+	p.primary = !ok || primary
+	p.includes = bluezIncludedServiceUUIDs(props, objects)
+	p.found = true
+	return p, nil
+}
+
+// bluezIncludedServiceUUIDs returns the UUIDs of the services included by the BlueZ service object with the properties.
+func bluezIncludedServiceUUIDs(props map[string]dbus.Variant, objects map[dbus.ObjectPath]map[string]map[string]dbus.Variant) []UUID {
+	includes, ok := props["Includes"].Value().([]dbus.ObjectPath)
+	if !ok {
+		return nil
+	}
+	uuids := make([]UUID, 0, len(includes))
+	for _, path := range includes {
+		includeProps, ok := objects[path][bluezGattService1]
+		if !ok {
+			continue
+		}
+		uuid, err := NewUUIDFromString(variantString(includeProps["UUID"]))
+		if err != nil {
+			continue
+		}
+		uuids = append(uuids, uuid)
+	}
+	return uuids
+}
+
+// bluezServicePath returns the path of the BlueZ service object, which is the first service with the UUID under the device as TinyGo selects.
+func (s *tinyService) bluezServicePath(objects map[dbus.ObjectPath]map[string]map[string]dbus.Variant) (dbus.ObjectPath, error) {
+	devPath := bluezDevicePathPrefix + strings.ReplaceAll(s.conn.tinyDev.Address.MAC.String(), ":", "_")
+	serviceUUID := s.tinyService.UUID().String()
+	for _, servicePath := range sortedObjectPaths(objects) {
+		if !strings.Contains(string(servicePath), devPath+"/") {
+			continue
+		}
+		props, ok := objects[servicePath][bluezGattService1]
+		if ok && strings.EqualFold(variantString(props["UUID"]), serviceUUID) {
+			return servicePath, nil
+		}
+	}
+	return "", fmt.Errorf("service %w: %s", ErrNotFound, serviceUUID)
+}
+
+// Properties returns the characteristic properties from the BlueZ flags, or zero if the characteristic is not found.
+func (char *tinyCharacteristic) Properties() CharacteristicProperties {
+	_, flags, err := char.bluezCharacteristic()
+	if err != nil {
+		return 0
+	}
+	return NewCharacteristicPropertiesFromNames(flags...)
+}
+
+// EnableNotifications enables notifications with the specified callback, or disables them if the callback is nil.
+func (char *tinyCharacteristic) EnableNotifications(callback func(buf []byte)) error {
+	return char.tinyChar.EnableNotifications(callback)
+}
+
+// EnableIndications enables indications with the specified callback, or disables them if the callback is nil.
+// BlueZ starts notifications whenever the characteristic supports them, so indications are enabled only for characteristics which support indications alone.
+func (char *tinyCharacteristic) EnableIndications(callback func(buf []byte)) error {
+	if callback != nil {
+		props := char.Properties()
+		if !props.IsIndicatable() {
+			return fmt.Errorf("%w indicate", ErrNotPermitted)
+		}
+		if props.IsNotifiable() {
+			return fmt.Errorf("indication of notifiable characteristic %w", ErrNotSupported)
+		}
+	}
+	return char.tinyChar.EnableNotifications(callback)
+}
+
+// Write writes the characteristic value with a write request which BlueZ acknowledges after the peer responds.
+// It falls back to a write command only if the characteristic lacks the write property.
+func (char *tinyCharacteristic) Write(data []byte) (int, error) {
+	obj, flags, err := char.bluezCharacteristic()
+	if err != nil {
+		return 0, err
+	}
+	writeType := bluezWriteTypeRequest
+	if !slices.Contains(flags, bluezFlagWrite) && slices.Contains(flags, bluezFlagWriteWithoutResp) {
+		writeType = bluezWriteTypeCommand
+	}
+	return char.writeValue(obj, data, writeType)
+}
+
+// WriteWithoutResponse writes the characteristic value with a write command.
+func (char *tinyCharacteristic) WriteWithoutResponse(data []byte) (int, error) {
+	obj, _, err := char.bluezCharacteristic()
+	if err != nil {
+		return 0, err
+	}
+	return char.writeValue(obj, data, bluezWriteTypeCommand)
+}
+
+func (char *tinyCharacteristic) writeValue(obj dbus.BusObject, data []byte, writeType string) (int, error) {
+	options := map[string]dbus.Variant{
+		"type": dbus.MakeVariant(writeType),
+	}
+	if err := obj.Call(bluezWriteValue, 0, data, options).Err; err != nil {
+		return 0, tinyATTError(err, ATTErrorWriteNotPermitted)
+	}
+	return len(data), nil
+}
+
+// bluezCharacteristic returns the BlueZ object and the flags of the characteristic.
+// A found object is cached, and a failed lookup is retried on the next call.
+func (char *tinyCharacteristic) bluezCharacteristic() (dbus.BusObject, []string, error) {
+	p := &char.platform
+	p.Lock()
+	defer p.Unlock()
+	if p.obj != nil {
+		return p.obj, p.flags, nil
+	}
+	obj, flags, err := char.lookupBlueZCharacteristic()
+	if err != nil {
+		return nil, nil, err
+	}
+	p.obj = obj
+	p.flags = flags
+	return obj, flags, nil
+}
+
+// lookupBlueZCharacteristic looks up the BlueZ object of the characteristic by its object path.
+// TinyGo discovers characteristics in the path order, which follows the attribute handles, so the characteristic is the one at its index among the characteristics with the same UUID in the service.
+func (char *tinyCharacteristic) lookupBlueZCharacteristic() (dbus.BusObject, []string, error) {
+	bus, objects, err := bluezManagedObjects()
+	if err != nil {
+		return nil, nil, err
+	}
+	service, err := char.service.bluezServiceFromObjects(objects)
+	if err != nil {
+		return nil, nil, err
+	}
+	servicePath := service.path
+	charUUID := char.tinyChar.UUID().String()
+	uuidIndex := 0
+	for _, charPath := range sortedObjectPaths(objects) {
+		if !strings.HasPrefix(string(charPath), string(servicePath)+bluezCharacteristicPathSep) {
+			continue
+		}
+		props, ok := objects[charPath][bluezGattCharacteristic1]
+		if !ok || !strings.EqualFold(variantString(props["UUID"]), charUUID) {
+			continue
+		}
+		if uuidIndex < char.uuidIndex {
+			uuidIndex++
+			continue
+		}
+		flags, _ := props["Flags"].Value().([]string)
+		return bus.Object(bluezService, charPath), flags, nil
+	}
+	return nil, nil, fmt.Errorf("characteristic %w: %s", ErrNotFound, charUUID)
+}
+
+// DiscoverDescriptors discovers the specified descriptors from the BlueZ objects under the characteristic. All descriptors are returned if no UUIDs are specified.
+func (char *tinyCharacteristic) DiscoverDescriptors(uuids []UUID) ([]BackendDescriptor, error) {
+	charObj, _, err := char.bluezCharacteristic()
+	if err != nil {
+		return nil, err
+	}
+	bus, objects, err := bluezManagedObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	descs := []BackendDescriptor{}
+	for _, descPath := range sortedObjectPaths(objects) {
+		if !strings.HasPrefix(string(descPath), string(charObj.Path())+bluezDescriptorPathSep) {
+			continue
+		}
+		props, ok := objects[descPath][bluezGattDescriptor1]
+		if !ok {
+			continue
+		}
+		uuid, err := NewUUIDFromString(variantString(props["UUID"]))
+		if err != nil {
+			continue
+		}
+		if 0 < len(uuids) && !slices.ContainsFunc(uuids, uuid.Equal) {
+			continue
+		}
+		descs = append(descs, &tinyDescriptor{
+			uuid: uuid,
+			obj:  bus.Object(bluezService, descPath),
+		})
+	}
+	return descs, nil
+}
+
+type tinyDescriptor struct {
+	uuid UUID
+	obj  dbus.BusObject
+}
+
+// UUID returns the descriptor UUID.
+func (desc *tinyDescriptor) UUID() UUID {
+	return desc.uuid
+}
+
+// Read reads the descriptor value.
+func (desc *tinyDescriptor) Read() ([]byte, error) {
+	var data []byte
+	err := desc.obj.Call(bluezDescReadValue, 0, map[string]dbus.Variant{}).Store(&data)
+	if err != nil {
+		return nil, tinyATTError(err, ATTErrorReadNotPermitted)
+	}
+	return data, nil
+}
+
+// Write writes the descriptor value with response.
+func (desc *tinyDescriptor) Write(data []byte) (int, error) {
+	if err := desc.obj.Call(bluezDescWriteValue, 0, data, map[string]dbus.Variant{}).Err; err != nil {
+		return 0, tinyATTError(err, ATTErrorWriteNotPermitted)
+	}
+	return len(data), nil
+}
+
+// bluezManagedObjects returns the system bus with all the objects managed by BlueZ.
+func bluezManagedObjects() (*dbus.Conn, map[dbus.ObjectPath]map[string]map[string]dbus.Variant, error) {
+	bus, err := dbus.SystemBus()
+	if err != nil {
+		return nil, nil, err
+	}
+	var objects map[dbus.ObjectPath]map[string]map[string]dbus.Variant
+	err = bus.Object(bluezService, "/").Call(bluezGetManagedObjects, 0).Store(&objects)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bus, objects, nil
+}
+
+// sortedObjectPaths returns the paths of the objects in the sorted order.
+func sortedObjectPaths(objects map[dbus.ObjectPath]map[string]map[string]dbus.Variant) []dbus.ObjectPath {
+	paths := make([]dbus.ObjectPath, 0, len(objects))
+	for path := range objects {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+func variantString(v dbus.Variant) string {
+	s, _ := v.Value().(string)
+	return s
+}
+
+// tinyATTError returns the ATT error reported in the BlueZ error, or the backend error as is.
+func tinyATTError(err error, notPermitted ATTErrorCode) error {
+	if attErr, ok := newATTErrorFromMessage(err); ok {
+		return attErr
+	}
+	var dbusErr dbus.Error
+	if !errors.As(err, &dbusErr) {
+		return err
+	}
+	var code ATTErrorCode
+	switch dbusErr.Name {
+	case bluezErrorNotPermitted:
+		code = notPermitted
+	case bluezErrorNotAuthorized:
+		code = ATTErrorInsufficientAuthorization
+	case bluezErrorInvalidOffset:
+		code = ATTErrorInvalidOffset
+	case bluezErrorInvalidValueLen:
+		code = ATTErrorInvalidAttributeValueLength
+	case bluezErrorNotSupported:
+		code = ATTErrorRequestNotSupported
+	case bluezErrorInProgress:
+		code = ATTErrorProcedureAlreadyInProgress
+	default:
+		return err
+	}
+	return NewATTError(code, err)
+}
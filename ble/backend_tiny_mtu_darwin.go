@@ -14,22 +14,8 @@
 
 package ble
 
-import (
-	"context"
-)
-
-type tinyCentral struct {
-	Scanner
-}
-
-// NewCentral creates a new Bluetooth central device.
-func NewCentral() Central {
-	return &tinyCentral{
-		Scanner: NewScanner(),
-	}
-}
-
-// Connect connects to the specified device.
-func (c *tinyCentral) Connect(ctx context.Context, dev Device) error {
-	return dev.Connect(ctx)
+// tinyMTU returns the ATT MTU from the MTU reported by the platform.
+// CoreBluetooth reports the maximum write value length, which excludes the ATT header.
+func tinyMTU(n uint16) int {
+	return int(n) + attHeaderSize
 }
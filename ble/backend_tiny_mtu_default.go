@@ -12,14 +12,11 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package ble
-
-import (
-	"tinygo.org/x/bluetooth"
-)
+//go:build !darwin
 
-var sharedAdapter = bluetooth.DefaultAdapter
+package ble
 
-func defaultAdapter() *bluetooth.Adapter {
-	return sharedAdapter
+// tinyMTU returns the ATT MTU from the MTU reported by the platform.
+func tinyMTU(n uint16) int {
+	return int(n)
 }
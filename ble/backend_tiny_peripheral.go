@@ -0,0 +1,157 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux || windows
+
+package ble
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"tinygo.org/x/bluetooth"
+)
+
+// AddService registers the local service and returns a backend handle for each characteristic.
+func (backend *tinyBackend) AddService(service LocalService) ([]BackendLocalCharacteristic, error) {
+	chars := service.Characteristics()
+	tinyChars := make([]*tinyLocalCharacteristic, len(chars))
+	tinyConfigs := make([]bluetooth.CharacteristicConfig, len(chars))
+	for n, char := range chars {
+		tinyChar := &tinyLocalCharacteristic{
+			Mutex:    sync.Mutex{},
+			handle:   bluetooth.Characteristic{}, // nolint: exhaustruct
+			updating: atomic.Bool{},
+		}
+		tinyChars[n] = tinyChar
+		tinyConfigs[n] = bluetooth.CharacteristicConfig{
+			Handle:     &tinyChar.handle,
+			UUID:       uuidToTiny(char.UUID()),
+			Value:      char.Value(),
+			Flags:      bluetooth.CharacteristicPermissions(char.Properties()),
+			WriteEvent: tinyChar.writeEventHandler(char),
+		}
+	}
+
+	tinyService := &bluetooth.Service{ // nolint: exhaustruct
+		UUID:            uuidToTiny(service.UUID()),
+		Characteristics: tinyConfigs,
+	}
+	if err := backend.adapter.AddService(tinyService); err != nil {
+		return nil, err
+	}
+
+	backendChars := make([]BackendLocalCharacteristic, len(tinyChars))
+	for n, tinyChar := range tinyChars {
+		backendChars[n] = tinyChar
+	}
+	return backendChars, nil
+}
+
+type tinyLocalCharacteristic struct {
+	sync.Mutex
+	handle   bluetooth.Characteristic
+	updating atomic.Bool
+}
+
+// writeEventHandler returns the handler which serves writes from centrals.
+// The TinyGo Bluetooth package cannot respond to the central with an error, so a rejected write is reported only to the write error handler of the local characteristic.
+// An accepted value is written back to the backend, which does not store the written value by itself, so that centrals read the updated value.
+func (char *tinyLocalCharacteristic) writeEventHandler(localChar LocalCharacteristic) bluetooth.WriteEvent {
+	return func(client bluetooth.Connection, offset int, value []byte) {
+		// The TinyGo Bluetooth package reports local updates as write events, so skip them.
+		if char.updating.Load() {
+			return
+		}
+		if err := localChar.WriteValue(offset, value); err != nil {
+			return
+		}
+		char.SetValue(localChar.Value())
+	}
+}
+
+// SetValue updates the characteristic value served by the backend.
+func (char *tinyLocalCharacteristic) SetValue(data []byte) error {
+	char.Lock()
+	defer char.Unlock()
+	char.updating.Store(true)
+	defer char.updating.Store(false)
+	_, err := char.handle.Write(data)
+	return err
+}
+
+// Notify sends the characteristic value to the subscribed centrals by notification or indication.
+func (char *tinyLocalCharacteristic) Notify(data []byte) error {
+	return char.SetValue(data)
+}
+
+// StartAdvertising starts advertising the specified advertising data with the parameters.
+// The TinyGo Bluetooth package advertises only the complete local name, service UUIDs, service data and manufacturer data,
+// so it returns ErrNotSupported if any other field or a scan response is set.
+func (backend *tinyBackend) StartAdvertising(data AdvertisingData, params AdvertisingParameters) error {
+	if params.ScanResponse != nil {
+		return fmt.Errorf("scan response %w", ErrNotSupported)
+	}
+	if _, ok := data.Flags(); ok {
+		return fmt.Errorf("flags %w", ErrNotSupported)
+	}
+	if data.IsShortenedLocalName() {
+		return fmt.Errorf("shortened local name %w", ErrNotSupported)
+	}
+	if _, ok := data.TxPower(); ok {
+		return fmt.Errorf("TX power %w", ErrNotSupported)
+	}
+	if _, ok := data.Appearance(); ok {
+		return fmt.Errorf("appearance %w", ErrNotSupported)
+	}
+
+	advType := bluetooth.AdvertisingTypeInd
+	if !params.Connectable {
+		advType = bluetooth.AdvertisingTypeNonConnInd
+	}
+	serviceData := make([]bluetooth.ServiceDataElement, 0, len(data.ServiceData()))
+	for _, sd := range data.ServiceData() {
+		serviceData = append(serviceData, bluetooth.ServiceDataElement{
+			UUID: uuidToTiny(sd.UUID()),
+			Data: sd.Data(),
+		})
+	}
+	manufacturerData := make([]bluetooth.ManufacturerDataElement, 0, len(data.ManufacturerData()))
+	for _, md := range data.ManufacturerData() {
+		manufacturerData = append(manufacturerData, bluetooth.ManufacturerDataElement{
+			CompanyID: uint16(md.ID()),
+			Data:      md.Data(),
+		})
+	}
+
+	adv := backend.adapter.DefaultAdvertisement()
+	err := adv.Configure(bluetooth.AdvertisementOptions{
+		AdvertisementType: advType,
+		LocalName:         data.LocalName(),
+		ServiceUUIDs:      uuidsToTiny(data.ServiceUUIDs()),
+		Interval:          bluetooth.NewDuration(params.Interval),
+		ManufacturerData:  manufacturerData,
+		ServiceData:       serviceData,
+	})
+	if err != nil {
+		return err
+	}
+	return adv.Start()
+}
+
+// StopAdvertising stops advertising.
+func (backend *tinyBackend) StopAdvertising() error {
+	return backend.adapter.DefaultAdvertisement().Stop()
+}
@@ -12,24 +12,21 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build linux
+//go:build !linux && !windows
 
 package ble
 
-import (
-	"fmt"
-	"time"
-)
+// AddService registers the local service and returns a backend handle for each characteristic.
+func (backend *tinyBackend) AddService(service LocalService) ([]BackendLocalCharacteristic, error) {
+	return nil, ErrNotSupported
+}
+
+// StartAdvertising starts advertising the specified advertising data with the parameters.
+func (backend *tinyBackend) StartAdvertising(data AdvertisingData, params AdvertisingParameters) error {
+	return ErrNotSupported
+}
 
-// Write writes the characteristic value.
-func (char *tinyCharacteristic) Write(data []byte) (int, error) {
-	if char.tinyChar == nil {
-		return 0, fmt.Errorf("%w: %s", ErrNotConnected, char.String())
-	}
-	nWrote, err := char.tinyChar.WriteWithoutResponse(data)
-	if err != nil {
-		return nWrote, fmt.Errorf("%w: %s", err, char.String())
-	}
-	time.Sleep(defaultCharacteristicWriteWithoutResponseWait)
-	return nWrote, nil
+// StopAdvertising stops advertising.
+func (backend *tinyBackend) StopAdvertising() error {
+	return ErrNotSupported
 }
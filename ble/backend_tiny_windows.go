@@ -0,0 +1,105 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build windows
+
+package ble
+
+import (
+	"fmt"
+
+	"tinygo.org/x/bluetooth"
+)
+
+// The errors which the TinyGo Bluetooth package returns for characteristics lacking the property, which are not exported.
+const (
+	tinyErrNoRead                 = "bluetooth: read not supported"
+	tinyErrNoWrite                = "bluetooth: write not supported"
+	tinyErrNoWriteWithoutResponse = "bluetooth: write without response not supported"
+)
+
+type tinyServicePlatform struct{}
+
+type tinyCharacteristicPlatform struct{}
+
+// IsPrimary returns true since the platform does not report secondary services.
+func (s *tinyService) IsPrimary() bool {
+	return true
+}
+
+// IncludedServiceUUIDs returns nil since the platform does not report included services.
+func (s *tinyService) IncludedServiceUUIDs() []UUID {
+	return nil
+}
+
+// Properties returns the characteristic properties whose flags match the GATT characteristic properties of WinRT.
+func (char *tinyCharacteristic) Properties() CharacteristicProperties {
+	return CharacteristicProperties(char.tinyChar.Properties())
+}
+
+// EnableNotifications enables notifications with the specified callback, or disables them if the callback is nil.
+func (char *tinyCharacteristic) EnableNotifications(callback func(buf []byte)) error {
+	return char.tinyChar.EnableNotifications(callback)
+}
+
+// EnableIndications enables indications with the specified callback, or disables them if the callback is nil.
+func (char *tinyCharacteristic) EnableIndications(callback func(buf []byte)) error {
+	if callback == nil {
+		return char.tinyChar.EnableNotifications(nil)
+	}
+	return char.tinyChar.EnableNotificationsWithMode(bluetooth.NotificationModeIndicate, callback)
+}
+
+// DiscoverDescriptors returns ErrNotSupported since the platform does not discover descriptors.
+func (char *tinyCharacteristic) DiscoverDescriptors(uuids []UUID) ([]BackendDescriptor, error) {
+	return nil, fmt.Errorf("descriptor discovery %w", ErrNotSupported)
+}
+
+// Write writes the characteristic value with response.
+// It falls back to a write without response only if the characteristic lacks the write property.
+func (char *tinyCharacteristic) Write(data []byte) (int, error) {
+	props := char.Properties()
+	if !props.IsWritable() && props.IsWritableWithoutResponse() {
+		return char.tinyChar.WriteWithoutResponse(data)
+	}
+	n, err := char.tinyChar.Write(data)
+	if err != nil {
+		return n, tinyATTError(err, ATTErrorWriteNotPermitted)
+	}
+	return n, nil
+}
+
+// WriteWithoutResponse writes the characteristic value without waiting for a response.
+func (char *tinyCharacteristic) WriteWithoutResponse(data []byte) (int, error) {
+	n, err := char.tinyChar.WriteWithoutResponse(data)
+	if err != nil {
+		return n, tinyATTError(err, ATTErrorWriteNotPermitted)
+	}
+	return n, nil
+}
+
+// tinyATTError returns the ATT error reported in the backend error, or the backend error as is.
+// The TinyGo Bluetooth package rejects reads and writes of characteristics lacking the property before sending a request,
+// so the rejection is returned as the not permitted ATT error which the peripheral would respond with.
+// WinRT reports only the communication status of a write, so the ATT error code of a write which the peripheral rejects cannot be known and the error is returned as is.
+func tinyATTError(err error, notPermitted ATTErrorCode) error {
+	if attErr, ok := newATTErrorFromMessage(err); ok {
+		return attErr
+	}
+	switch err.Error() {
+	case tinyErrNoRead, tinyErrNoWrite, tinyErrNoWriteWithoutResponse:
+		return NewATTError(notPermitted, err)
+	}
+	return err
+}
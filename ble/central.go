@@ -16,11 +16,38 @@ package ble
 
 import (
 	"context"
+	"time"
 )
 
 // Central represents a Bluetooth central device.
 type Central interface {
 	Scanner
-	// Connect connects to the specified device.
-	Connect(ctx context.Context, dev Device) error
+	// Connect connects to the specified device with the options.
+	Connect(ctx context.Context, dev Device, opts ...ConnectOption) error
+	// Timeouts returns the timeouts applied to the operations of the discovered devices whose context has no deadline.
+	Timeouts() Timeouts
+}
+
+// CentralOption represents an option for the central.
+type CentralOption func(*Timeouts)
+
+// WithCentralConnectTimeout sets the timeout for connecting to a device.
+func WithCentralConnectTimeout(timeout time.Duration) CentralOption {
+	return func(timeouts *Timeouts) {
+		timeouts.Connect = timeout
+	}
+}
+
+// WithCentralOperationTimeout sets the timeout for GATT operations and disconnection.
+func WithCentralOperationTimeout(timeout time.Duration) CentralOption {
+	return func(timeouts *Timeouts) {
+		timeouts.Operation = timeout
+	}
+}
+
+// WithCentralTransportTimeout sets the timeout for reading from the transports opened on the services.
+func WithCentralTransportTimeout(timeout time.Duration) CentralOption {
+	return func(timeouts *Timeouts) {
+		timeouts.Transport = timeout
+	}
 }
@@ -0,0 +1,51 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+)
+
+type backendCentral struct {
+	Scanner
+	timeouts Timeouts
+}
+
+// NewCentral creates a new Bluetooth central device with the default backend and the options.
+func NewCentral(opts ...CentralOption) Central {
+	return NewCentralWithBackend(DefaultBackend(), opts...)
+}
+
+// NewCentralWithBackend creates a new Bluetooth central device with the specified backend and the options.
+func NewCentralWithBackend(backend Backend, opts ...CentralOption) Central {
+	timeouts := NewDefaultTimeouts()
+	for _, opt := range opts {
+		opt(&timeouts)
+	}
+	return &backendCentral{
+		Scanner:  newBackendScanner(backend, timeouts),
+		timeouts: timeouts,
+	}
+}
+
+// Timeouts returns the timeouts applied to the operations of the discovered devices whose context has no deadline.
+func (c *backendCentral) Timeouts() Timeouts {
+	return c.timeouts
+}
+
+// Connect connects to the specified device with the options.
+func (c *backendCentral) Connect(ctx context.Context, dev Device, opts ...ConnectOption) error {
+	return dev.Connect(ctx, opts...)
+}
@@ -15,17 +15,13 @@
 package ble
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"time"
 
 	"github.com/cybergarage/go-ble/ble/db"
 )
 
-const (
-	defaultCharacteristicWriteWithoutResponseWait = time.Duration(500 * time.Millisecond)
-)
-
 // OnCharacteristicNotification represents a callback function to be called when a notification is received.
 type OnCharacteristicNotification func(char Characteristic, buf []byte)
 
@@ -51,18 +47,41 @@ type CharacteristicDescriptor interface {
 	Name() string
 	// ID returns the Characteristic ID.
 	ID() string
+	// Properties returns the Characteristic properties, or zero if the backend does not report them.
+	Properties() CharacteristicProperties
+	// LookupDescriptor looks up a descriptor by UUID.
+	LookupDescriptor(uuid any) (Descriptor, bool)
+	// Descriptors returns the descriptors of the Characteristic.
+	Descriptors() []Descriptor
 }
 
 // CharacteristicOperator represents operations that can be performed on a Bluetooth Characteristic.
+// The operations without a context apply the operation timeout of the device, as do the context variants if the context has no deadline.
 type CharacteristicOperator interface {
-	// Read reads the characteristic value.
+	// Read reads the characteristic value, following Read Blob requests until the full long value is read.
 	Read() ([]byte, error)
-	// Write writes the characteristic value.
+	// ReadContext reads the characteristic value like Read with the context.
+	ReadContext(ctx context.Context) ([]byte, error)
+	// Write writes the characteristic value of up to MaxAttributeValueSize bytes, as a long write if it exceeds the ATT MTU.
 	Write([]byte) (int, error)
-	// WriteWithoutResponse writes the characteristic value without waiting for a response.
+	// WriteContext writes the characteristic value like Write with the context.
+	WriteContext(ctx context.Context, data []byte) (int, error)
+	// WriteWithoutResponse writes the characteristic value without waiting for a response, split into writes of the ATT MTU.
 	WriteWithoutResponse([]byte) (int, error)
-	// Notify subscribes to characteristic notifications.
+	// WriteWithoutResponseContext writes the characteristic value like WriteWithoutResponse with the context, which also bounds the waits of the paced write queue.
+	WriteWithoutResponseContext(ctx context.Context, data []byte) (int, error)
+	// Notify sets the callback for characteristic notifications, replacing the callback set before, or unsubscribes it if the callback is nil.
 	Notify(OnCharacteristicNotification) error
+	// NotifyContext sets the callback for characteristic notifications like Notify with the context.
+	NotifyContext(ctx context.Context, callback OnCharacteristicNotification) error
+	// Subscribe subscribes to characteristic notifications, or indications with WithSubscribeIndication.
+	// All the subscriptions of the characteristic share one subscription to the peripheral.
+	Subscribe(callback OnCharacteristicNotification, opts ...SubscribeOption) (Subscription, error)
+	// SubscribeContext subscribes to the characteristic like Subscribe with the context.
+	SubscribeContext(ctx context.Context, callback OnCharacteristicNotification, opts ...SubscribeOption) (Subscription, error)
+	// SubscribeChannel subscribes to the characteristic and returns a channel which receives the values until the context is done.
+	// Values which arrive while the channel is full are dropped.
+	SubscribeChannel(ctx context.Context, opts ...SubscribeOption) (<-chan []byte, error)
 }
 
 // nolint: staticcheck
@@ -71,15 +90,19 @@ type characteristic struct {
 	Uuid    UUID
 	Nam     string
 	Id      string
+	props   CharacteristicProperties
+	descs   []Descriptor
 }
 
-func newCharacteristic(service Service, uuid UUID) *characteristic {
+func newCharacteristic(service Service, uuid UUID, props CharacteristicProperties) *characteristic {
 	dbChar, _ := db.DefaultDatabase().LookupCharacteristic(uuid)
 	return &characteristic{
 		service: service,
 		Uuid:    uuid,
 		Nam:     dbChar.Name(),
 		Id:      dbChar.ID(),
+		props:   props,
+		descs:   []Descriptor{},
 	}
 }
 
@@ -103,30 +126,98 @@ func (char *characteristic) ID() string {
 	return char.Id
 }
 
+// Properties returns the Characteristic properties, or zero if the backend does not report them.
+func (char *characteristic) Properties() CharacteristicProperties {
+	return char.props
+}
+
+// LookupDescriptor looks up a descriptor by UUID.
+func (char *characteristic) LookupDescriptor(anyUUID any) (Descriptor, bool) {
+	lookupUUID, err := NewUUIDFrom(anyUUID)
+	if err != nil {
+		return nil, false
+	}
+	for _, desc := range char.descs {
+		if lookupUUID.Equal(desc.UUID()) {
+			return desc, true
+		}
+	}
+	return nil, false
+}
+
+// Descriptors returns the descriptors of the Characteristic.
+func (char *characteristic) Descriptors() []Descriptor {
+	return char.descs
+}
+
+// addDescriptor adds a descriptor to the characteristic.
+func (char *characteristic) addDescriptor(desc Descriptor) {
+	char.descs = append(char.descs, desc)
+}
+
 // Read reads the characteristic value.
 func (char *characteristic) Read() ([]byte, error) {
+	return char.ReadContext(context.Background())
+}
+
+// ReadContext reads the characteristic value with the context.
+func (char *characteristic) ReadContext(ctx context.Context) ([]byte, error) {
 	return nil, fmt.Errorf("%w: %s", ErrNotConnected, char.String())
 }
 
 // Write writes the characteristic value.
 func (char *characteristic) Write(data []byte) (int, error) {
+	return char.WriteContext(context.Background(), data)
+}
+
+// WriteContext writes the characteristic value with the context.
+func (char *characteristic) WriteContext(ctx context.Context, data []byte) (int, error) {
 	return 0, fmt.Errorf("%w: %s", ErrNotConnected, char.String())
 }
 
-// Notify subscribes to characteristic notifications.
+// Notify sets the callback for characteristic notifications.
 func (char *characteristic) Notify(callback OnCharacteristicNotification) error {
+	return char.NotifyContext(context.Background(), callback)
+}
+
+// NotifyContext sets the callback for characteristic notifications with the context.
+func (char *characteristic) NotifyContext(ctx context.Context, callback OnCharacteristicNotification) error {
 	return fmt.Errorf("%w: %s", ErrNotConnected, char.String())
 }
 
+// Subscribe subscribes to characteristic notifications.
+func (char *characteristic) Subscribe(callback OnCharacteristicNotification, opts ...SubscribeOption) (Subscription, error) {
+	return char.SubscribeContext(context.Background(), callback, opts...)
+}
+
+// SubscribeContext subscribes to characteristic notifications with the context.
+func (char *characteristic) SubscribeContext(ctx context.Context, callback OnCharacteristicNotification, opts ...SubscribeOption) (Subscription, error) {
+	return nil, fmt.Errorf("%w: %s", ErrNotConnected, char.String())
+}
+
+// SubscribeChannel subscribes to the characteristic and returns a channel which receives the values.
+func (char *characteristic) SubscribeChannel(ctx context.Context, opts ...SubscribeOption) (<-chan []byte, error) {
+	return nil, fmt.Errorf("%w: %s", ErrNotConnected, char.String())
+}
+
+// MarshalObject returns an object suitable for marshaling to JSON.
 func (char *characteristic) MarshalObject() any {
+	descObjs := make([]any, 0, len(char.descs))
+	for _, desc := range char.descs {
+		descObjs = append(descObjs, desc.MarshalObject())
+	}
 	return struct {
-		UUID string `json:"uuid"`
-		Name string `json:"name"`
-		ID   string `json:"id"`
+		UUID        string   `json:"uuid"`
+		Name        string   `json:"name"`
+		ID          string   `json:"id"`
+		Properties  []string `json:"properties"`
+		Descriptors []any    `json:"descriptors"`
 	}{
-		UUID: char.UUID().String(),
-		Name: char.Name(),
-		ID:   char.ID(),
+		UUID:        char.UUID().String(),
+		Name:        char.Name(),
+		ID:          char.ID(),
+		Properties:  char.props.Names(),
+		Descriptors: descObjs,
 	}
 }
 
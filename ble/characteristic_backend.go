@@ -0,0 +1,347 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+)
+
+type backendCharacteristic struct {
+	*characteristic
+	backendChar BackendCharacteristic
+	subMutex    sync.Mutex
+	subs        []*subscription
+	subMode     NotificationMode
+	notifyMutex sync.Mutex
+	notifySub   *subscription
+	pending     pendingCall
+}
+
+func newBackendCharacteristic(service Service, uuid UUID, char BackendCharacteristic) *backendCharacteristic {
+	return &backendCharacteristic{
+		characteristic: newCharacteristic(service, uuid, char.Properties()),
+		backendChar:    char,
+		subMutex:       sync.Mutex{},
+		subs:           []*subscription{},
+		subMode:        NotificationModeNotify,
+		notifyMutex:    sync.Mutex{},
+		notifySub:      nil,
+		pending:        pendingCall{}, // nolint: exhaustruct
+	}
+}
+
+// discoverDescriptors discovers the descriptors of the characteristic using the backend.
+// Backends which cannot discover descriptors leave the characteristic without descriptors.
+func (char *backendCharacteristic) discoverDescriptors() error {
+	backendDescs, err := char.backendChar.DiscoverDescriptors(nil)
+	if err != nil {
+		if errors.Is(err, ErrNotSupported) {
+			return nil
+		}
+		return err
+	}
+	for _, backendDesc := range backendDescs {
+		char.addDescriptor(newDescriptor(char, backendDesc.UUID(), backendDesc))
+	}
+	return nil
+}
+
+// Read reads the characteristic value.
+func (char *backendCharacteristic) Read() ([]byte, error) {
+	return char.ReadContext(context.Background())
+}
+
+// ReadContext reads the characteristic value with the context. The operation timeout applies if the context has no deadline.
+func (char *backendCharacteristic) ReadContext(ctx context.Context) ([]byte, error) {
+	if char.backendChar == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotConnected, char.String())
+	}
+	ctx, cancel := contextWithTimeout(ctx, char.timeouts().Operation)
+	defer cancel()
+	data, err := doContext(ctx, char.read)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, char.String())
+	}
+	return data, nil
+}
+
+func (char *backendCharacteristic) read() ([]byte, error) {
+	data, err := char.backendChar.Read()
+	if err != nil {
+		return nil, err
+	}
+	blobReader, ok := char.backendChar.(BackendBlobReader)
+	if !ok {
+		return data, nil
+	}
+	// A response filling the ATT MTU may be followed by the rest of a long value.
+	partSize := char.mtu() - 1
+	part := data
+	for len(part) == partSize && len(data) < MaxAttributeValueSize {
+		part, err = blobReader.ReadBlob(len(data))
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, part...)
+	}
+	return data, nil
+}
+
+// Write writes the characteristic value.
+func (char *backendCharacteristic) Write(data []byte) (int, error) {
+	return char.WriteContext(context.Background(), data)
+}
+
+// WriteContext writes the characteristic value with the context. The operation timeout applies if the context has no deadline.
+// A write left running when the context ends delays the next write and subscription of the characteristic until it returns, so that they are not reordered.
+func (char *backendCharacteristic) WriteContext(ctx context.Context, data []byte) (int, error) {
+	if char.backendChar == nil {
+		return 0, fmt.Errorf("%w: %s", ErrNotConnected, char.String())
+	}
+	if MaxAttributeValueSize < len(data) {
+		return 0, fmt.Errorf("%w value size: %d > %d: %s", ErrInvalid, len(data), MaxAttributeValueSize, char.String())
+	}
+	ctx, cancel := contextWithTimeout(ctx, char.timeouts().Operation)
+	defer cancel()
+	if err := char.pending.wait(ctx); err != nil {
+		return 0, fmt.Errorf("%w: %s", err, char.String())
+	}
+	nWrote, done, err := doStateContext(ctx, func() (int, error) {
+		return char.backendChar.Write(data)
+	}, func(int, error) {})
+	char.pending.set(done)
+	if err != nil {
+		return nWrote, fmt.Errorf("%w: %s", err, char.String())
+	}
+	return nWrote, nil
+}
+
+// WriteWithoutResponse writes the characteristic value without response.
+func (char *backendCharacteristic) WriteWithoutResponse(data []byte) (int, error) {
+	return char.WriteWithoutResponseContext(context.Background(), data)
+}
+
+// WriteWithoutResponseContext writes the characteristic value without response through the paced write queue of the connection.
+// The operation timeout applies if the context has no deadline.
+func (char *backendCharacteristic) WriteWithoutResponseContext(ctx context.Context, data []byte) (int, error) {
+	if char.backendChar == nil {
+		return 0, fmt.Errorf("%w: %s", ErrNotConnected, char.String())
+	}
+	ctx, cancel := contextWithTimeout(ctx, char.timeouts().Operation)
+	defer cancel()
+	// Each write command carries at most the ATT payload, so long values are split into consecutive writes.
+	chunkSize := attPayloadSize(char.mtu())
+	pacer := char.writePacer()
+	nWrote := 0
+	for {
+		if err := pacer.Wait(ctx); err != nil {
+			return nWrote, fmt.Errorf("%w: %s", err, char.String())
+		}
+		if err := char.pending.wait(ctx); err != nil {
+			return nWrote, fmt.Errorf("%w: %s", err, char.String())
+		}
+		chunk := data[nWrote:min(nWrote+chunkSize, len(data))]
+		n, done, err := doStateContext(ctx, func() (int, error) {
+			return char.backendChar.WriteWithoutResponse(chunk)
+		}, func(int, error) {})
+		char.pending.set(done)
+		nWrote += n
+		if err != nil {
+			return nWrote, fmt.Errorf("%w: %s", err, char.String())
+		}
+		if len(data) <= nWrote {
+			return nWrote, nil
+		}
+		if n == 0 {
+			return nWrote, fmt.Errorf("%w: %s", io.ErrShortWrite, char.String())
+		}
+	}
+}
+
+// timeouts returns the timeouts of the device.
+func (char *backendCharacteristic) timeouts() Timeouts {
+	if char.service == nil {
+		return NewDefaultTimeouts()
+	}
+	return deviceTimeouts(char.service.Device())
+}
+
+// mtu returns the negotiated ATT MTU of the device connection.
+func (char *backendCharacteristic) mtu() int {
+	if char.service == nil || char.service.Device() == nil {
+		return DefaultATTMTU
+	}
+	return char.service.Device().MTU()
+}
+
+// writePacer returns the paced write queue of the device connection.
+func (char *backendCharacteristic) writePacer() WritePacer {
+	if char.service == nil || char.service.Device() == nil {
+		return NewWritePacer(WritePacing{}) // nolint: exhaustruct
+	}
+	return char.service.Device().WritePacer()
+}
+
+// Notify sets the callback for characteristic notifications, replacing the callback set before, or unsubscribes it if the callback is nil.
+func (char *backendCharacteristic) Notify(callback OnCharacteristicNotification) error {
+	return char.NotifyContext(context.Background(), callback)
+}
+
+// NotifyContext sets the callback for characteristic notifications with the context. The operation timeout applies if the context has no deadline.
+func (char *backendCharacteristic) NotifyContext(ctx context.Context, callback OnCharacteristicNotification) error {
+	char.notifyMutex.Lock()
+	defer char.notifyMutex.Unlock()
+	if char.notifySub != nil {
+		if callback != nil {
+			char.notifySub.setCallback(callback)
+			return nil
+		}
+		err := char.notifySub.unsubscribe(ctx)
+		char.notifySub = nil
+		return err
+	}
+	if callback == nil {
+		return nil
+	}
+	sub, err := char.subscribe(ctx, callback, newSubscribeParams())
+	if err != nil {
+		return err
+	}
+	char.notifySub = sub
+	return nil
+}
+
+// Subscribe subscribes to characteristic notifications, or indications with WithSubscribeIndication.
+// The first subscription enables notifications on the peripheral, and the later ones share it.
+func (char *backendCharacteristic) Subscribe(callback OnCharacteristicNotification, opts ...SubscribeOption) (Subscription, error) {
+	return char.SubscribeContext(context.Background(), callback, opts...)
+}
+
+// SubscribeContext subscribes to characteristic notifications with the context. The operation timeout applies if the context has no deadline.
+func (char *backendCharacteristic) SubscribeContext(ctx context.Context, callback OnCharacteristicNotification, opts ...SubscribeOption) (Subscription, error) {
+	return char.subscribe(ctx, callback, newSubscribeParams(opts...))
+}
+
+// SubscribeChannel subscribes to the characteristic and returns a channel which receives the values until the context is done.
+func (char *backendCharacteristic) SubscribeChannel(ctx context.Context, opts ...SubscribeOption) (<-chan []byte, error) {
+	return subscribeChannel(ctx, char, opts...)
+}
+
+func (char *backendCharacteristic) subscribe(ctx context.Context, callback OnCharacteristicNotification, params *subscribeParams) (*subscription, error) {
+	if char.backendChar == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotConnected, char.String())
+	}
+	if callback == nil {
+		return nil, fmt.Errorf("%w callback: nil: %s", ErrInvalid, char.String())
+	}
+	ctx, cancel := contextWithTimeout(ctx, char.timeouts().Operation)
+	defer cancel()
+	if err := char.lockSubscriptions(ctx); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, char.String())
+	}
+	defer char.subMutex.Unlock()
+	if len(char.subs) == 0 {
+		// Notifications enabled after the context ended are disabled again since no subscription tracks them.
+		_, done, err := doStateContext(ctx, func() (struct{}, error) {
+			return struct{}{}, char.enableNotifications(params.mode, char.dispatch)
+		}, func(_ struct{}, err error) {
+			if err == nil {
+				_ = char.enableNotifications(params.mode, nil)
+			}
+		})
+		char.pending.set(done)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, char.String())
+		}
+		char.subMode = params.mode
+	} else if char.subMode != params.mode {
+		return nil, fmt.Errorf("%w mode: %s is already subscribed with %s: %s", ErrInvalid, params.mode, char.subMode, char.String())
+	}
+	sub := newSubscription(char, params.mode, callback)
+	char.subs = append(char.subs, sub)
+	return sub, nil
+}
+
+// unsubscribe removes the subscription and disables notifications on the peripheral if it was the last one.
+func (char *backendCharacteristic) unsubscribe(ctx context.Context, sub *subscription) error {
+	ctx, cancel := contextWithTimeout(ctx, char.timeouts().Operation)
+	defer cancel()
+	if err := char.lockSubscriptions(ctx); err != nil {
+		return fmt.Errorf("%w: %s", err, char.String())
+	}
+	defer char.subMutex.Unlock()
+	n := slices.Index(char.subs, sub)
+	if n < 0 {
+		return nil
+	}
+	char.subs = slices.Delete(char.subs, n, n+1)
+	if 0 < len(char.subs) {
+		return nil
+	}
+	mode := char.subMode
+	// Disabling left running when the context ends delays the next subscription until it returns.
+	_, done, err := doStateContext(ctx, func() (struct{}, error) {
+		return struct{}{}, char.enableNotifications(mode, nil)
+	}, func(struct{}, error) {})
+	char.pending.set(done)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, char.String())
+	}
+	return nil
+}
+
+// lockSubscriptions locks the subscriptions after the backend call left running by an earlier operation returns.
+// It waits without the lock since the backend may wait for the notification being dispatched.
+func (char *backendCharacteristic) lockSubscriptions(ctx context.Context) error {
+	for {
+		if err := char.pending.wait(ctx); err != nil {
+			return err
+		}
+		char.subMutex.Lock()
+		if !char.pending.isPending() {
+			return nil
+		}
+		char.subMutex.Unlock()
+	}
+}
+
+// enableNotifications enables notifications or indications on the backend with the callback, or disables them if the callback is nil.
+// If the backend cannot disable them, the values are dropped instead.
+func (char *backendCharacteristic) enableNotifications(mode NotificationMode, callback func(buf []byte)) error {
+	enable := char.backendChar.EnableNotifications
+	if mode == NotificationModeIndicate {
+		enable = char.backendChar.EnableIndications
+	}
+	err := enable(callback)
+	if callback == nil && errors.Is(err, ErrNotSupported) {
+		return enable(func([]byte) {})
+	}
+	return err
+}
+
+// dispatch fans out the received value to all the subscriptions, each with its own copy.
+func (char *backendCharacteristic) dispatch(buf []byte) {
+	char.subMutex.Lock()
+	subs := slices.Clone(char.subs)
+	char.subMutex.Unlock()
+	for _, sub := range subs {
+		sub.notify(slices.Clone(buf))
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"strings"
+)
+
+// CharacteristicProperties represents the properties of a Bluetooth characteristic.
+type CharacteristicProperties uint8
+
+const (
+	// CharacteristicPropertyBroadcast permits broadcasts of the characteristic value.
+	CharacteristicPropertyBroadcast CharacteristicProperties = 0x01
+	// CharacteristicPropertyRead permits reads of the characteristic value.
+	CharacteristicPropertyRead CharacteristicProperties = 0x02
+	// CharacteristicPropertyWriteWithoutResponse permits writes of the characteristic value without response.
+	CharacteristicPropertyWriteWithoutResponse CharacteristicProperties = 0x04
+	// CharacteristicPropertyWrite permits writes of the characteristic value with response.
+	CharacteristicPropertyWrite CharacteristicProperties = 0x08
+	// CharacteristicPropertyNotify permits notifications of the characteristic value.
+	CharacteristicPropertyNotify CharacteristicProperties = 0x10
+	// CharacteristicPropertyIndicate permits indications of the characteristic value.
+	CharacteristicPropertyIndicate CharacteristicProperties = 0x20
+	// CharacteristicPropertyAuthenticatedSignedWrites permits signed writes of the characteristic value.
+	CharacteristicPropertyAuthenticatedSignedWrites CharacteristicProperties = 0x40
+	// CharacteristicPropertyExtendedProperties indicates that additional properties are defined in the extended properties descriptor.
+	CharacteristicPropertyExtendedProperties CharacteristicProperties = 0x80
+)
+
+var characteristicPropertyNames = []struct {
+	prop CharacteristicProperties
+	name string
+}{
+	{CharacteristicPropertyBroadcast, "broadcast"},
+	{CharacteristicPropertyRead, "read"},
+	{CharacteristicPropertyWriteWithoutResponse, "write-without-response"},
+	{CharacteristicPropertyWrite, "write"},
+	{CharacteristicPropertyNotify, "notify"},
+	{CharacteristicPropertyIndicate, "indicate"},
+	{CharacteristicPropertyAuthenticatedSignedWrites, "authenticated-signed-writes"},
+	{CharacteristicPropertyExtendedProperties, "extended-properties"},
+}
+
+// NewCharacteristicPropertiesFromNames returns the properties with the specified names, ignoring unknown names.
+func NewCharacteristicPropertiesFromNames(names ...string) CharacteristicProperties {
+	var props CharacteristicProperties
+	for _, name := range names {
+		for _, p := range characteristicPropertyNames {
+			if p.name == name {
+				props |= p.prop
+			}
+		}
+	}
+	return props
+}
+
+// Has returns whether all the specified properties are set.
+func (props CharacteristicProperties) Has(other CharacteristicProperties) bool {
+	return props&other == other
+}
+
+// IsReadable returns whether the characteristic value can be read.
+func (props CharacteristicProperties) IsReadable() bool {
+	return props.Has(CharacteristicPropertyRead)
+}
+
+// IsWritable returns whether the characteristic value can be written with response.
+func (props CharacteristicProperties) IsWritable() bool {
+	return props.Has(CharacteristicPropertyWrite)
+}
+
+// IsWritableWithoutResponse returns whether the characteristic value can be written without response.
+func (props CharacteristicProperties) IsWritableWithoutResponse() bool {
+	return props.Has(CharacteristicPropertyWriteWithoutResponse)
+}
+
+// IsNotifiable returns whether the characteristic value can be notified.
+func (props CharacteristicProperties) IsNotifiable() bool {
+	return props.Has(CharacteristicPropertyNotify)
+}
+
+// IsIndicatable returns whether the characteristic value can be indicated.
+func (props CharacteristicProperties) IsIndicatable() bool {
+	return props.Has(CharacteristicPropertyIndicate)
+}
+
+// Names returns the names of the set properties.
+func (props CharacteristicProperties) Names() []string {
+	names := []string{}
+	for _, p := range characteristicPropertyNames {
+		if props.Has(p.prop) {
+			names = append(names, p.name)
+		}
+	}
+	return names
+}
+
+// String returns a string representation of the properties.
+func (props CharacteristicProperties) String() string {
+	return strings.Join(props.Names(), "|")
+}
+
+// CharacteristicPermissions represents the access permissions of a local Bluetooth characteristic.
+type CharacteristicPermissions uint8
+
+const (
+	// CharacteristicPermissionRead permits reads of the characteristic value.
+	CharacteristicPermissionRead CharacteristicPermissions = 0x01
+	// CharacteristicPermissionWrite permits writes of the characteristic value.
+	CharacteristicPermissionWrite CharacteristicPermissions = 0x02
+	// CharacteristicPermissionReadEncrypted permits reads of the characteristic value over an encrypted link.
+	CharacteristicPermissionReadEncrypted CharacteristicPermissions = 0x04
+	// CharacteristicPermissionWriteEncrypted permits writes of the characteristic value over an encrypted link.
+	CharacteristicPermissionWriteEncrypted CharacteristicPermissions = 0x08
+	// CharacteristicPermissionReadAuthenticated permits reads of the characteristic value over an authenticated link.
+	CharacteristicPermissionReadAuthenticated CharacteristicPermissions = 0x10
+	// CharacteristicPermissionWriteAuthenticated permits writes of the characteristic value over an authenticated link.
+	CharacteristicPermissionWriteAuthenticated CharacteristicPermissions = 0x20
+)
+
+var characteristicPermissionNames = []struct {
+	perm CharacteristicPermissions
+	name string
+}{
+	{CharacteristicPermissionRead, "read"},
+	{CharacteristicPermissionWrite, "write"},
+	{CharacteristicPermissionReadEncrypted, "read-encrypted"},
+	{CharacteristicPermissionWriteEncrypted, "write-encrypted"},
+	{CharacteristicPermissionReadAuthenticated, "read-authenticated"},
+	{CharacteristicPermissionWriteAuthenticated, "write-authenticated"},
+}
+
+// Has returns whether all the specified permissions are set.
+func (perms CharacteristicPermissions) Has(other CharacteristicPermissions) bool {
+	return perms&other == other
+}
+
+// IsReadable returns whether any read permission is set.
+func (perms CharacteristicPermissions) IsReadable() bool {
+	return perms&(CharacteristicPermissionRead|CharacteristicPermissionReadEncrypted|CharacteristicPermissionReadAuthenticated) != 0
+}
+
+// IsWritable returns whether any write permission is set.
+func (perms CharacteristicPermissions) IsWritable() bool {
+	return perms&(CharacteristicPermissionWrite|CharacteristicPermissionWriteEncrypted|CharacteristicPermissionWriteAuthenticated) != 0
+}
+
+// Names returns the names of the set permissions.
+func (perms CharacteristicPermissions) Names() []string {
+	names := []string{}
+	for _, p := range characteristicPermissionNames {
+		if perms.Has(p.perm) {
+			names = append(names, p.name)
+		}
+	}
+	return names
+}
+
+// String returns a string representation of the permissions.
+func (perms CharacteristicPermissions) String() string {
+	return strings.Join(perms.Names(), "|")
+}
@@ -19,12 +19,23 @@ import (
 	"time"
 
 	"github.com/cybergarage/go-ble/ble"
+	"github.com/cybergarage/go-ble/ble/matter"
 	"github.com/cybergarage/go-logger/log"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	PayloadParamStr = "payload"
 )
 
 func init() {
 	rootCmd.AddCommand(scanCmd)
+
+	viper.SetDefault(PayloadParamStr, "")
+	scanCmd.Flags().String(PayloadParamStr, "", "Matter setup payload (QR code \"MT:...\" or manual pairing code) to look up")
+	viper.BindPFlag(PayloadParamStr, scanCmd.Flags().Lookup(PayloadParamStr))
+	viper.BindEnv(PayloadParamStr) // BLE_LOOKUP_PAYLOAD
 }
 
 var scanCmd = &cobra.Command{ // nolint:exhaustruct
@@ -42,13 +53,25 @@ var scanCmd = &cobra.Command{ // nolint:exhaustruct
 		// 	return err
 		// }
 
+		scanOpts := []ble.ScannerOption{
+			ble.ScanHandler(func(dev ble.Device) {
+				log.Infof("Device responded: %s", dev.String())
+			}),
+		}
+		if payloadStr := viper.GetString(PayloadParamStr); 0 < len(payloadStr) {
+			payload, err := matter.ParseSetupPayload(payloadStr)
+			if err != nil {
+				return err
+			}
+			log.Infof("Matter setup payload: %s", payload.String())
+			scanOpts = append(scanOpts, matter.WithSetupPayload(payload))
+		}
+
 		central := SharedCentral()
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		err := central.Scan(ctx, ble.ScanHandler(func(dev ble.Device) {
-			log.Infof("Device responded: %s", dev.String())
-		}))
+		err := central.Scan(ctx, scanOpts...)
 		if err != nil {
 			log.Fatalf("Failed to scan: %v", err)
 		}
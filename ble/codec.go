@@ -0,0 +1,25 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+// Codec represents a framing codec that delimits application messages in a transport byte stream.
+type Codec interface {
+	// Encode returns the frame of the specified message.
+	Encode(msg []byte) ([]byte, error)
+	// Decode decodes the first frame in the buffer and returns the message and the number of bytes consumed.
+	// It returns zero bytes consumed if the frame is incomplete, and a nil message with a positive count when bytes are skipped without a message.
+	// When the frame is corrupted, it returns an error with the number of bytes to discard.
+	Decode(buf []byte) ([]byte, int, error)
+}
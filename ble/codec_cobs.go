@@ -0,0 +1,82 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"bytes"
+	"fmt"
+)
+
+type cobsCodec struct{}
+
+// NewCOBSCodec returns a codec that encodes messages with Consistent Overhead Byte Stuffing and delimits frames with a zero byte.
+func NewCOBSCodec() Codec {
+	return &cobsCodec{}
+}
+
+// Encode returns the frame of the specified message.
+func (codec *cobsCodec) Encode(msg []byte) ([]byte, error) {
+	frame := make([]byte, 1, len(msg)+len(msg)/254+2)
+	codeIdx := 0
+	code := byte(1)
+	for n, b := range msg {
+		if b != 0 {
+			frame = append(frame, b)
+			code++
+			if code < 0xFF {
+				continue
+			}
+		}
+		frame[codeIdx] = code
+		code = 1
+		codeIdx = len(frame)
+		// A full block at the end of the message needs no further code byte.
+		if b == 0 || n < len(msg)-1 {
+			frame = append(frame, 0)
+		} else {
+			codeIdx = -1
+		}
+	}
+	if 0 <= codeIdx {
+		frame[codeIdx] = code
+	}
+	return append(frame, 0), nil
+}
+
+// Decode decodes the first frame in the buffer and returns the message and the number of bytes consumed.
+// Empty frames between zero bytes are skipped.
+func (codec *cobsCodec) Decode(buf []byte) ([]byte, int, error) {
+	end := bytes.IndexByte(buf, 0)
+	if end < 0 {
+		return nil, 0, nil
+	}
+	if end == 0 {
+		return nil, 1, nil
+	}
+	frame := buf[:end]
+	msg := make([]byte, 0, end)
+	for n := 0; n < len(frame); {
+		code := int(frame[n])
+		if len(frame) < n+code {
+			return nil, end + 1, fmt.Errorf("%w COBS code: 0x%02X", ErrInvalid, frame[n])
+		}
+		msg = append(msg, frame[n+1:n+code]...)
+		n += code
+		if code < 0xFF && n < len(frame) {
+			msg = append(msg, 0)
+		}
+	}
+	return msg, end + 1, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"bytes"
+	"fmt"
+)
+
+type delimiterCodec struct {
+	delim  byte
+	trimCR bool
+	name   string
+}
+
+// NewDelimiterCodec returns a codec that terminates each message with the specified delimiter byte.
+func NewDelimiterCodec(delim byte) Codec {
+	return &delimiterCodec{
+		delim:  delim,
+		trimCR: false,
+		name:   fmt.Sprintf("0x%02X", delim),
+	}
+}
+
+// NewNewlineCodec returns a codec that terminates each message with a newline, accepting CRLF line endings on decoding.
+func NewNewlineCodec() Codec {
+	return &delimiterCodec{
+		delim:  '\n',
+		trimCR: true,
+		name:   "newline",
+	}
+}
+
+// Encode returns the frame of the specified message.
+func (codec *delimiterCodec) Encode(msg []byte) ([]byte, error) {
+	if bytes.IndexByte(msg, codec.delim) >= 0 {
+		return nil, fmt.Errorf("%w message contains the %s delimiter", ErrInvalid, codec.name)
+	}
+	frame := make([]byte, 0, len(msg)+1)
+	frame = append(frame, msg...)
+	return append(frame, codec.delim), nil
+}
+
+// Decode decodes the first frame in the buffer and returns the message and the number of bytes consumed.
+func (codec *delimiterCodec) Decode(buf []byte) ([]byte, int, error) {
+	end := bytes.IndexByte(buf, codec.delim)
+	if end < 0 {
+		return nil, 0, nil
+	}
+	msgLen := end
+	if codec.trimCR && 0 < msgLen && buf[msgLen-1] == '\r' {
+		msgLen--
+	}
+	msg := make([]byte, msgLen)
+	copy(msg, buf[:msgLen])
+	return msg, end + 1, nil
+}
@@ -0,0 +1,170 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+)
+
+// HeaderCodecCRC represents the checksum appended to frames of a header codec.
+type HeaderCodecCRC int
+
+const (
+	// HeaderCodecCRCNone appends no checksum.
+	HeaderCodecCRCNone HeaderCodecCRC = iota
+	// HeaderCodecCRC16 appends a CRC-16/CCITT-FALSE checksum.
+	HeaderCodecCRC16
+	// HeaderCodecCRC32 appends a CRC-32/IEEE checksum.
+	HeaderCodecCRC32
+)
+
+const (
+	headerCodecLengthSize = 2
+)
+
+// HeaderCodecOption represents a function type to set header codec options.
+type HeaderCodecOption func(*headerCodec)
+
+// WithHeaderCodecMagic sets the magic bytes that start each frame and are used to resynchronize after corrupted frames.
+func WithHeaderCodecMagic(magic ...byte) HeaderCodecOption {
+	return func(codec *headerCodec) {
+		codec.magic = magic
+	}
+}
+
+// WithHeaderCodecByteOrder sets the byte order of the length field and the checksum.
+func WithHeaderCodecByteOrder(order binary.ByteOrder) HeaderCodecOption {
+	return func(codec *headerCodec) {
+		codec.order = order
+	}
+}
+
+// WithHeaderCodecCRC sets the checksum appended to each frame.
+func WithHeaderCodecCRC(crc HeaderCodecCRC) HeaderCodecOption {
+	return func(codec *headerCodec) {
+		codec.crc = crc
+	}
+}
+
+type headerCodec struct {
+	magic []byte
+	order binary.ByteOrder
+	crc   HeaderCodecCRC
+}
+
+// NewHeaderCodec returns a codec that frames each message with a fixed header and a trailing checksum.
+// A frame consists of the optional magic bytes, a 2-byte message length, the message and the checksum
+// computed over the length and the message. By default, the byte order is little-endian and the checksum is CRC-16.
+func NewHeaderCodec(opts ...HeaderCodecOption) Codec {
+	codec := &headerCodec{
+		magic: []byte{},
+		order: binary.LittleEndian,
+		crc:   HeaderCodecCRC16,
+	}
+	for _, opt := range opts {
+		opt(codec)
+	}
+	return codec
+}
+
+// Encode returns the frame of the specified message.
+func (codec *headerCodec) Encode(msg []byte) ([]byte, error) {
+	if 0xFFFF < len(msg) {
+		return nil, fmt.Errorf("%w message size: %d > %d", ErrInvalid, len(msg), 0xFFFF)
+	}
+	frame := make([]byte, 0, len(codec.magic)+headerCodecLengthSize+len(msg)+codec.crcSize())
+	frame = append(frame, codec.magic...)
+	length := make([]byte, headerCodecLengthSize)
+	codec.order.PutUint16(length, uint16(len(msg)))
+	frame = append(frame, length...)
+	frame = append(frame, msg...)
+	return append(frame, codec.checksum(frame[len(codec.magic):])...), nil
+}
+
+// Decode decodes the first frame in the buffer and returns the message and the number of bytes consumed.
+// Bytes preceding the magic bytes are skipped.
+func (codec *headerCodec) Decode(buf []byte) ([]byte, int, error) {
+	if 0 < len(codec.magic) {
+		idx := bytes.Index(buf, codec.magic)
+		if idx < 0 {
+			// Keep the tail that may be the beginning of the magic bytes.
+			return nil, max(0, len(buf)-len(codec.magic)+1), nil
+		}
+		if 0 < idx {
+			return nil, idx, nil
+		}
+	}
+	headerSize := len(codec.magic) + headerCodecLengthSize
+	if len(buf) < headerSize {
+		return nil, 0, nil
+	}
+	msgLen := int(codec.order.Uint16(buf[len(codec.magic):]))
+	frameLen := headerSize + msgLen + codec.crcSize()
+	if len(buf) < frameLen {
+		return nil, 0, nil
+	}
+	discard := frameLen
+	if 0 < len(codec.magic) {
+		discard = len(codec.magic)
+	}
+	checksum := codec.checksum(buf[len(codec.magic) : headerSize+msgLen])
+	if !bytes.Equal(checksum, buf[headerSize+msgLen:frameLen]) {
+		return nil, discard, fmt.Errorf("%w checksum: %X != %X", ErrInvalid, buf[headerSize+msgLen:frameLen], checksum)
+	}
+	msg := make([]byte, msgLen)
+	copy(msg, buf[headerSize:headerSize+msgLen])
+	return msg, frameLen, nil
+}
+
+func (codec *headerCodec) crcSize() int {
+	switch codec.crc {
+	case HeaderCodecCRC16:
+		return 2
+	case HeaderCodecCRC32:
+		return 4
+	default:
+		return 0
+	}
+}
+
+func (codec *headerCodec) checksum(b []byte) []byte {
+	sum := make([]byte, codec.crcSize())
+	switch codec.crc {
+	case HeaderCodecCRC16:
+		codec.order.PutUint16(sum, crc16CCITT(b))
+	case HeaderCodecCRC32:
+		codec.order.PutUint32(sum, crc32.ChecksumIEEE(b))
+	}
+	return sum
+}
+
+// crc16CCITT returns the CRC-16/CCITT-FALSE checksum of the bytes.
+func crc16CCITT(b []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, v := range b {
+		crc ^= uint16(v) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+type lengthPrefixCodec struct {
+	size  int
+	order binary.ByteOrder
+}
+
+// NewLengthPrefixCodec returns a codec that prefixes each message with its length encoded in 1, 2 or 4 bytes of the specified byte order.
+func NewLengthPrefixCodec(size int, order binary.ByteOrder) Codec {
+	return &lengthPrefixCodec{
+		size:  size,
+		order: order,
+	}
+}
+
+// Encode returns the frame of the specified message.
+func (codec *lengthPrefixCodec) Encode(msg []byte) ([]byte, error) {
+	maxLen, err := codec.maxLength()
+	if err != nil {
+		return nil, err
+	}
+	if maxLen < uint64(len(msg)) {
+		return nil, fmt.Errorf("%w message size: %d > %d", ErrInvalid, len(msg), maxLen)
+	}
+	frame := make([]byte, codec.size+len(msg))
+	switch codec.size {
+	case 1:
+		frame[0] = byte(len(msg))
+	case 2:
+		codec.order.PutUint16(frame, uint16(len(msg)))
+	default:
+		codec.order.PutUint32(frame, uint32(len(msg)))
+	}
+	copy(frame[codec.size:], msg)
+	return frame, nil
+}
+
+// Decode decodes the first frame in the buffer and returns the message and the number of bytes consumed.
+func (codec *lengthPrefixCodec) Decode(buf []byte) ([]byte, int, error) {
+	if _, err := codec.maxLength(); err != nil {
+		return nil, 0, err
+	}
+	if len(buf) < codec.size {
+		return nil, 0, nil
+	}
+	var msgLen uint64
+	switch codec.size {
+	case 1:
+		msgLen = uint64(buf[0])
+	case 2:
+		msgLen = uint64(codec.order.Uint16(buf))
+	default:
+		msgLen = uint64(codec.order.Uint32(buf))
+	}
+	if uint64(len(buf)-codec.size) < msgLen {
+		return nil, 0, nil
+	}
+	frameLen := codec.size + int(msgLen)
+	msg := make([]byte, msgLen)
+	copy(msg, buf[codec.size:frameLen])
+	return msg, frameLen, nil
+}
+
+func (codec *lengthPrefixCodec) maxLength() (uint64, error) {
+	switch codec.size {
+	case 1:
+		return 0xFF, nil
+	case 2:
+		return 0xFFFF, nil
+	case 4:
+		return 0xFFFFFFFF, nil
+	}
+	return 0, fmt.Errorf("%w length prefix size: %d", ErrInvalid, codec.size)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const (
+	slipEnd    = 0xC0
+	slipEsc    = 0xDB
+	slipEscEnd = 0xDC
+	slipEscEsc = 0xDD
+)
+
+type slipCodec struct{}
+
+// NewSLIPCodec returns a codec that frames messages with SLIP (RFC 1055).
+func NewSLIPCodec() Codec {
+	return &slipCodec{}
+}
+
+// Encode returns the frame of the specified message.
+// The frame starts with an END byte to flush any line noise received before it.
+func (codec *slipCodec) Encode(msg []byte) ([]byte, error) {
+	frame := make([]byte, 0, len(msg)+2)
+	frame = append(frame, slipEnd)
+	for _, b := range msg {
+		switch b {
+		case slipEnd:
+			frame = append(frame, slipEsc, slipEscEnd)
+		case slipEsc:
+			frame = append(frame, slipEsc, slipEscEsc)
+		default:
+			frame = append(frame, b)
+		}
+	}
+	return append(frame, slipEnd), nil
+}
+
+// Decode decodes the first frame in the buffer and returns the message and the number of bytes consumed.
+// Empty frames between END bytes are skipped.
+func (codec *slipCodec) Decode(buf []byte) ([]byte, int, error) {
+	end := bytes.IndexByte(buf, slipEnd)
+	if end < 0 {
+		return nil, 0, nil
+	}
+	if end == 0 {
+		return nil, 1, nil
+	}
+	msg := make([]byte, 0, end)
+	for n := 0; n < end; n++ {
+		b := buf[n]
+		if b != slipEsc {
+			msg = append(msg, b)
+			continue
+		}
+		n++
+		if end <= n {
+			return nil, end + 1, fmt.Errorf("%w SLIP escape at end of frame", ErrInvalid)
+		}
+		switch buf[n] {
+		case slipEscEnd:
+			msg = append(msg, slipEnd)
+		case slipEscEsc:
+			msg = append(msg, slipEsc)
+		default:
+			return nil, end + 1, fmt.Errorf("%w SLIP escape: 0x%02X", ErrInvalid, buf[n])
+		}
+	}
+	return msg, end + 1, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+const (
+	// DefaultATTMTU is the default ATT MTU used before an MTU exchange.
+	DefaultATTMTU = 23
+	// MaxATTMTU is the largest ATT MTU supported by an MTU exchange.
+	MaxATTMTU = 517
+	// MaxAttributeValueSize is the maximum length of an attribute value.
+	MaxAttributeValueSize = 512
+	// attHeaderSize is the size of the opcode and the attribute handle of ATT writes and notifications.
+	attHeaderSize = 3
+)
+
+// ConnectionParameters represents the parameters used to connect to a device.
+type ConnectionParameters struct {
+	// MTU is the ATT MTU requested on connection, or zero to use the largest MTU of the backend.
+	MTU int
+	// WritePacing is the flow control of writes without response on the connection.
+	WritePacing WritePacing
+}
+
+// ConnectOption represents an option for connecting to a device.
+type ConnectOption func(*ConnectionParameters)
+
+// WithConnectMTU requests the specified ATT MTU on connection.
+// Backends which cannot request an MTU, such as the default backend, negotiate the MTU automatically and only cap the reported MTU at the requested MTU.
+func WithConnectMTU(mtu int) ConnectOption {
+	return func(params *ConnectionParameters) {
+		params.MTU = mtu
+	}
+}
+
+// WithConnectWritePacing sets the flow control of writes without response on the connection.
+func WithConnectWritePacing(pacing WritePacing) ConnectOption {
+	return func(params *ConnectionParameters) {
+		params.WritePacing = pacing
+	}
+}
+
+// newConnectionParameters returns the connection parameters with the specified options.
+func newConnectionParameters(opts ...ConnectOption) ConnectionParameters {
+	params := ConnectionParameters{
+		MTU:         0,
+		WritePacing: WritePacing{}, // nolint: exhaustruct
+	}
+	for _, opt := range opts {
+		opt(&params)
+	}
+	return params
+}
+
+// attPayloadSize returns the maximum value size of a single ATT write or notification for the MTU.
+func attPayloadSize(mtu int) int {
+	return max(mtu, DefaultATTMTU) - attHeaderSize
+}
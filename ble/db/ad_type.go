@@ -0,0 +1,51 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+// ADType represents a Bluetooth advertising data type.
+type ADType interface {
+	// ID returns the advertising data type value.
+	ID() int
+	// Name returns the advertising data type name.
+	Name() string
+	// Reference returns the specification which defines the advertising data type.
+	Reference() string
+}
+
+type adType struct {
+	Value int    `yaml:"value"`
+	Nam   string `yaml:"name"`
+	Ref   string `yaml:"reference"`
+}
+
+// nolint: tagliatelle
+type adTypes struct {
+	ADTypes []*adType `yaml:"ad_types"`
+}
+
+// ID returns the advertising data type value.
+func (t *adType) ID() int {
+	return t.Value
+}
+
+// Name returns the advertising data type name.
+func (t *adType) Name() string {
+	return t.Nam
+}
+
+// Reference returns the specification which defines the advertising data type.
+func (t *adType) Reference() string {
+	return t.Ref
+}
@@ -27,6 +27,9 @@ import (
 //go:embed std/company_identifiers.yaml
 var companyIdentifiers []byte
 
+//go:embed std/ad_types.yaml
+var adTypeIdentifiers []byte
+
 //go:embed std/service_uuids.yaml
 var serviceUUIDs []byte
 
@@ -36,6 +39,9 @@ var sdoUUIDs []byte
 //go:embed std/characteristic_uuids.yaml
 var characteristicUUIDs []byte
 
+//go:embed std/descriptors.yaml
+var descriptorUUIDs []byte
+
 // Database represents a Bluetooth database.
 type Database interface {
 	// LookupCompany looks up a company by its ID.
@@ -44,6 +50,10 @@ type Database interface {
 	LookupService(uuid UUID) (Service, bool)
 	// LookupCharacteristic looks up a characteristic by its UUID.
 	LookupCharacteristic(uuid UUID) (Characteristic, bool)
+	// LookupDescriptor looks up a descriptor by its UUID.
+	LookupDescriptor(uuid UUID) (Descriptor, bool)
+	// LookupADType looks up an advertising data type by its value.
+	LookupADType(id int) (ADType, bool)
 }
 
 var sharedDatabase *database
@@ -61,6 +71,21 @@ func init() {
 		companyMap[c.Value] = c
 	}
 
+	// AD Types
+
+	var types adTypes
+	err = yaml.Unmarshal(adTypeIdentifiers, &types)
+	if err != nil {
+		panic(err)
+	}
+	adTypeMap := make(map[int]*adType)
+	for _, t := range types.ADTypes {
+		if _, ok := adTypeMap[t.Value]; ok {
+			continue
+		}
+		adTypeMap[t.Value] = t
+	}
+
 	// Service UUIDs
 
 	var svcs services
@@ -97,10 +122,25 @@ func init() {
 		characteristicMap[c.uuid] = c
 	}
 
+	// Descriptor UUIDs
+
+	var descs descriptors
+	err = yaml.Unmarshal(descriptorUUIDs, &descs)
+	if err != nil {
+		panic(err)
+	}
+	descriptorMap := make(map[UUID]*descriptor)
+	for _, d := range descs.Descriptors {
+		d.uuid = NewUUIDFromUUID16(d.Uuid)
+		descriptorMap[d.uuid] = d
+	}
+
 	sharedDatabase = &database{
 		companies: companyMap,
 		services:  serviceMap,
 		chars:     characteristicMap,
+		descs:     descriptorMap,
+		adTypes:   adTypeMap,
 	}
 }
 
@@ -113,6 +153,8 @@ type database struct {
 	companies map[int]*company
 	services  map[UUID]*service
 	chars     map[UUID]*characteristic
+	descs     map[UUID]*descriptor
+	adTypes   map[int]*adType
 }
 
 // LookupCompany looks up a company by its ID.
@@ -165,3 +207,30 @@ func (db *database) LookupCharacteristic(uuid UUID) (Characteristic, bool) {
 		Id:   "",
 	}, false
 }
+
+// LookupDescriptor looks up a descriptor by its UUID.
+func (db *database) LookupDescriptor(uuid UUID) (Descriptor, bool) {
+	dbDesc, ok := db.descs[uuid]
+	if ok {
+		return dbDesc, true
+	}
+	return &descriptor{
+		Uuid: 0,
+		uuid: uuid,
+		Nam:  "",
+		Id:   "",
+	}, false
+}
+
+// LookupADType looks up an advertising data type by its value.
+func (db *database) LookupADType(id int) (ADType, bool) {
+	dbADType, ok := db.adTypes[id]
+	if ok {
+		return dbADType, true
+	}
+	return &adType{
+		Value: id,
+		Nam:   "",
+		Ref:   "",
+	}, false
+}
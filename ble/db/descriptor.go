@@ -0,0 +1,53 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package db
+
+// Descriptor represents a Bluetooth Descriptor.
+type Descriptor interface {
+	// UUID returns the Descriptor UUID.
+	UUID() UUID
+	// Name returns the Descriptor name.
+	Name() string
+	// ID returns the Descriptor ID.
+	ID() string
+}
+
+// nolint: staticcheck
+type descriptor struct {
+	Uuid uint16 `yaml:"uuid"`
+	Nam  string `yaml:"name"`
+	Id   string `yaml:"id"`
+	uuid UUID   `yaml:"-"`
+}
+
+// nolint: tagliatelle
+type descriptors struct {
+	Descriptors []*descriptor `yaml:"uuids"`
+}
+
+// UUID returns the Descriptor UUID.
+func (desc *descriptor) UUID() UUID {
+	return desc.uuid
+}
+
+// Name returns the Descriptor name.
+func (desc *descriptor) Name() string {
+	return desc.Nam
+}
+
+// ID returns the Descriptor ID.
+func (desc *descriptor) ID() string {
+	return desc.Id
+}
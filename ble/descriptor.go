@@ -0,0 +1,164 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cybergarage/go-ble/ble/db"
+)
+
+// Descriptor represents a Bluetooth characteristic descriptor.
+type Descriptor interface {
+	// Characteristic returns the characteristic that the descriptor belongs to.
+	Characteristic() Characteristic
+	// UUID returns the descriptor UUID.
+	UUID() UUID
+	// Name returns the descriptor name.
+	Name() string
+	// ID returns the descriptor ID.
+	ID() string
+	// Read reads the descriptor value within the operation timeout of the device.
+	Read() ([]byte, error)
+	// ReadContext reads the descriptor value with the context. The operation timeout applies if the context has no deadline.
+	ReadContext(ctx context.Context) ([]byte, error)
+	// Write writes the descriptor value with response within the operation timeout of the device.
+	Write([]byte) (int, error)
+	// WriteContext writes the descriptor value with the context. The operation timeout applies if the context has no deadline.
+	WriteContext(ctx context.Context, data []byte) (int, error)
+	// MarshalObject returns an object suitable for marshaling to JSON.
+	MarshalObject() any
+	// String returns a string representation of the descriptor.
+	String() string
+}
+
+type descriptor struct {
+	char        Characteristic
+	db          db.Descriptor
+	uuid        UUID
+	backendDesc BackendDescriptor
+	pending     pendingCall
+}
+
+func newDescriptor(char Characteristic, uuid UUID, desc BackendDescriptor) *descriptor {
+	dbDesc, _ := db.DefaultDatabase().LookupDescriptor(uuid)
+	return &descriptor{
+		char:        char,
+		db:          dbDesc,
+		uuid:        uuid,
+		backendDesc: desc,
+		pending:     pendingCall{}, // nolint: exhaustruct
+	}
+}
+
+// Characteristic returns the characteristic that the descriptor belongs to.
+func (desc *descriptor) Characteristic() Characteristic {
+	return desc.char
+}
+
+// UUID returns the descriptor UUID.
+func (desc *descriptor) UUID() UUID {
+	return desc.uuid
+}
+
+// Name returns the descriptor name.
+func (desc *descriptor) Name() string {
+	return desc.db.Name()
+}
+
+// ID returns the descriptor ID.
+func (desc *descriptor) ID() string {
+	return desc.db.ID()
+}
+
+// Read reads the descriptor value within the operation timeout of the device.
+func (desc *descriptor) Read() ([]byte, error) {
+	return desc.ReadContext(context.Background())
+}
+
+// ReadContext reads the descriptor value with the context. The operation timeout applies if the context has no deadline.
+func (desc *descriptor) ReadContext(ctx context.Context) ([]byte, error) {
+	if desc.backendDesc == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotConnected, desc.String())
+	}
+	ctx, cancel := contextWithTimeout(ctx, desc.timeouts().Operation)
+	defer cancel()
+	data, err := doContext(ctx, desc.backendDesc.Read)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, desc.String())
+	}
+	return data, nil
+}
+
+// Write writes the descriptor value with response within the operation timeout of the device.
+func (desc *descriptor) Write(data []byte) (int, error) {
+	return desc.WriteContext(context.Background(), data)
+}
+
+// WriteContext writes the descriptor value with the context. The operation timeout applies if the context has no deadline.
+// A write left running when the context ends delays the next write of the descriptor until it returns.
+func (desc *descriptor) WriteContext(ctx context.Context, data []byte) (int, error) {
+	if desc.backendDesc == nil {
+		return 0, fmt.Errorf("%w: %s", ErrNotConnected, desc.String())
+	}
+	if MaxAttributeValueSize < len(data) {
+		return 0, fmt.Errorf("%w value size: %d > %d: %s", ErrInvalid, len(data), MaxAttributeValueSize, desc.String())
+	}
+	ctx, cancel := contextWithTimeout(ctx, desc.timeouts().Operation)
+	defer cancel()
+	if err := desc.pending.wait(ctx); err != nil {
+		return 0, fmt.Errorf("%w: %s", err, desc.String())
+	}
+	nWrote, done, err := doStateContext(ctx, func() (int, error) {
+		return desc.backendDesc.Write(data)
+	}, func(int, error) {})
+	desc.pending.set(done)
+	if err != nil {
+		return nWrote, fmt.Errorf("%w: %s", err, desc.String())
+	}
+	return nWrote, nil
+}
+
+// timeouts returns the timeouts of the device.
+func (desc *descriptor) timeouts() Timeouts {
+	if desc.char == nil || desc.char.Service() == nil {
+		return NewDefaultTimeouts()
+	}
+	return deviceTimeouts(desc.char.Service().Device())
+}
+
+// MarshalObject returns an object suitable for marshaling to JSON.
+func (desc *descriptor) MarshalObject() any {
+	return struct {
+		UUID string `json:"uuid"`
+		Name string `json:"name"`
+		ID   string `json:"id"`
+	}{
+		UUID: desc.UUID().String(),
+		Name: desc.Name(),
+		ID:   desc.ID(),
+	}
+}
+
+// String returns a string representation of the descriptor.
+func (desc *descriptor) String() string {
+	b, err := json.Marshal(desc.MarshalObject())
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
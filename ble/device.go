@@ -31,16 +31,22 @@ type Device interface {
 
 // DeviceDescriptor represents a read-only Bluetooth device descriptor.
 type DeviceDescriptor interface {
-	// Manufacturer returns the Bluetooth manufacturer of the device.
+	// Manufacturer returns the Bluetooth manufacturer of the device, which is the last one if the device advertises several.
 	Manufacturer() Manufacturer
+	// Manufacturers returns all the Bluetooth manufacturers of the device in the advertised order.
+	Manufacturers() []Manufacturer
+	// LookupManufacturer looks up a Bluetooth manufacturer of the device by its company ID.
+	LookupManufacturer(id int) (Manufacturer, bool)
 	// LocalName returns the local name of the device.
 	LocalName() string
 	// Address returns the Bluetooth address of the device.
 	Address() Address
-	// Services returns the Bluetooth services of the device.
+	// Services returns the discovered GATT services of the device if it is connected and its services are discovered, or the advertised services otherwise.
 	Services() []Service
 	// RSSI returns the received signal strength indicator of the device.
 	RSSI() int
+	// Advertisement returns the latest advertisement of the device.
+	Advertisement() Advertisement
 	// DiscoveredAt returns the time when the device was first discovered.
 	DiscoveredAt() time.Time
 	// ModifiedAt returns the time when the device was last modified.
@@ -51,12 +57,27 @@ type DeviceDescriptor interface {
 
 // DeviceOperator represents a Bluetooth device operator.
 type DeviceOperator interface {
-	// Connect connects to the device.
-	Connect(ctx context.Context) error
-	// Disconnect disconnects from the device.
+	// Connect connects to the device with the specified options. The connect timeout applies if the context has no deadline.
+	// If the device is already connected, the connection is closed first.
+	Connect(ctx context.Context, opts ...ConnectOption) error
+	// Disconnect disconnects from the device within the operation timeout.
 	Disconnect() error
+	// DisconnectContext disconnects from the device. The operation timeout applies if the context has no deadline.
+	DisconnectContext(ctx context.Context) error
 	// IsConnected returns whether the device is connected.
 	IsConnected() bool
+	// MTU returns the negotiated ATT MTU of the connection, or DefaultATTMTU if the device is not connected.
+	MTU() int
+	// WritePacer returns the paced write queue of the connection which throttles writes without response.
+	WritePacer() WritePacer
 	// LookupService looks up a service by its UUID. The UUID can be of any type accepted such as string, uint16, uint32, []byte, or UUID.
 	LookupService(uuid any) (Service, bool)
+	// LookupServiceContext looks up a service by its UUID, discovering it within the operation timeout if the context has no deadline.
+	// It returns ErrNotFound if the device has no such service.
+	LookupServiceContext(ctx context.Context, uuid any) (Service, error)
+	// DiscoverServices discovers all the primary, secondary and included services with their characteristics and descriptors, and caches them on the device.
+	// The cache is invalidated when the device disconnects or indicates a Service Changed. The operation timeout applies if the context has no deadline.
+	DiscoverServices(ctx context.Context) ([]Service, error)
+	// Timeouts returns the timeouts applied to the operations of the device whose context has no deadline.
+	Timeouts() Timeouts
 }
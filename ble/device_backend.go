@@ -0,0 +1,582 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+type backendDevice struct {
+	*baseDevice
+	backend       Backend
+	timeouts      Timeouts
+	scanResult    ScanResult
+	advMutex      sync.RWMutex
+	localName     string
+	rssi          int
+	manufacturers []Manufacturer
+	adv           Advertisement
+	adServiceMap  sync.Map
+	connMutex     sync.RWMutex
+	conn          BackendConnection
+	pacer         WritePacer
+	gattMutex     sync.RWMutex
+	gattServices  []Service
+	gattSub       Subscription
+	gattSubMutex  sync.Mutex
+}
+
+func newDeviceFromScanResult(backend Backend, timeouts Timeouts, scanResult ScanResult) *backendDevice {
+	dev := &backendDevice{
+		baseDevice:    newBaseDevice(),
+		backend:       backend,
+		timeouts:      timeouts,
+		scanResult:    scanResult,
+		advMutex:      sync.RWMutex{},
+		localName:     scanResult.LocalName(),
+		rssi:          scanResult.RSSI(),
+		manufacturers: scanResult.ManufacturerData(),
+		adv:           newAdvertisementFromScanResult(scanResult),
+		adServiceMap:  sync.Map{},
+		connMutex:     sync.RWMutex{},
+		conn:          nil,
+		pacer:         NewWritePacer(WritePacing{}), // nolint: exhaustruct
+		gattMutex:     sync.RWMutex{},
+		gattServices:  nil,
+		gattSub:       nil,
+		gattSubMutex:  sync.Mutex{},
+	}
+	for _, sd := range scanResult.ServiceData() {
+		dev.addServiceDataElement(sd)
+	}
+	return dev
+}
+
+// Manufacturer returns the Bluetooth manufacturer of the device, which is the last one if the device advertises several.
+func (dev *backendDevice) Manufacturer() Manufacturer {
+	manufacturers := dev.Manufacturers()
+	if len(manufacturers) == 0 {
+		return newNilManufacturer()
+	}
+	return manufacturers[len(manufacturers)-1]
+}
+
+// Manufacturers returns all the Bluetooth manufacturers of the device in the advertised order.
+func (dev *backendDevice) Manufacturers() []Manufacturer {
+	dev.advMutex.RLock()
+	defer dev.advMutex.RUnlock()
+	return dev.manufacturers
+}
+
+// LookupManufacturer looks up a Bluetooth manufacturer of the device by its company ID.
+func (dev *backendDevice) LookupManufacturer(id int) (Manufacturer, bool) {
+	for _, manufacturer := range dev.Manufacturers() {
+		if manufacturer.ID() == id {
+			return manufacturer, true
+		}
+	}
+	return nil, false
+}
+
+// LocalName returns the local name of the device.
+func (dev *backendDevice) LocalName() string {
+	dev.advMutex.RLock()
+	defer dev.advMutex.RUnlock()
+	return dev.localName
+}
+
+// Address returns the Bluetooth address of the device.
+func (dev *backendDevice) Address() Address {
+	return dev.scanResult.Address()
+}
+
+// RSSI returns the received signal strength indicator of the device.
+func (dev *backendDevice) RSSI() int {
+	dev.advMutex.RLock()
+	defer dev.advMutex.RUnlock()
+	return dev.rssi
+}
+
+// Advertisement returns the latest advertisement of the device.
+func (dev *backendDevice) Advertisement() Advertisement {
+	dev.advMutex.RLock()
+	defer dev.advMutex.RUnlock()
+	return dev.adv
+}
+
+// updateFromScanDevice merges a later advertisement of the device into the device and returns the changed fields.
+// The local name and the manufacturer data are kept if the advertisement omits them, such as without a scan response.
+// A change of the advertised service UUIDs is reported as DeviceChangeServices unless the advertisement omits them.
+func (dev *backendDevice) updateFromScanDevice(scanDev *backendDevice, now time.Time) DeviceChanges {
+	var changes DeviceChanges
+	dev.advMutex.Lock()
+	if dev.rssi != scanDev.RSSI() {
+		dev.rssi = scanDev.RSSI()
+		changes |= DeviceChangeRSSI
+	}
+	if name := scanDev.LocalName(); 0 < len(name) && name != dev.localName {
+		dev.localName = name
+		changes |= DeviceChangeLocalName
+	}
+	if manufacturers := scanDev.Manufacturers(); 0 < len(manufacturers) && !equalManufacturers(manufacturers, dev.manufacturers) {
+		dev.manufacturers = manufacturers
+		changes |= DeviceChangeManufacturerData
+	}
+	adv := scanDev.Advertisement()
+	if uuids := adv.ServiceUUIDs(); 0 < len(uuids) && !slices.EqualFunc(uuids, dev.adv.ServiceUUIDs(), UUID.Equal) {
+		changes |= DeviceChangeServices
+	}
+	dev.adv = adv
+	dev.advMutex.Unlock()
+
+	for _, scanService := range scanDev.advertisedServices() {
+		service, ok := dev.lookupAdvertisedService(scanService.UUID())
+		if ok && bytes.Equal(service.Data(), scanService.Data()) {
+			continue
+		}
+		dev.addService(newService(dev, scanService.UUID(), scanService.Data(), []Characteristic{}))
+		changes |= DeviceChangeServices
+	}
+
+	dev.setLastSeenAt(now)
+	if changes&^DeviceChangeRSSI != 0 {
+		dev.setModifiedAt(now)
+	}
+	return changes
+}
+
+func equalManufacturers(a []Manufacturer, b []Manufacturer) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for n := range a {
+		if a[n].ID() != b[n].ID() || !bytes.Equal(a[n].Data(), b[n].Data()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (dev *backendDevice) lookupAdvertisedService(lookupUUID UUID) (Service, bool) {
+	for _, service := range dev.advertisedServices() {
+		if lookupUUID.Equal(service.UUID()) {
+			return service, true
+		}
+	}
+	return nil, false
+}
+
+// LookupService looks up a Bluetooth service by its UUID.
+func (dev *backendDevice) LookupService(anyUUID any) (Service, bool) {
+	service, err := dev.LookupServiceContext(context.Background(), anyUUID)
+	if err != nil {
+		return nil, false
+	}
+	return service, true
+}
+
+// LookupServiceContext looks up a Bluetooth service by its UUID, discovering it within the operation timeout if the context has no deadline.
+func (dev *backendDevice) LookupServiceContext(ctx context.Context, anyUUID any) (Service, error) {
+	lookupUUID, err := NewUUIDFrom(anyUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// If not connected, look up in the cached services.
+	conn := dev.connection()
+	if conn == nil {
+		service, ok := dev.lookupAdvertisedService(lookupUUID)
+		if !ok {
+			return nil, fmt.Errorf("service %w: %s", ErrNotFound, lookupUUID.String())
+		}
+		return service, nil
+	}
+
+	// If connected, look up in the discovered services, discovering them from the device if not cached.
+	services, ok := dev.discoveredServices()
+	if !ok {
+		services, err = dev.DiscoverServices(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, service := range services {
+		if lookupUUID.Equal(service.UUID()) {
+			return service, nil
+		}
+	}
+	return nil, fmt.Errorf("service %w: %s", ErrNotFound, lookupUUID.String())
+}
+
+// DiscoverServices discovers all the primary, secondary and included services with their characteristics and descriptors, and caches them on the device.
+// The cache is invalidated when the device disconnects or indicates a Service Changed. The operation timeout applies if the context has no deadline.
+func (dev *backendDevice) DiscoverServices(ctx context.Context) ([]Service, error) {
+	conn := dev.connection()
+	if conn == nil {
+		return nil, ErrNotConnected
+	}
+	ctx, cancel := contextWithTimeout(ctx, dev.timeouts.Operation)
+	defer cancel()
+	services, err := doContext(ctx, func() ([]Service, error) {
+		return dev.discoverServices(conn)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !dev.setDiscoveredServices(conn, services) {
+		return nil, fmt.Errorf("%w: disconnected during the discovery", ErrNotConnected)
+	}
+	// The subscription has its own operation timeout since the discovery may have used up the deadline.
+	subCtx, subCancel := contextWithTimeout(context.WithoutCancel(ctx), dev.timeouts.Operation)
+	defer subCancel()
+	dev.subscribeServiceChanged(subCtx, conn, services)
+	return services, nil
+}
+
+// discoverServices discovers all the services with the characteristics and their descriptors using the backend.
+func (dev *backendDevice) discoverServices(conn BackendConnection) ([]Service, error) {
+	backendServices, err := conn.DiscoverServices(nil)
+	if err != nil {
+		return nil, err
+	}
+	services := make([]*backendService, 0, len(backendServices))
+	for _, backendService := range backendServices {
+		service, err := dev.discoverService(backendService)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, service)
+	}
+	discovered := make([]Service, 0, len(services))
+	for _, service := range services {
+		discovered = append(discovered, service)
+	}
+	for _, service := range services {
+		service.resolveIncludedServices(discovered)
+	}
+	return discovered, nil
+}
+
+// discoverService discovers the characteristics and their descriptors of the service using the backend.
+func (dev *backendDevice) discoverService(backendService BackendService) (*backendService, error) {
+	backendServiceUUID := backendService.UUID()
+	backendChars, err := backendService.DiscoverCharacteristics(nil)
+	if err != nil {
+		return nil, err
+	}
+	adData := []byte{}
+	adService, ok := dev.lookupAdvertisedService(backendServiceUUID)
+	if ok {
+		adData = adService.Data()
+	}
+	service := newBackendService(
+		dev,
+		backendService,
+		backendServiceUUID,
+		adData,
+		[]Characteristic{},
+	)
+	for _, backendChar := range backendChars {
+		char := newBackendCharacteristic(
+			service,
+			backendChar.UUID(),
+			backendChar,
+		)
+		if err := char.discoverDescriptors(); err != nil {
+			return nil, err
+		}
+		service.addDeviceCharacteristic(char)
+	}
+	return service, nil
+}
+
+// discoveredServices returns the cached GATT services, or false if they are not discovered.
+func (dev *backendDevice) discoveredServices() ([]Service, bool) {
+	dev.gattMutex.RLock()
+	defer dev.gattMutex.RUnlock()
+	if dev.gattServices == nil {
+		return nil, false
+	}
+	return dev.gattServices, true
+}
+
+// setDiscoveredServices caches the GATT services discovered on the connection, or returns false if the device is no longer connected with it.
+func (dev *backendDevice) setDiscoveredServices(conn BackendConnection, services []Service) bool {
+	dev.gattMutex.Lock()
+	defer dev.gattMutex.Unlock()
+	if dev.connection() != conn {
+		return false
+	}
+	dev.gattServices = services
+	return true
+}
+
+// invalidateDiscoveredServices clears the cached GATT services and unsubscribes from Service Changed indications.
+func (dev *backendDevice) invalidateDiscoveredServices() {
+	dev.gattMutex.Lock()
+	sub := dev.gattSub
+	dev.gattServices = nil
+	dev.gattSub = nil
+	dev.gattMutex.Unlock()
+	if sub != nil {
+		sub.Unsubscribe()
+	}
+}
+
+// subscribeServiceChanged subscribes to Service Changed indications of the Generic Attribute service, which invalidate the cached GATT services.
+// Devices which do not indicate Service Changed are cached until they disconnect.
+func (dev *backendDevice) subscribeServiceChanged(ctx context.Context, conn BackendConnection, services []Service) {
+	// Concurrent discoveries subscribe one at a time since their characteristics share the backend characteristic,
+	// and unsubscribing a redundant subscription would disable the indications of the other.
+	dev.gattSubMutex.Lock()
+	defer dev.gattSubMutex.Unlock()
+	if !dev.needsServiceChangedSubscription(conn) {
+		return
+	}
+	for _, service := range services {
+		if !GenericAttributeServiceUUID.Equal(service.UUID()) {
+			continue
+		}
+		char, ok := service.LookupCharacteristic(ServiceChangedUUID)
+		if !ok {
+			return
+		}
+		// Some backends do not report the properties and select notifications or indications by themselves,
+		// so the subscription is attempted in the default mode and its error ignored.
+		props := char.Properties()
+		opts := []SubscribeOption{}
+		switch {
+		case props == 0:
+		case props.IsIndicatable():
+			opts = append(opts, WithSubscribeIndication())
+		default:
+			return
+		}
+		sub, err := char.SubscribeContext(ctx, func(char Characteristic, buf []byte) {
+			dev.onServiceChanged()
+		}, opts...)
+		if err != nil {
+			return
+		}
+		// The device may have disconnected or connected again meanwhile.
+		dev.gattMutex.Lock()
+		if dev.gattSub != nil || dev.connection() != conn {
+			dev.gattMutex.Unlock()
+			sub.Unsubscribe()
+			return
+		}
+		dev.gattSub = sub
+		dev.gattMutex.Unlock()
+		return
+	}
+}
+
+// needsServiceChangedSubscription returns whether the device is still connected with the connection and not subscribed to Service Changed indications.
+func (dev *backendDevice) needsServiceChangedSubscription(conn BackendConnection) bool {
+	dev.gattMutex.RLock()
+	defer dev.gattMutex.RUnlock()
+	return dev.gattSub == nil && dev.connection() == conn
+}
+
+// onServiceChanged clears the cached GATT services when the device indicates a Service Changed.
+func (dev *backendDevice) onServiceChanged() {
+	dev.gattMutex.Lock()
+	defer dev.gattMutex.Unlock()
+	dev.gattServices = nil
+}
+
+func (dev *backendDevice) addServiceDataElement(sd ServiceData) {
+	service := newService(
+		dev,
+		sd.UUID(),
+		sd.Data(),
+		[]Characteristic{}, // No characteristics in scan result
+	)
+	dev.addService(service)
+}
+
+func (dev *backendDevice) addService(service Service) {
+	dev.adServiceMap.Store(service.UUID(), service)
+}
+
+// Services returns the discovered GATT services of the device if it is connected and its services are discovered, or the advertised services otherwise.
+func (dev *backendDevice) Services() []Service {
+	if dev.IsConnected() {
+		if services, ok := dev.discoveredServices(); ok {
+			return services
+		}
+	}
+	return dev.advertisedServices()
+}
+
+// advertisedServices returns the services advertised with service data.
+func (dev *backendDevice) advertisedServices() []Service {
+	services := make([]Service, 0)
+	dev.adServiceMap.Range(func(key, value any) bool {
+		service, ok := value.(Service)
+		if ok {
+			services = append(services, service)
+		}
+		return true
+	})
+	return services
+}
+
+// Connect connects to the device with the specified options. The connect timeout applies if the context has no deadline.
+// If the device is already connected, the connection is closed first, and the GATT services discovered on it are cleared.
+func (dev *backendDevice) Connect(ctx context.Context, opts ...ConnectOption) error {
+	ctx, cancel := contextWithTimeout(ctx, dev.timeouts.Connect)
+	defer cancel()
+	if dev.connection() != nil {
+		if err := dev.DisconnectContext(ctx); err != nil {
+			return err
+		}
+	}
+	params := newConnectionParameters(opts...)
+	conn, err := dev.backend.Connect(ctx, dev.Address(), params)
+	if err != nil {
+		return err
+	}
+	// The services discovered before are cleared before the connection is published, so that a discovery on it is kept.
+	dev.invalidateDiscoveredServices()
+	dev.connMutex.Lock()
+	dev.conn = conn
+	dev.pacer = NewWritePacer(params.WritePacing)
+	dev.connMutex.Unlock()
+	return nil
+}
+
+// Disconnect disconnects from the device within the operation timeout.
+func (dev *backendDevice) Disconnect() error {
+	return dev.DisconnectContext(context.Background())
+}
+
+// DisconnectContext disconnects from the device. The operation timeout applies if the context has no deadline.
+// If the context ends first, the connection is still released once the backend disconnects.
+func (dev *backendDevice) DisconnectContext(ctx context.Context) error {
+	conn := dev.connection()
+	if conn == nil {
+		return nil
+	}
+	dev.invalidateDiscoveredServices()
+	ctx, cancel := contextWithTimeout(ctx, dev.timeouts.Operation)
+	defer cancel()
+	_, _, err := doStateContext(ctx, func() (struct{}, error) {
+		return struct{}{}, conn.Disconnect()
+	}, func(_ struct{}, err error) {
+		if err == nil {
+			dev.releaseConnection(conn)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	dev.releaseConnection(conn)
+	return nil
+}
+
+// releaseConnection forgets the backend connection unless the device has connected again since.
+func (dev *backendDevice) releaseConnection(conn BackendConnection) {
+	dev.connMutex.Lock()
+	defer dev.connMutex.Unlock()
+	if dev.conn == conn {
+		dev.conn = nil
+	}
+}
+
+// connection returns the backend connection, or nil if the device is not connected.
+func (dev *backendDevice) connection() BackendConnection {
+	dev.connMutex.RLock()
+	defer dev.connMutex.RUnlock()
+	return dev.conn
+}
+
+// IsConnected returns whether the device is connected.
+func (dev *backendDevice) IsConnected() bool {
+	return dev.connection() != nil
+}
+
+// MTU returns the negotiated ATT MTU of the connection, or DefaultATTMTU if the device is not connected.
+func (dev *backendDevice) MTU() int {
+	conn := dev.connection()
+	if conn == nil {
+		return DefaultATTMTU
+	}
+	return conn.MTU()
+}
+
+// Timeouts returns the timeouts applied to the operations of the device whose context has no deadline.
+func (dev *backendDevice) Timeouts() Timeouts {
+	return dev.timeouts
+}
+
+// WritePacer returns the paced write queue of the connection which throttles writes without response.
+func (dev *backendDevice) WritePacer() WritePacer {
+	dev.connMutex.RLock()
+	defer dev.connMutex.RUnlock()
+	return dev.pacer
+}
+
+// MarshalObject returns an object suitable for marshaling to JSON.
+func (dev *backendDevice) MarshalObject() any {
+	manufacturers := dev.Manufacturers()
+	manufacturerObjs := make([]any, 0, len(manufacturers))
+	for _, manufacturer := range manufacturers {
+		manufacturerObjs = append(manufacturerObjs, manufacturer.MarshalObject())
+	}
+	devServices := dev.Services()
+	serviceObjs := make([]any, 0, len(devServices))
+	for _, service := range devServices {
+		serviceObjs = append(serviceObjs, service.MarshalObject())
+	}
+	return struct {
+		Address       string `json:"address"`
+		LocalName     string `json:"localName"`
+		Manufacturer  any    `json:"manufacturer"`
+		Manufacturers []any  `json:"manufacturers"`
+		RSSI          int    `json:"rssi"`
+		Advertisement any    `json:"advertisement"`
+		Services      []any  `json:"services"`
+		DiscoveredAt  string `json:"discoveredAt"`
+		ModifiedAt    string `json:"modifiedAt"`
+		LastSeenAt    string `json:"lastSeenAt"`
+	}{
+		Address:       dev.Address().String(),
+		LocalName:     dev.LocalName(),
+		Manufacturer:  dev.Manufacturer().MarshalObject(),
+		Manufacturers: manufacturerObjs,
+		RSSI:          dev.RSSI(),
+		Advertisement: dev.Advertisement().MarshalObject(),
+		Services:      serviceObjs,
+		DiscoveredAt:  dev.DiscoveredAt().Format(time.RFC3339),
+		ModifiedAt:    dev.ModifiedAt().Format(time.RFC3339),
+		LastSeenAt:    dev.LastSeenAt().Format(time.RFC3339),
+	}
+}
+
+// String returns a string representation of the device.
+func (dev *backendDevice) String() string {
+	b, err := json.Marshal(dev.MarshalObject())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
@@ -17,39 +17,65 @@ package ble
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 )
 
 type baseDevice struct {
+	timeMutex    sync.RWMutex
 	discoveredAt time.Time
 	modifiedAt   time.Time
 	lastSeenAt   time.Time
 }
 
 func newBaseDevice() *baseDevice {
-	now := time.Now()
+	return newBaseDeviceAt(time.Now())
+}
+
+func newBaseDeviceAt(t time.Time) *baseDevice {
 	return &baseDevice{
-		discoveredAt: now,
-		modifiedAt:   now,
-		lastSeenAt:   now,
+		timeMutex:    sync.RWMutex{},
+		discoveredAt: t,
+		modifiedAt:   t,
+		lastSeenAt:   t,
 	}
 }
 
 // DiscoveredAt returns the time when the device was discovered.
 func (baseDev *baseDevice) DiscoveredAt() time.Time {
+	baseDev.timeMutex.RLock()
+	defer baseDev.timeMutex.RUnlock()
 	return baseDev.discoveredAt
 }
 
 // ModifiedAt returns the time when the device was last modified.
 func (baseDev *baseDevice) ModifiedAt() time.Time {
+	baseDev.timeMutex.RLock()
+	defer baseDev.timeMutex.RUnlock()
 	return baseDev.modifiedAt
 }
 
 // LastSeenAt returns the time when the device was last seen.
 func (baseDev *baseDevice) LastSeenAt() time.Time {
+	baseDev.timeMutex.RLock()
+	defer baseDev.timeMutex.RUnlock()
 	return baseDev.lastSeenAt
 }
 
+// setLastSeenAt sets the time when the device was last seen.
+func (baseDev *baseDevice) setLastSeenAt(t time.Time) {
+	baseDev.timeMutex.Lock()
+	defer baseDev.timeMutex.Unlock()
+	baseDev.lastSeenAt = t
+}
+
+// setModifiedAt sets the time when the device was last modified.
+func (baseDev *baseDevice) setModifiedAt(t time.Time) {
+	baseDev.timeMutex.Lock()
+	defer baseDev.timeMutex.Unlock()
+	baseDev.modifiedAt = t
+}
+
 // String returns a string representation of the device.
 func (baseDev *baseDevice) StringFrom(dev Device) string {
 	devServices := dev.Services()
@@ -0,0 +1,131 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultDeviceLostTimeout is the default duration without advertisements after which a device is reported lost.
+	DefaultDeviceLostTimeout = time.Duration(30 * time.Second)
+)
+
+// DeviceEventType represents the type of a device lifecycle event.
+type DeviceEventType int
+
+const (
+	// DeviceDiscovered indicates that the device advertised for the first time, or again after it was lost.
+	DeviceDiscovered DeviceEventType = iota
+	// DeviceUpdated indicates that an advertisement of the device changed some of its fields.
+	DeviceUpdated
+	// DeviceLost indicates that the device has not advertised for the lost timeout.
+	DeviceLost
+)
+
+// String returns the string representation of the event type.
+func (t DeviceEventType) String() string {
+	switch t {
+	case DeviceDiscovered:
+		return "discovered"
+	case DeviceUpdated:
+		return "updated"
+	case DeviceLost:
+		return "lost"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(t))
+	}
+}
+
+// DeviceChanges represents the set of device fields changed by an advertisement.
+type DeviceChanges uint8
+
+const (
+	// DeviceChangeRSSI indicates that the RSSI changed.
+	DeviceChangeRSSI DeviceChanges = 0x01
+	// DeviceChangeLocalName indicates that the local name changed.
+	DeviceChangeLocalName DeviceChanges = 0x02
+	// DeviceChangeServices indicates that advertised services were added, their service data changed, or the advertised service UUIDs changed.
+	DeviceChangeServices DeviceChanges = 0x04
+	// DeviceChangeManufacturerData indicates that the manufacturer specific data changed.
+	DeviceChangeManufacturerData DeviceChanges = 0x08
+)
+
+var deviceChangeNames = []struct {
+	change DeviceChanges
+	name   string
+}{
+	{DeviceChangeRSSI, "rssi"},
+	{DeviceChangeLocalName, "localName"},
+	{DeviceChangeServices, "services"},
+	{DeviceChangeManufacturerData, "manufacturerData"},
+}
+
+// Has returns whether all the specified changes are set.
+func (changes DeviceChanges) Has(other DeviceChanges) bool {
+	return changes&other == other
+}
+
+// Names returns the names of the changes.
+func (changes DeviceChanges) Names() []string {
+	names := []string{}
+	for _, c := range deviceChangeNames {
+		if changes.Has(c.change) {
+			names = append(names, c.name)
+		}
+	}
+	return names
+}
+
+// String returns the string representation of the changes.
+func (changes DeviceChanges) String() string {
+	return strings.Join(changes.Names(), "|")
+}
+
+// DeviceEvent represents a lifecycle event of a device discovered by a scanner.
+type DeviceEvent struct {
+	// Type is the type of the event.
+	Type DeviceEventType
+	// Device is the device of the event.
+	Device Device
+	// Changes is the set of fields changed by the advertisement for DeviceUpdated, or zero for the other events.
+	Changes DeviceChanges
+	// Time is the time when the event occurred.
+	Time time.Time
+}
+
+// String returns a string representation of the event.
+func (event DeviceEvent) String() string {
+	s := fmt.Sprintf("%s %s", event.Type, event.Device.Address())
+	if event.Changes != 0 {
+		s += fmt.Sprintf(" (%s)", event.Changes)
+	}
+	return s
+}
+
+// DeviceEventHandler is a scanner option which is called with the lifecycle events of the discovered devices.
+// Handlers are called one event at a time from the scanning goroutines.
+type DeviceEventHandler func(DeviceEvent)
+
+// DeviceEventChannel is a scanner option which receives the lifecycle events of the discovered devices.
+// Events which arrive while the channel is full are dropped so that scanning is never blocked.
+type DeviceEventChannel chan<- DeviceEvent
+
+// DeviceLostTimeout is a scanner option which sets the duration without advertisements after which a device is reported lost.
+// DefaultDeviceLostTimeout applies if it is not specified, and devices are never reported lost if it is not positive.
+// Connected devices are never reported lost since peripherals stop advertising while connected.
+type DeviceLostTimeout time.Duration
@@ -27,4 +27,16 @@ var (
 	ErrInvalid = errors.New("invalid")
 	// ErrNotFound indicates that the value was not found.
 	ErrNotFound = errors.New("not found")
+	// ErrNotPermitted indicates that the operation is not permitted.
+	ErrNotPermitted = errors.New("not permitted")
+	// ErrNotSupported indicates that the operation is not supported.
+	ErrNotSupported = errors.New("not supported")
+	// ErrOverflow indicates that a queue overflowed and data was dropped.
+	ErrOverflow = errors.New("overflow")
+	// ErrClosed indicates that the resource is closed.
+	ErrClosed = errors.New("closed")
+	// ErrTimeout indicates that the operation timed out.
+	ErrTimeout = errors.New("timeout")
+	// ErrBusy indicates that the resource is busy with another operation.
+	ErrBusy = errors.New("busy")
 )
@@ -0,0 +1,159 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+const (
+	// DefaultFramedTransportMaxFrameSize is the default maximum number of bytes buffered while reassembling a frame.
+	DefaultFramedTransportMaxFrameSize = 64 * 1024
+)
+
+// FramedTransport represents a transport that sends and receives whole application messages framed by a codec.
+type FramedTransport interface {
+	// Transport returns the underlying transport.
+	Transport() Transport
+	// Codec returns the framing codec.
+	Codec() Codec
+	// ReadMessage reads the next message, reassembling the frame from as many notifications as needed.
+	ReadMessage(ctx context.Context) ([]byte, error)
+	// WriteMessage encodes the message and writes the frame, split into writes of the ATT MTU or the write size.
+	WriteMessage(ctx context.Context, msg []byte) error
+	// Close closes the underlying transport and releases buffered data.
+	Close() error
+}
+
+// FramedTransportOption represents a function type to set framed transport options.
+type FramedTransportOption func(*framedTransport)
+
+// WithFramedTransportWriteSize sets the maximum number of bytes written per transport write.
+// By default, the transport splits writes according to the ATT MTU of the connection.
+func WithFramedTransportWriteSize(size int) FramedTransportOption {
+	return func(t *framedTransport) {
+		t.writeSize = size
+	}
+}
+
+// WithFramedTransportWriteWithoutResponse writes frames using write without response.
+func WithFramedTransportWriteWithoutResponse() FramedTransportOption {
+	return func(t *framedTransport) {
+		t.withoutResponse = true
+	}
+}
+
+// WithFramedTransportMaxFrameSize sets the maximum number of bytes buffered while reassembling a frame.
+func WithFramedTransportMaxFrameSize(size int) FramedTransportOption {
+	return func(t *framedTransport) {
+		t.maxFrameSize = size
+	}
+}
+
+type framedTransport struct {
+	transport       Transport
+	codec           Codec
+	writeSize       int
+	withoutResponse bool
+	maxFrameSize    int
+	readMutex       sync.Mutex
+	readBuf         []byte
+	writeMutex      sync.Mutex
+}
+
+// NewFramedTransport returns a new framed transport over the specified transport using the codec.
+func NewFramedTransport(transport Transport, codec Codec, opts ...FramedTransportOption) FramedTransport {
+	t := &framedTransport{
+		transport:       transport,
+		codec:           codec,
+		writeSize:       0,
+		withoutResponse: false,
+		maxFrameSize:    DefaultFramedTransportMaxFrameSize,
+		readMutex:       sync.Mutex{},
+		readBuf:         []byte{},
+		writeMutex:      sync.Mutex{},
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Transport returns the underlying transport.
+func (t *framedTransport) Transport() Transport {
+	return t.transport
+}
+
+// Codec returns the framing codec.
+func (t *framedTransport) Codec() Codec {
+	return t.codec
+}
+
+// ReadMessage reads the next message, reassembling the frame from as many notifications as needed.
+// A corrupted frame is discarded and reported with an error, and the next call continues with the following bytes.
+func (t *framedTransport) ReadMessage(ctx context.Context) ([]byte, error) {
+	t.readMutex.Lock()
+	defer t.readMutex.Unlock()
+	for {
+		for 0 < len(t.readBuf) {
+			msg, n, err := t.codec.Decode(t.readBuf)
+			if 0 < n {
+				t.readBuf = t.readBuf[min(n, len(t.readBuf)):]
+			}
+			if err != nil {
+				return nil, err
+			}
+			if msg != nil {
+				return msg, nil
+			}
+			if n == 0 {
+				break
+			}
+		}
+		if t.maxFrameSize <= len(t.readBuf) {
+			size := len(t.readBuf)
+			t.readBuf = []byte{}
+			return nil, fmt.Errorf("%w frame size: %d >= %d", ErrOverflow, size, t.maxFrameSize)
+		}
+		data, err := t.transport.Read(ctx)
+		if err != nil {
+			return nil, err
+		}
+		t.readBuf = append(t.readBuf, data...)
+	}
+}
+
+// WriteMessage encodes the message and writes the frame, split into writes of the ATT MTU or the write size.
+func (t *framedTransport) WriteMessage(ctx context.Context, msg []byte) error {
+	frame, err := t.codec.Encode(msg)
+	if err != nil {
+		return err
+	}
+	t.writeMutex.Lock()
+	defer t.writeMutex.Unlock()
+	_, err = writeTransportChunks(ctx, t.transport, frame, t.writeSize, t.withoutResponse)
+	return err
+}
+
+// Close closes the underlying transport and releases buffered data.
+func (t *framedTransport) Close() error {
+	err := t.transport.Close()
+	t.readMutex.Lock()
+	t.readBuf = nil
+	t.readMutex.Unlock()
+	return err
+}
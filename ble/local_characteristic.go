@@ -0,0 +1,329 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cybergarage/go-ble/ble/db"
+)
+
+// OnLocalCharacteristicRead represents a callback function to be called when a central reads a local characteristic.
+type OnLocalCharacteristicRead func(char LocalCharacteristic) ([]byte, error)
+
+// OnLocalCharacteristicWrite represents a callback function to be called with the updated value when a central writes a local characteristic.
+type OnLocalCharacteristicWrite func(char LocalCharacteristic, data []byte)
+
+// OnLocalCharacteristicWriteError represents a callback function to be called with the error when a write from a central to a local characteristic is rejected.
+type OnLocalCharacteristicWriteError func(char LocalCharacteristic, err error)
+
+// LocalCharacteristic represents a Bluetooth characteristic served by a local peripheral.
+type LocalCharacteristic interface {
+	// LocalCharacteristicDescriptor represents a local characteristic descriptor.
+	LocalCharacteristicDescriptor
+	// LocalCharacteristicOperator represents operations that can be performed on a local characteristic.
+	LocalCharacteristicOperator
+	// MarshalObject returns an object suitable for marshaling to JSON.
+	MarshalObject() any
+	// String returns a string representation of the characteristic.
+	String() string
+}
+
+// LocalCharacteristicDescriptor represents a local characteristic descriptor.
+type LocalCharacteristicDescriptor interface {
+	// Service returns the local service that the characteristic belongs to.
+	Service() LocalService
+	// UUID returns the characteristic UUID.
+	UUID() UUID
+	// Name returns the characteristic name.
+	Name() string
+	// ID returns the characteristic ID.
+	ID() string
+	// Properties returns the characteristic properties.
+	Properties() CharacteristicProperties
+	// Permissions returns the characteristic permissions.
+	Permissions() CharacteristicPermissions
+}
+
+// LocalCharacteristicOperator represents operations that can be performed on a local characteristic.
+type LocalCharacteristicOperator interface {
+	// Value returns the current characteristic value.
+	Value() []byte
+	// SetValue sets the characteristic value served to centrals.
+	SetValue(data []byte) error
+	// Notify sets the characteristic value and notifies or indicates it to the subscribed centrals.
+	Notify(data []byte) error
+	// ReadValue serves a read request from a central.
+	ReadValue() ([]byte, error)
+	// WriteValue serves a write request from a central at the specified offset.
+	WriteValue(offset int, data []byte) error
+}
+
+// LocalCharacteristicOption represents an option for a local characteristic.
+type LocalCharacteristicOption func(*localCharacteristic)
+
+// WithLocalCharacteristicProperties sets the properties of the local characteristic.
+func WithLocalCharacteristicProperties(props CharacteristicProperties) LocalCharacteristicOption {
+	return func(char *localCharacteristic) {
+		char.props = props
+	}
+}
+
+// WithLocalCharacteristicPermissions sets the permissions of the local characteristic.
+// If no permissions are set, they are derived from the properties.
+func WithLocalCharacteristicPermissions(perms CharacteristicPermissions) LocalCharacteristicOption {
+	return func(char *localCharacteristic) {
+		char.perms = perms
+	}
+}
+
+// WithLocalCharacteristicValue sets the initial value of the local characteristic.
+func WithLocalCharacteristicValue(data []byte) LocalCharacteristicOption {
+	return func(char *localCharacteristic) {
+		char.value = data
+	}
+}
+
+// WithLocalCharacteristicReadHandler sets the handler called when a central reads the local characteristic.
+// The handler is called only by backends which support dynamic reads; other backends serve the value set by SetValue.
+func WithLocalCharacteristicReadHandler(handler OnLocalCharacteristicRead) LocalCharacteristicOption {
+	return func(char *localCharacteristic) {
+		char.readHandler = handler
+	}
+}
+
+// WithLocalCharacteristicWriteHandler sets the handler called when a central writes the local characteristic.
+func WithLocalCharacteristicWriteHandler(handler OnLocalCharacteristicWrite) LocalCharacteristicOption {
+	return func(char *localCharacteristic) {
+		char.writeHandler = handler
+	}
+}
+
+// WithLocalCharacteristicWriteErrorHandler sets the handler called when a write from a central to the local characteristic is rejected.
+// It is the only way to learn of rejected writes on backends which cannot respond to the central with an error.
+func WithLocalCharacteristicWriteErrorHandler(handler OnLocalCharacteristicWriteError) LocalCharacteristicOption {
+	return func(char *localCharacteristic) {
+		char.writeErrorHandler = handler
+	}
+}
+
+type localCharacteristic struct {
+	sync.Mutex
+	service           LocalService
+	db                db.Characteristic
+	uuid              UUID
+	props             CharacteristicProperties
+	perms             CharacteristicPermissions
+	value             []byte
+	readHandler       OnLocalCharacteristicRead
+	writeHandler      OnLocalCharacteristicWrite
+	writeErrorHandler OnLocalCharacteristicWriteError
+	backendChar       BackendLocalCharacteristic
+}
+
+// NewLocalCharacteristic returns a new local characteristic with the specified UUID.
+func NewLocalCharacteristic(uuid UUID, opts ...LocalCharacteristicOption) LocalCharacteristic {
+	dbChar, _ := db.DefaultDatabase().LookupCharacteristic(uuid)
+	char := &localCharacteristic{
+		Mutex:             sync.Mutex{},
+		service:           nil,
+		db:                dbChar,
+		uuid:              uuid,
+		props:             0,
+		perms:             0,
+		value:             []byte{},
+		readHandler:       nil,
+		writeHandler:      nil,
+		writeErrorHandler: nil,
+		backendChar:       nil,
+	}
+	for _, opt := range opts {
+		opt(char)
+	}
+	if char.perms == 0 {
+		if char.props.IsReadable() {
+			char.perms |= CharacteristicPermissionRead
+		}
+		if char.props.IsWritable() || char.props.IsWritableWithoutResponse() {
+			char.perms |= CharacteristicPermissionWrite
+		}
+	}
+	return char
+}
+
+// Service returns the local service that the characteristic belongs to.
+func (char *localCharacteristic) Service() LocalService {
+	return char.service
+}
+
+// UUID returns the characteristic UUID.
+func (char *localCharacteristic) UUID() UUID {
+	return char.uuid
+}
+
+// Name returns the characteristic name.
+func (char *localCharacteristic) Name() string {
+	return char.db.Name()
+}
+
+// ID returns the characteristic ID.
+func (char *localCharacteristic) ID() string {
+	return char.db.ID()
+}
+
+// Properties returns the characteristic properties.
+func (char *localCharacteristic) Properties() CharacteristicProperties {
+	return char.props
+}
+
+// Permissions returns the characteristic permissions.
+func (char *localCharacteristic) Permissions() CharacteristicPermissions {
+	return char.perms
+}
+
+// Value returns the current characteristic value.
+func (char *localCharacteristic) Value() []byte {
+	char.Lock()
+	defer char.Unlock()
+	value := make([]byte, len(char.value))
+	copy(value, char.value)
+	return value
+}
+
+func (char *localCharacteristic) setValue(data []byte) BackendLocalCharacteristic {
+	value := make([]byte, len(data))
+	copy(value, data)
+	char.Lock()
+	defer char.Unlock()
+	char.value = value
+	return char.backendChar
+}
+
+// SetValue sets the characteristic value served to centrals.
+func (char *localCharacteristic) SetValue(data []byte) error {
+	backendChar := char.setValue(data)
+	if backendChar == nil {
+		return nil
+	}
+	if err := backendChar.SetValue(data); err != nil {
+		return fmt.Errorf("%w: %s", err, char.String())
+	}
+	return nil
+}
+
+// Notify sets the characteristic value and notifies or indicates it to the subscribed centrals.
+func (char *localCharacteristic) Notify(data []byte) error {
+	if !char.props.IsNotifiable() && !char.props.IsIndicatable() {
+		return fmt.Errorf("notify %w: %s", ErrNotPermitted, char.String())
+	}
+	backendChar := char.setValue(data)
+	if backendChar == nil {
+		return fmt.Errorf("%w: %s", ErrNotConnected, char.String())
+	}
+	if err := backendChar.Notify(data); err != nil {
+		return fmt.Errorf("%w: %s", err, char.String())
+	}
+	return nil
+}
+
+// ReadValue serves a read request from a central.
+func (char *localCharacteristic) ReadValue() ([]byte, error) {
+	if !char.perms.IsReadable() {
+		return nil, fmt.Errorf("read %w: %s", ErrNotPermitted, char.String())
+	}
+	if char.readHandler != nil {
+		data, err := char.readHandler(char)
+		if err != nil {
+			return nil, err
+		}
+		char.setValue(data)
+		return data, nil
+	}
+	return char.Value(), nil
+}
+
+// WriteValue serves a write request from a central at the specified offset.
+// A rejected write is also reported to the write error handler.
+func (char *localCharacteristic) WriteValue(offset int, data []byte) error {
+	if err := char.writeValue(offset, data); err != nil {
+		if char.writeErrorHandler != nil {
+			char.writeErrorHandler(char, err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (char *localCharacteristic) writeValue(offset int, data []byte) error {
+	if !char.perms.IsWritable() {
+		return fmt.Errorf("write %w: %s", ErrNotPermitted, char.String())
+	}
+	char.Lock()
+	if offset < 0 || len(char.value) < offset {
+		char.Unlock()
+		return fmt.Errorf("%w offset: %d", ErrInvalid, offset)
+	}
+	value := make([]byte, offset+len(data))
+	copy(value, char.value[:offset])
+	copy(value[offset:], data)
+	char.value = value
+	char.Unlock()
+	if char.writeHandler != nil {
+		char.writeHandler(char, value)
+	}
+	return nil
+}
+
+func (char *localCharacteristic) setService(service LocalService) {
+	char.service = service
+}
+
+func (char *localCharacteristic) bind(backendChar BackendLocalCharacteristic) {
+	char.Lock()
+	defer char.Unlock()
+	char.backendChar = backendChar
+}
+
+// MarshalObject returns an object suitable for marshaling to JSON.
+func (char *localCharacteristic) MarshalObject() any {
+	return struct {
+		UUID        string   `json:"uuid"`
+		Name        string   `json:"name"`
+		ID          string   `json:"id"`
+		Properties  []string `json:"properties"`
+		Permissions []string `json:"permissions"`
+		Value       string   `json:"value"`
+	}{
+		UUID:        char.UUID().String(),
+		Name:        char.Name(),
+		ID:          char.ID(),
+		Properties:  char.props.Names(),
+		Permissions: char.perms.Names(),
+		Value:       strings.ToUpper(hex.EncodeToString(char.Value())),
+	}
+}
+
+// String returns a string representation of the characteristic.
+func (char *localCharacteristic) String() string {
+	b, err := json.Marshal(char.MarshalObject())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
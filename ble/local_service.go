@@ -0,0 +1,111 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"encoding/json"
+
+	"github.com/cybergarage/go-ble/ble/db"
+)
+
+// LocalService represents a Bluetooth service served by a local peripheral.
+type LocalService interface {
+	// UUID returns the UUID of the service.
+	UUID() UUID
+	// Name returns the name of the service.
+	Name() string
+	// ID returns the ID of the service.
+	ID() string
+	// LookupCharacteristic looks up a characteristic by UUID.
+	LookupCharacteristic(uuid any) (LocalCharacteristic, bool)
+	// Characteristics returns the characteristics of the service.
+	Characteristics() []LocalCharacteristic
+	// MarshalObject returns an object suitable for marshaling to JSON.
+	MarshalObject() any
+	// String returns a string representation of the service.
+	String() string
+}
+
+type localService struct {
+	db.Service
+	uuid  UUID
+	chars []LocalCharacteristic
+}
+
+// NewLocalService returns a new local service with the specified UUID and characteristics.
+func NewLocalService(uuid UUID, chars ...LocalCharacteristic) LocalService {
+	dbService, _ := db.DefaultDatabase().LookupService(uuid)
+	s := &localService{
+		Service: dbService,
+		uuid:    uuid,
+		chars:   chars,
+	}
+	for _, char := range chars {
+		if c, ok := char.(*localCharacteristic); ok {
+			c.setService(s)
+		}
+	}
+	return s
+}
+
+// UUID returns the UUID of the service.
+func (s *localService) UUID() UUID {
+	return s.uuid
+}
+
+// LookupCharacteristic looks up a characteristic by UUID.
+func (s *localService) LookupCharacteristic(anyUUID any) (LocalCharacteristic, bool) {
+	lookupUUID, err := NewUUIDFrom(anyUUID)
+	if err != nil {
+		return nil, false
+	}
+	for _, char := range s.chars {
+		if lookupUUID.Equal(char.UUID()) {
+			return char, true
+		}
+	}
+	return nil, false
+}
+
+// Characteristics returns the characteristics of the service.
+func (s *localService) Characteristics() []LocalCharacteristic {
+	return s.chars
+}
+
+// MarshalObject returns an object suitable for marshaling to JSON.
+func (s *localService) MarshalObject() any {
+	charObjs := make([]any, 0, len(s.chars))
+	for _, char := range s.chars {
+		charObjs = append(charObjs, char.MarshalObject())
+	}
+	return struct {
+		UUID            string `json:"uuid"`
+		Name            string `json:"name"`
+		Characteristics []any  `json:"characteristics"`
+	}{
+		UUID:            s.uuid.String(),
+		Name:            s.Name(),
+		Characteristics: charObjs,
+	}
+}
+
+// String returns a string representation of the service.
+func (s *localService) String() string {
+	b, err := json.Marshal(s.MarshalObject())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
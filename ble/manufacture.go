@@ -49,7 +49,8 @@ func newNilManufacturer() Manufacturer {
 	}
 }
 
-func newManufacturer(id int, data []byte) Manufacturer {
+// NewManufacturer returns a new manufacturer with the specified company ID and manufacturer specific data.
+func NewManufacturer(id int, data []byte) Manufacturer {
 	company, _ := DefaultDatabase().LookupCompany(id)
 	return &manufacturer{
 		Company: company,
@@ -0,0 +1,81 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+const (
+	base38Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ-."
+	base38Radix    = 38
+)
+
+// base38CharsPerBytes represents the number of base38 characters which encode 1, 2 or 3 bytes.
+var base38CharsPerBytes = []int{0, 2, 4, 5}
+
+// base38Encode encodes the bytes in little-endian chunks of up to 3 bytes.
+func base38Encode(b []byte) string {
+	var sb strings.Builder
+	for offset := 0; offset < len(b); offset += 3 {
+		n := min(3, len(b)-offset)
+		v := 0
+		for i := n - 1; 0 <= i; i-- {
+			v = v<<8 | int(b[offset+i])
+		}
+		for range base38CharsPerBytes[n] {
+			sb.WriteByte(base38Alphabet[v%base38Radix])
+			v /= base38Radix
+		}
+	}
+	return sb.String()
+}
+
+// base38Decode decodes the string encoded by base38Encode.
+func base38Decode(s string) ([]byte, error) {
+	b := []byte{}
+	for offset := 0; offset < len(s); {
+		chars := min(5, len(s)-offset)
+		n := 0
+		for bytes, c := range base38CharsPerBytes {
+			if c == chars {
+				n = bytes
+			}
+		}
+		if n == 0 {
+			return nil, fmt.Errorf("%w base38 length: %d", ble.ErrInvalid, len(s))
+		}
+		v := 0
+		for i := chars - 1; 0 <= i; i-- {
+			idx := strings.IndexByte(base38Alphabet, s[offset+i])
+			if idx < 0 {
+				return nil, fmt.Errorf("%w base38 character: %q", ble.ErrInvalid, s[offset+i])
+			}
+			v = v*base38Radix + idx
+		}
+		if 1<<(8*n) <= v {
+			return nil, fmt.Errorf("%w base38 chunk: %s", ble.ErrInvalid, s[offset:offset+chars])
+		}
+		for range n {
+			b = append(b, byte(v))
+			v >>= 8
+		}
+		offset += chars
+	}
+	return b, nil
+}
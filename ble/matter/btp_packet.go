@@ -0,0 +1,241 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+// BTPFlags represents the header flags of a BTP packet.
+type BTPFlags uint8
+
+const (
+	// BTPFlagBeginning indicates the beginning segment of a message.
+	BTPFlagBeginning BTPFlags = 0x01
+	// BTPFlagContinuing indicates a continuing segment of a message.
+	BTPFlagContinuing BTPFlags = 0x02
+	// BTPFlagEnding indicates the ending segment of a message.
+	BTPFlagEnding BTPFlags = 0x04
+	// BTPFlagAck indicates that the packet carries an acknowledgement number.
+	BTPFlagAck BTPFlags = 0x08
+	// BTPFlagManagement indicates that the packet carries a management opcode.
+	BTPFlagManagement BTPFlags = 0x20
+	// BTPFlagHandshake indicates a handshake packet.
+	BTPFlagHandshake BTPFlags = 0x40
+)
+
+const (
+	// BTPVersion is the BTP protocol version supported by the session.
+	BTPVersion = 4
+	// BTPManagementOpcodeHandshake is the management opcode of handshake packets.
+	BTPManagementOpcodeHandshake = 0x6C
+	// BTPMinSegmentSize is the minimum segment size which fits the default ATT MTU.
+	BTPMinSegmentSize = 20
+	// BTPMaxSegmentSize is the maximum segment size which fits the maximum ATT MTU.
+	BTPMaxSegmentSize = 244
+	// BTPMaxMessageSize is the maximum size of a BTP message.
+	BTPMaxMessageSize = 0xFFFF
+)
+
+const (
+	btpHandshakeFlags        = BTPFlagHandshake | BTPFlagManagement | BTPFlagEnding | BTPFlagBeginning
+	btpHandshakeRequestSize  = 9
+	btpHandshakeResponseSize = 6
+	btpMaxSupportedVersions  = 8
+)
+
+// Has returns whether all the specified flags are set.
+func (flags BTPFlags) Has(other BTPFlags) bool {
+	return flags&other == other
+}
+
+// BTPHandshakeRequest represents a BTP handshake request sent by the central on C1.
+type BTPHandshakeRequest struct {
+	// Versions is the list of supported BTP versions in descending order.
+	Versions []uint8
+	// MTU is the ATT MTU of the connection, or zero if it is unknown.
+	MTU uint16
+	// WindowSize is the receive window size of the central.
+	WindowSize uint8
+}
+
+// ParseBTPHandshakeRequest parses a BTP handshake request.
+func ParseBTPHandshakeRequest(b []byte) (*BTPHandshakeRequest, error) {
+	if len(b) != btpHandshakeRequestSize || BTPFlags(b[0]) != btpHandshakeFlags || b[1] != BTPManagementOpcodeHandshake {
+		return nil, fmt.Errorf("%w BTP handshake request: %X", ble.ErrInvalid, b)
+	}
+	versions := []uint8{}
+	for n := range btpMaxSupportedVersions {
+		version := (b[2+n/2] >> (4 * (n % 2))) & 0x0F
+		if version == 0 {
+			break
+		}
+		versions = append(versions, version)
+	}
+	return &BTPHandshakeRequest{
+		Versions:   versions,
+		MTU:        binary.LittleEndian.Uint16(b[6:8]),
+		WindowSize: b[8],
+	}, nil
+}
+
+// Bytes returns the encoded handshake request.
+func (req *BTPHandshakeRequest) Bytes() []byte {
+	b := make([]byte, btpHandshakeRequestSize)
+	b[0] = byte(btpHandshakeFlags)
+	b[1] = BTPManagementOpcodeHandshake
+	for n, version := range req.Versions {
+		if btpMaxSupportedVersions <= n {
+			break
+		}
+		b[2+n/2] |= (version & 0x0F) << (4 * (n % 2))
+	}
+	binary.LittleEndian.PutUint16(b[6:8], req.MTU)
+	b[8] = req.WindowSize
+	return b
+}
+
+// BTPHandshakeResponse represents a BTP handshake response indicated by the peripheral on C2.
+type BTPHandshakeResponse struct {
+	// Version is the selected BTP version.
+	Version uint8
+	// SegmentSize is the selected maximum segment size.
+	SegmentSize uint16
+	// WindowSize is the selected receive window size.
+	WindowSize uint8
+}
+
+// ParseBTPHandshakeResponse parses a BTP handshake response.
+func ParseBTPHandshakeResponse(b []byte) (*BTPHandshakeResponse, error) {
+	if len(b) != btpHandshakeResponseSize || BTPFlags(b[0]) != btpHandshakeFlags || b[1] != BTPManagementOpcodeHandshake {
+		return nil, fmt.Errorf("%w BTP handshake response: %X", ble.ErrInvalid, b)
+	}
+	return &BTPHandshakeResponse{
+		Version:     b[2] & 0x0F,
+		SegmentSize: binary.LittleEndian.Uint16(b[3:5]),
+		WindowSize:  b[5],
+	}, nil
+}
+
+// Bytes returns the encoded handshake response.
+func (res *BTPHandshakeResponse) Bytes() []byte {
+	b := make([]byte, btpHandshakeResponseSize)
+	b[0] = byte(btpHandshakeFlags)
+	b[1] = BTPManagementOpcodeHandshake
+	b[2] = res.Version & 0x0F
+	binary.LittleEndian.PutUint16(b[3:5], res.SegmentSize)
+	b[5] = res.WindowSize
+	return b
+}
+
+// BTPPacket represents a BTP data packet.
+type BTPPacket struct {
+	// Flags is the header flags.
+	Flags BTPFlags
+	// Opcode is the management opcode which is present if the management flag is set.
+	Opcode uint8
+	// Ack is the acknowledged sequence number which is present if the ack flag is set.
+	Ack uint8
+	// Seq is the sequence number.
+	Seq uint8
+	// MessageLength is the total message length which is present if the beginning flag is set.
+	MessageLength uint16
+	// Payload is the segment payload.
+	Payload []byte
+}
+
+// ParseBTPPacket parses a BTP data packet.
+func ParseBTPPacket(b []byte) (*BTPPacket, error) {
+	if len(b) < 1 {
+		return nil, fmt.Errorf("%w BTP packet: %X", ble.ErrInvalid, b)
+	}
+	pkt := &BTPPacket{
+		Flags:         BTPFlags(b[0]),
+		Opcode:        0,
+		Ack:           0,
+		Seq:           0,
+		MessageLength: 0,
+		Payload:       nil,
+	}
+	if pkt.Flags.Has(BTPFlagHandshake) {
+		return nil, fmt.Errorf("%w BTP packet: unexpected handshake %X", ble.ErrInvalid, b)
+	}
+	offset := 1
+	next := func(n int) ([]byte, error) {
+		if len(b) < offset+n {
+			return nil, fmt.Errorf("%w BTP packet: truncated %X", ble.ErrInvalid, b)
+		}
+		v := b[offset : offset+n]
+		offset += n
+		return v, nil
+	}
+	if pkt.Flags.Has(BTPFlagManagement) {
+		v, err := next(1)
+		if err != nil {
+			return nil, err
+		}
+		pkt.Opcode = v[0]
+	}
+	if pkt.Flags.Has(BTPFlagAck) {
+		v, err := next(1)
+		if err != nil {
+			return nil, err
+		}
+		pkt.Ack = v[0]
+	}
+	v, err := next(1)
+	if err != nil {
+		return nil, err
+	}
+	pkt.Seq = v[0]
+	if pkt.Flags.Has(BTPFlagBeginning) {
+		v, err := next(2)
+		if err != nil {
+			return nil, err
+		}
+		pkt.MessageLength = binary.LittleEndian.Uint16(v)
+	}
+	pkt.Payload = b[offset:]
+	return pkt, nil
+}
+
+// IsStandaloneAck returns whether the packet is a stand-alone acknowledgement which carries no segment.
+func (pkt *BTPPacket) IsStandaloneAck() bool {
+	return pkt.Flags == BTPFlagAck && len(pkt.Payload) == 0
+}
+
+// IsKeepAlive returns whether the packet is a keep-alive which carries neither an acknowledgement nor a segment.
+func (pkt *BTPPacket) IsKeepAlive() bool {
+	return pkt.Flags == 0 && len(pkt.Payload) == 0
+}
+
+// Bytes returns the encoded packet.
+func (pkt *BTPPacket) Bytes() []byte {
+	b := []byte{byte(pkt.Flags)}
+	if pkt.Flags.Has(BTPFlagManagement) {
+		b = append(b, pkt.Opcode)
+	}
+	if pkt.Flags.Has(BTPFlagAck) {
+		b = append(b, pkt.Ack)
+	}
+	b = append(b, pkt.Seq)
+	if pkt.Flags.Has(BTPFlagBeginning) {
+		b = binary.LittleEndian.AppendUint16(b, pkt.MessageLength)
+	}
+	return append(b, pkt.Payload...)
+}
@@ -0,0 +1,603 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+const (
+	// DefaultBTPWindowSize is the default receive window size requested in the handshake.
+	DefaultBTPWindowSize = 6
+	// DefaultBTPHandshakeTimeout is the default timeout to receive the handshake response.
+	DefaultBTPHandshakeTimeout = 5 * time.Second
+	// DefaultBTPAckTimeout is the default timeout to receive an acknowledgement of a sent packet.
+	DefaultBTPAckTimeout = 15 * time.Second
+	// DefaultBTPSendAckTimeout is the default timeout to acknowledge a received packet when no packet is sent.
+	DefaultBTPSendAckTimeout = 2500 * time.Millisecond
+	// DefaultBTPIdleTimeout is the default timeout to close the session when no packet is received.
+	DefaultBTPIdleTimeout = 30 * time.Second
+	// DefaultBTPKeepAliveInterval is the default interval to send a keep-alive when no packet is sent.
+	DefaultBTPKeepAliveInterval = 2500 * time.Millisecond
+)
+
+const (
+	btpTimerInterval = 10 * time.Millisecond
+	// btpSegmentHeaderSize is the maximum header size of segments: flags, ack, sequence and message length.
+	btpSegmentHeaderSize = 5
+)
+
+// BTPSession represents a Matter BTP (Bluetooth Transport Protocol) session as the central.
+// Read and Write transfer whole messages; Read returns io.ErrShortBuffer if the buffer is smaller than the message.
+type BTPSession interface {
+	io.ReadWriteCloser
+	// Version returns the negotiated BTP version.
+	Version() uint8
+	// SegmentSize returns the negotiated maximum segment size.
+	SegmentSize() int
+	// WindowSize returns the negotiated window size.
+	WindowSize() int
+	// ReadMessage reads a whole message.
+	ReadMessage(ctx context.Context) ([]byte, error)
+	// WriteMessage writes a whole message.
+	WriteMessage(ctx context.Context, msg []byte) error
+}
+
+// BTPOption represents an option for a BTP session.
+type BTPOption func(*btpSession)
+
+// WithBTPMTU sets the ATT MTU of the connection which is sent in the handshake request.
+func WithBTPMTU(mtu uint16) BTPOption {
+	return func(s *btpSession) {
+		s.mtu = mtu
+	}
+}
+
+// WithBTPWindowSize sets the receive window size which is sent in the handshake request.
+func WithBTPWindowSize(size uint8) BTPOption {
+	return func(s *btpSession) {
+		s.requestedWindow = size
+	}
+}
+
+// WithBTPHandshakeTimeout sets the timeout to receive the handshake response.
+func WithBTPHandshakeTimeout(timeout time.Duration) BTPOption {
+	return func(s *btpSession) {
+		s.handshakeTimeout = timeout
+	}
+}
+
+// WithBTPAckTimeout sets the timeout to receive an acknowledgement of a sent packet.
+func WithBTPAckTimeout(timeout time.Duration) BTPOption {
+	return func(s *btpSession) {
+		s.ackTimeout = timeout
+	}
+}
+
+// WithBTPSendAckTimeout sets the timeout to acknowledge a received packet when no packet is sent.
+func WithBTPSendAckTimeout(timeout time.Duration) BTPOption {
+	return func(s *btpSession) {
+		s.sendAckTimeout = timeout
+	}
+}
+
+// WithBTPIdleTimeout sets the timeout to close the session when no packet is received.
+func WithBTPIdleTimeout(timeout time.Duration) BTPOption {
+	return func(s *btpSession) {
+		s.idleTimeout = timeout
+	}
+}
+
+// WithBTPKeepAliveInterval sets the interval to send a keep-alive when no packet is sent. Zero disables keep-alives.
+func WithBTPKeepAliveInterval(interval time.Duration) BTPOption {
+	return func(s *btpSession) {
+		s.keepAliveInterval = interval
+	}
+}
+
+type btpSession struct {
+	sync.Mutex
+	sendMutex  sync.Mutex
+	writeMutex sync.Mutex
+	transport  ble.Transport
+	ctx        context.Context
+	cancel     context.CancelFunc
+	done       chan struct{}
+	closeOnce  sync.Once
+	err        error
+
+	mtu               uint16
+	requestedWindow   uint8
+	handshakeTimeout  time.Duration
+	ackTimeout        time.Duration
+	sendAckTimeout    time.Duration
+	idleTimeout       time.Duration
+	keepAliveInterval time.Duration
+
+	version     uint8
+	segmentSize int
+	window      int
+
+	txNextSeq       uint8
+	txOldestUnacked uint8
+	txUnackedSince  time.Time
+	lastSentAt      time.Time
+
+	rxLastSeq      uint8
+	rxUnacked      int
+	rxNeedsAck     bool
+	rxPendingSince time.Time
+	lastReceivedAt time.Time
+
+	rxMessage       []byte
+	rxMessageLength int
+	rxInMessage     bool
+
+	messages      [][]byte
+	messageSignal chan struct{}
+	windowSignal  chan struct{}
+	ackSignal     chan struct{}
+}
+
+// OpenBTPSession opens a transport on the Matter BLE service of the connected device and starts a BTP session over it.
+func OpenBTPSession(ctx context.Context, dev ble.Device, opts ...BTPOption) (BTPSession, error) {
+	service, ok := dev.LookupService(ServiceUUID)
+	if !ok {
+		return nil, fmt.Errorf("service %w: %s", ble.ErrNotFound, ServiceUUID.String())
+	}
+	transport, err := service.Open(
+		ble.WithTransportWriteUUID(C1UUID),
+		ble.WithTransportNotifyUUID(C2UUID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	// The negotiated ATT MTU of the connection is sent unless it is specified by the options.
+	opts = append([]BTPOption{WithBTPMTU(uint16(dev.MTU()))}, opts...)
+	session, err := NewBTPSession(ctx, transport, opts...)
+	if err != nil {
+		transport.Close()
+		return nil, err
+	}
+	return session, nil
+}
+
+// NewBTPSession performs the BTP handshake over the opened transport, which writes to C1 and is notified on C2, and returns the established session.
+// The session owns the transport and closes it when the session is closed.
+func NewBTPSession(ctx context.Context, transport ble.Transport, opts ...BTPOption) (BTPSession, error) {
+	s := &btpSession{
+		Mutex:             sync.Mutex{},
+		sendMutex:         sync.Mutex{},
+		writeMutex:        sync.Mutex{},
+		transport:         transport,
+		ctx:               nil,
+		cancel:            nil,
+		done:              make(chan struct{}),
+		closeOnce:         sync.Once{},
+		err:               nil,
+		mtu:               0,
+		requestedWindow:   DefaultBTPWindowSize,
+		handshakeTimeout:  DefaultBTPHandshakeTimeout,
+		ackTimeout:        DefaultBTPAckTimeout,
+		sendAckTimeout:    DefaultBTPSendAckTimeout,
+		idleTimeout:       DefaultBTPIdleTimeout,
+		keepAliveInterval: DefaultBTPKeepAliveInterval,
+		version:           0,
+		segmentSize:       0,
+		window:            0,
+		txNextSeq:         0,
+		txOldestUnacked:   0,
+		txUnackedSince:    time.Time{},
+		lastSentAt:        time.Time{},
+		rxLastSeq:         0,
+		rxUnacked:         0,
+		rxNeedsAck:        false,
+		rxPendingSince:    time.Time{},
+		lastReceivedAt:    time.Time{},
+		rxMessage:         nil,
+		rxMessageLength:   0,
+		rxInMessage:       false,
+		messages:          [][]byte{},
+		messageSignal:     make(chan struct{}, 1),
+		windowSignal:      make(chan struct{}, 1),
+		ackSignal:         make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if err := s.handshake(ctx); err != nil {
+		return nil, err
+	}
+
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	go s.receiveLoop()
+	go s.timerLoop()
+
+	return s, nil
+}
+
+func (s *btpSession) handshake(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.handshakeTimeout)
+	defer cancel()
+
+	req := &BTPHandshakeRequest{
+		Versions:   []uint8{BTPVersion},
+		MTU:        s.mtu,
+		WindowSize: s.requestedWindow,
+	}
+	if _, err := s.transport.Write(ctx, req.Bytes()); err != nil {
+		return err
+	}
+
+	b, err := s.transport.Read(ctx)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("%w BTP handshake: %w", ErrTimeout, err)
+		}
+		return err
+	}
+	res, err := ParseBTPHandshakeResponse(b)
+	if err != nil {
+		return err
+	}
+	if res.Version != BTPVersion {
+		return fmt.Errorf("%w BTP version: %d", ble.ErrNotSupported, res.Version)
+	}
+	if res.SegmentSize < BTPMinSegmentSize || BTPMaxSegmentSize < res.SegmentSize {
+		return fmt.Errorf("%w BTP segment size: %d", ble.ErrInvalid, res.SegmentSize)
+	}
+	if res.WindowSize < 2 {
+		return fmt.Errorf("%w BTP window size: %d", ble.ErrInvalid, res.WindowSize)
+	}
+
+	now := time.Now()
+	s.version = res.Version
+	s.segmentSize = int(res.SegmentSize)
+	s.window = int(res.WindowSize)
+	// The handshake response is the first packet of the peripheral with the sequence number zero, which must be acknowledged.
+	s.rxLastSeq = 0
+	s.rxUnacked = 1
+	s.rxNeedsAck = true
+	s.rxPendingSince = now
+	s.lastReceivedAt = now
+	s.lastSentAt = now
+
+	return nil
+}
+
+// Version returns the negotiated BTP version.
+func (s *btpSession) Version() uint8 {
+	return s.version
+}
+
+// SegmentSize returns the negotiated maximum segment size.
+func (s *btpSession) SegmentSize() int {
+	return s.segmentSize
+}
+
+// WindowSize returns the negotiated window size.
+func (s *btpSession) WindowSize() int {
+	return s.window
+}
+
+// ReadMessage reads a whole message.
+func (s *btpSession) ReadMessage(ctx context.Context) ([]byte, error) {
+	for {
+		s.Lock()
+		if 0 < len(s.messages) {
+			msg := s.messages[0]
+			s.messages = s.messages[1:]
+			s.Unlock()
+			return msg, nil
+		}
+		err := s.err
+		s.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		select {
+		case <-s.messageSignal:
+		case <-s.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// WriteMessage writes a whole message.
+func (s *btpSession) WriteMessage(ctx context.Context, msg []byte) error {
+	if BTPMaxMessageSize < len(msg) {
+		return fmt.Errorf("%w BTP message size: %d > %d", ble.ErrInvalid, len(msg), BTPMaxMessageSize)
+	}
+
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	offset := 0
+	for first := true; first || offset < len(msg); first = false {
+		pkt := &BTPPacket{
+			Flags:         BTPFlagContinuing,
+			Opcode:        0,
+			Ack:           0,
+			Seq:           0,
+			MessageLength: 0,
+			Payload:       nil,
+		}
+		if first {
+			pkt.Flags = BTPFlagBeginning
+			pkt.MessageLength = uint16(len(msg))
+		}
+		n := min(s.segmentSize-btpSegmentHeaderSize, len(msg)-offset)
+		if offset+n == len(msg) {
+			pkt.Flags |= BTPFlagEnding
+		}
+		pkt.Payload = msg[offset : offset+n]
+		// The last slot of the peer window is reserved for acknowledgements.
+		if err := s.send(ctx, pkt, s.window-1); err != nil {
+			return err
+		}
+		offset += n
+	}
+	return nil
+}
+
+// Read reads a whole message into the specified buffer.
+func (s *btpSession) Read(p []byte) (int, error) {
+	msg, err := s.ReadMessage(context.Background())
+	if err != nil {
+		if errors.Is(err, ErrClosed) {
+			return 0, io.EOF
+		}
+		return 0, err
+	}
+	n := copy(p, msg)
+	if n < len(msg) {
+		return n, io.ErrShortBuffer
+	}
+	return n, nil
+}
+
+// Write writes the specified buffer as a whole message.
+func (s *btpSession) Write(p []byte) (int, error) {
+	if err := s.WriteMessage(context.Background(), p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the session and the underlying transport.
+func (s *btpSession) Close() error {
+	s.fail(ErrClosed)
+	return s.transport.Close()
+}
+
+func (s *btpSession) fail(err error) {
+	s.Lock()
+	if s.err == nil {
+		s.err = err
+	}
+	s.Unlock()
+	s.closeOnce.Do(func() {
+		close(s.done)
+		if s.cancel != nil {
+			s.cancel()
+		}
+	})
+}
+
+// inFlight returns the number of sent packets which are not acknowledged yet.
+func (s *btpSession) inFlight() int {
+	return int(s.txNextSeq - s.txOldestUnacked)
+}
+
+// trySend sends the packet if the number of unacknowledged packets is less than the limit and returns whether it is sent.
+func (s *btpSession) trySend(ctx context.Context, pkt *BTPPacket, limit int) (bool, error) {
+	s.sendMutex.Lock()
+	defer s.sendMutex.Unlock()
+
+	s.Lock()
+	if s.err != nil {
+		err := s.err
+		s.Unlock()
+		return false, err
+	}
+	if limit <= s.inFlight() {
+		s.Unlock()
+		return false, nil
+	}
+	now := time.Now()
+	if 0 < s.rxUnacked {
+		pkt.Flags |= BTPFlagAck
+		pkt.Ack = s.rxLastSeq
+		s.rxUnacked = 0
+		s.rxNeedsAck = false
+	}
+	if s.inFlight() == 0 {
+		s.txUnackedSince = now
+	}
+	pkt.Seq = s.txNextSeq
+	s.txNextSeq++
+	s.lastSentAt = now
+	s.Unlock()
+
+	if _, err := s.transport.Write(ctx, pkt.Bytes()); err != nil {
+		s.fail(err)
+		return false, err
+	}
+	return true, nil
+}
+
+// send sends the packet, waiting until the number of unacknowledged packets is less than the limit.
+func (s *btpSession) send(ctx context.Context, pkt *BTPPacket, limit int) error {
+	for {
+		ok, err := s.trySend(ctx, pkt, limit)
+		if err != nil || ok {
+			return err
+		}
+		select {
+		case <-s.windowSignal:
+		case <-s.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *btpSession) receiveLoop() {
+	for {
+		b, err := s.transport.Read(s.ctx)
+		if err != nil {
+			if s.ctx.Err() != nil {
+				return
+			}
+			if errors.Is(err, context.DeadlineExceeded) {
+				continue
+			}
+			s.fail(err)
+			return
+		}
+		if err := s.receive(b); err != nil {
+			s.fail(err)
+			return
+		}
+	}
+}
+
+func (s *btpSession) receive(b []byte) error {
+	pkt, err := ParseBTPPacket(b)
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	now := time.Now()
+	s.lastReceivedAt = now
+
+	// A repeated acknowledgement of the last acknowledged packet is ignored.
+	if pkt.Flags.Has(BTPFlagAck) && pkt.Ack != s.txOldestUnacked-1 {
+		acked := int(pkt.Ack - s.txOldestUnacked)
+		if s.inFlight() <= acked {
+			return fmt.Errorf("%w BTP ack: %d", ble.ErrInvalid, pkt.Ack)
+		}
+		s.txOldestUnacked = pkt.Ack + 1
+		s.txUnackedSince = now
+		signal(s.windowSignal)
+	}
+
+	if pkt.Seq != s.rxLastSeq+1 {
+		return fmt.Errorf("%w BTP sequence: %d != %d", ble.ErrInvalid, pkt.Seq, s.rxLastSeq+1)
+	}
+	s.rxLastSeq = pkt.Seq
+	if s.rxUnacked == 0 {
+		s.rxPendingSince = now
+	}
+	s.rxUnacked++
+	if !pkt.IsStandaloneAck() {
+		s.rxNeedsAck = true
+	}
+	if s.window-1 <= s.rxUnacked {
+		signal(s.ackSignal)
+	}
+
+	switch {
+	case pkt.Flags.Has(BTPFlagBeginning):
+		if s.rxInMessage {
+			return fmt.Errorf("%w BTP segment: unexpected beginning", ble.ErrInvalid)
+		}
+		s.rxInMessage = true
+		s.rxMessageLength = int(pkt.MessageLength)
+		s.rxMessage = make([]byte, 0, s.rxMessageLength)
+	case pkt.Flags.Has(BTPFlagContinuing) || pkt.Flags.Has(BTPFlagEnding):
+		if !s.rxInMessage {
+			return fmt.Errorf("%w BTP segment: unexpected continuing", ble.ErrInvalid)
+		}
+	default:
+		return nil
+	}
+	s.rxMessage = append(s.rxMessage, pkt.Payload...)
+	if s.rxMessageLength < len(s.rxMessage) {
+		return fmt.Errorf("%w BTP message length: %d > %d", ble.ErrInvalid, len(s.rxMessage), s.rxMessageLength)
+	}
+	if pkt.Flags.Has(BTPFlagEnding) {
+		if len(s.rxMessage) != s.rxMessageLength {
+			return fmt.Errorf("%w BTP message length: %d != %d", ble.ErrInvalid, len(s.rxMessage), s.rxMessageLength)
+		}
+		s.messages = append(s.messages, s.rxMessage)
+		s.rxMessage = nil
+		s.rxInMessage = false
+		signal(s.messageSignal)
+	}
+	return nil
+}
+
+func (s *btpSession) timerLoop() {
+	ticker := time.NewTicker(btpTimerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		case <-s.ackSignal:
+		}
+
+		now := time.Now()
+		s.Lock()
+		inFlight := s.inFlight()
+		ackExpired := 0 < inFlight && s.ackTimeout < now.Sub(s.txUnackedSince)
+		idleExpired := s.idleTimeout < now.Sub(s.lastReceivedAt)
+		sendAck := 0 < s.rxUnacked && ((s.rxNeedsAck && s.sendAckTimeout <= now.Sub(s.rxPendingSince)) || s.window-1 <= s.rxUnacked)
+		keepAlive := 0 < s.keepAliveInterval && s.keepAliveInterval <= now.Sub(s.lastSentAt)
+		s.Unlock()
+
+		switch {
+		case ackExpired:
+			s.fail(fmt.Errorf("%w BTP ack: %d packets unacknowledged", ErrTimeout, inFlight))
+			return
+		case idleExpired:
+			s.fail(fmt.Errorf("%w BTP idle: no packet received for %s", ErrTimeout, s.idleTimeout))
+			return
+		case sendAck || keepAlive:
+			pkt := &BTPPacket{
+				Flags:         0,
+				Opcode:        0,
+				Ack:           0,
+				Seq:           0,
+				MessageLength: 0,
+				Payload:       nil,
+			}
+			if _, err := s.trySend(s.ctx, pkt, s.window); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -12,21 +12,15 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package ble
+package matter
 
 import (
-	"tinygo.org/x/bluetooth"
+	"github.com/cybergarage/go-ble/ble"
 )
 
-type tinyService struct {
-	*service
-	tinyService *bluetooth.DeviceService
-}
-
-func newTinyService(dev Device, service *bluetooth.DeviceService, uuid UUID, data []byte, chars []Characteristic) *tinyService {
-	s := &tinyService{
-		service:     newService(dev, uuid, data, chars),
-		tinyService: service,
-	}
-	return s
-}
+var (
+	// ErrTimeout indicates that the operation timed out.
+	ErrTimeout = ble.ErrTimeout
+	// ErrClosed indicates that the session is closed.
+	ErrClosed = ble.ErrClosed
+)
@@ -0,0 +1,67 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"github.com/cybergarage/go-ble/ble"
+)
+
+// WithCommissionable returns a scan filter which matches devices advertising the Matter BLE service data.
+func WithCommissionable() ble.ScanFilter {
+	return func(dev ble.Device) bool {
+		sd, ok := LookupServiceData(dev)
+		return ok && sd.IsCommissionable()
+	}
+}
+
+// WithDiscriminator returns a scan filter which matches devices advertising the specified 12-bit discriminator.
+func WithDiscriminator(discriminator uint16) ble.ScanFilter {
+	return func(dev ble.Device) bool {
+		sd, ok := LookupServiceData(dev)
+		return ok && sd.Discriminator == (discriminator&DiscriminatorMask)
+	}
+}
+
+// WithVendorID returns a scan filter which matches devices advertising the specified vendor ID.
+func WithVendorID(vendorID uint16) ble.ScanFilter {
+	return func(dev ble.Device) bool {
+		sd, ok := LookupServiceData(dev)
+		return ok && sd.VendorID == vendorID
+	}
+}
+
+// WithProductID returns a scan filter which matches devices advertising the specified product ID.
+func WithProductID(productID uint16) ble.ScanFilter {
+	return func(dev ble.Device) bool {
+		sd, ok := LookupServiceData(dev)
+		return ok && sd.ProductID == productID
+	}
+}
+
+// WithShortDiscriminator returns a scan filter which matches devices advertising a discriminator with the specified upper 4 bits as in manual pairing codes.
+func WithShortDiscriminator(shortDiscriminator uint8) ble.ScanFilter {
+	return func(dev ble.Device) bool {
+		sd, ok := LookupServiceData(dev)
+		return ok && sd.ShortDiscriminator() == shortDiscriminator
+	}
+}
+
+// WithSetupPayload returns a scan filter which matches devices advertising the discriminator and the vendor and product IDs of the setup payload.
+func WithSetupPayload(payload *SetupPayload) ble.ScanFilter {
+	return func(dev ble.Device) bool {
+		sd, ok := LookupServiceData(dev)
+		return ok && payload.MatchesServiceData(sd)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+const (
+	// OpcodeCommissionable is the opcode of the commissionable data in the service data.
+	OpcodeCommissionable = 0x00
+	// ServiceDataSize is the size of the Matter BLE service data.
+	ServiceDataSize = 8
+	// DiscriminatorMask is the mask of the 12-bit discriminator.
+	DiscriminatorMask = 0x0FFF
+)
+
+const (
+	additionalDataFlag       = 0x01
+	extendedAnnouncementFlag = 0x02
+)
+
+// ServiceData represents the Matter BLE service data advertised by a commissionable device (CHIPoBLE).
+type ServiceData struct {
+	// Opcode is the opcode of the service data.
+	Opcode uint8
+	// Version is the 4-bit advertisement version.
+	Version uint8
+	// Discriminator is the 12-bit discriminator.
+	Discriminator uint16
+	// VendorID is the vendor ID.
+	VendorID uint16
+	// ProductID is the product ID.
+	ProductID uint16
+	// AdditionalData indicates whether the C3 characteristic is present.
+	AdditionalData bool
+	// ExtendedAnnouncement indicates whether the device is in extended announcement mode.
+	ExtendedAnnouncement bool
+}
+
+// ParseServiceData parses the Matter BLE service data.
+func ParseServiceData(b []byte) (*ServiceData, error) {
+	if len(b) < ServiceDataSize {
+		return nil, fmt.Errorf("%w service data size: %d < %d", ble.ErrInvalid, len(b), ServiceDataSize)
+	}
+	discVersion := binary.LittleEndian.Uint16(b[1:3])
+	return &ServiceData{
+		Opcode:               b[0],
+		Version:              uint8(discVersion >> 12),
+		Discriminator:        discVersion & DiscriminatorMask,
+		VendorID:             binary.LittleEndian.Uint16(b[3:5]),
+		ProductID:            binary.LittleEndian.Uint16(b[5:7]),
+		AdditionalData:       (b[7] & additionalDataFlag) != 0,
+		ExtendedAnnouncement: (b[7] & extendedAnnouncementFlag) != 0,
+	}, nil
+}
+
+// LookupServiceData looks up the Matter BLE service data advertised by the device.
+func LookupServiceData(dev ble.Device) (*ServiceData, bool) {
+	service, ok := dev.LookupService(ServiceUUID)
+	if !ok {
+		return nil, false
+	}
+	sd, err := ParseServiceData(service.Data())
+	if err != nil {
+		return nil, false
+	}
+	return sd, true
+}
+
+// IsCommissionable returns whether the service data is advertised by a commissionable device.
+func (sd *ServiceData) IsCommissionable() bool {
+	return sd.Opcode == OpcodeCommissionable
+}
+
+// ShortDiscriminator returns the upper 4 bits of the discriminator used in manual pairing codes.
+func (sd *ServiceData) ShortDiscriminator() uint8 {
+	return uint8(sd.Discriminator >> 8)
+}
+
+// Bytes returns the encoded service data.
+func (sd *ServiceData) Bytes() []byte {
+	b := make([]byte, ServiceDataSize)
+	b[0] = sd.Opcode
+	discVersion := uint16(sd.Version&0x0F)<<12 | (sd.Discriminator & DiscriminatorMask)
+	binary.LittleEndian.PutUint16(b[1:3], discVersion)
+	binary.LittleEndian.PutUint16(b[3:5], sd.VendorID)
+	binary.LittleEndian.PutUint16(b[5:7], sd.ProductID)
+	if sd.AdditionalData {
+		b[7] |= additionalDataFlag
+	}
+	if sd.ExtendedAnnouncement {
+		b[7] |= extendedAnnouncementFlag
+	}
+	return b
+}
+
+// MarshalObject returns an object suitable for marshaling to JSON.
+func (sd *ServiceData) MarshalObject() any {
+	return struct {
+		Opcode               uint8  `json:"opcode"`
+		Version              uint8  `json:"version"`
+		Discriminator        uint16 `json:"discriminator"`
+		VendorID             uint16 `json:"vendorID"`
+		ProductID            uint16 `json:"productID"`
+		AdditionalData       bool   `json:"additionalData"`
+		ExtendedAnnouncement bool   `json:"extendedAnnouncement"`
+	}{
+		Opcode:               sd.Opcode,
+		Version:              sd.Version,
+		Discriminator:        sd.Discriminator,
+		VendorID:             sd.VendorID,
+		ProductID:            sd.ProductID,
+		AdditionalData:       sd.AdditionalData,
+		ExtendedAnnouncement: sd.ExtendedAnnouncement,
+	}
+}
+
+// String returns a string representation of the service data.
+func (sd *ServiceData) String() string {
+	b, err := json.Marshal(sd.MarshalObject())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
@@ -0,0 +1,376 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+// CommissioningFlow represents the commissioning flow of a setup payload.
+type CommissioningFlow uint8
+
+const (
+	// CommissioningFlowStandard indicates that the device is commissionable when powered on.
+	CommissioningFlowStandard CommissioningFlow = 0
+	// CommissioningFlowUserIntent indicates that the device requires a user action to become commissionable.
+	CommissioningFlowUserIntent CommissioningFlow = 1
+	// CommissioningFlowCustom indicates that the device requires a vendor specific flow to become commissionable.
+	CommissioningFlowCustom CommissioningFlow = 2
+)
+
+// DiscoveryCapabilities represents the discovery capabilities of a setup payload.
+type DiscoveryCapabilities uint8
+
+const (
+	// DiscoveryCapabilitySoftAP indicates that the device supports Wi-Fi Soft-AP.
+	DiscoveryCapabilitySoftAP DiscoveryCapabilities = 0x01
+	// DiscoveryCapabilityBLE indicates that the device supports BLE.
+	DiscoveryCapabilityBLE DiscoveryCapabilities = 0x02
+	// DiscoveryCapabilityOnNetwork indicates that the device is on an IP network.
+	DiscoveryCapabilityOnNetwork DiscoveryCapabilities = 0x04
+)
+
+const (
+	// QRCodePrefix is the prefix of QR code payloads.
+	QRCodePrefix = "MT:"
+	// OptionalDataTagSerialNumber is the tag of the serial number in the optional data.
+	OptionalDataTagSerialNumber = 0x00
+	// MaxPasscode is the maximum setup passcode.
+	MaxPasscode = 99999998
+)
+
+const (
+	qrCodeBits              = 88
+	manualCodeShortLength   = 11
+	manualCodeLongLength    = 21
+	manualCodeVIDPIDPresent = 0x04
+	passcodeLowerBits       = 14
+	passcodeLowerMask       = 1<<passcodeLowerBits - 1
+)
+
+// Has returns whether all the specified capabilities are set.
+func (caps DiscoveryCapabilities) Has(other DiscoveryCapabilities) bool {
+	return caps&other == other
+}
+
+// SetupPayload represents a Matter onboarding payload encoded in a QR code or a manual pairing code.
+type SetupPayload struct {
+	// Version is the payload version.
+	Version uint8
+	// VendorID is the vendor ID, or zero if it is not present.
+	VendorID uint16
+	// ProductID is the product ID, or zero if it is not present.
+	ProductID uint16
+	// CommissioningFlow is the commissioning flow.
+	CommissioningFlow CommissioningFlow
+	// DiscoveryCapabilities is the discovery capabilities which is only present in QR codes.
+	DiscoveryCapabilities DiscoveryCapabilities
+	// Discriminator is the 12-bit discriminator. Only the upper 4 bits are valid if HasShortDiscriminator is true.
+	Discriminator uint16
+	// HasShortDiscriminator indicates that only the 4-bit short discriminator is known as in manual pairing codes.
+	HasShortDiscriminator bool
+	// Passcode is the setup passcode.
+	Passcode uint32
+	// OptionalData is the optional TLV data which is only present in QR codes.
+	OptionalData []OptionalData
+}
+
+// ParseSetupPayload parses a QR code payload starting with "MT:" or a manual pairing code.
+func ParseSetupPayload(s string) (*SetupPayload, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, QRCodePrefix) {
+		return ParseQRCode(s)
+	}
+	return ParseManualPairingCode(s)
+}
+
+// ParseQRCode parses a QR code payload starting with "MT:".
+func ParseQRCode(s string) (*SetupPayload, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, QRCodePrefix) {
+		return nil, fmt.Errorf("%w QR code prefix: %s", ble.ErrInvalid, s)
+	}
+	s = strings.TrimPrefix(s, QRCodePrefix)
+	if strings.Contains(s, "*") {
+		return nil, fmt.Errorf("%w QR code: concatenated payloads", ble.ErrNotSupported)
+	}
+	b, err := base38Decode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < qrCodeBits/8 {
+		return nil, fmt.Errorf("%w QR code length: %d", ble.ErrInvalid, len(b))
+	}
+	r := &bitReader{b: b, offset: 0}
+	p := &SetupPayload{
+		Version:               uint8(r.read(3)),
+		VendorID:              uint16(r.read(16)),
+		ProductID:             uint16(r.read(16)),
+		CommissioningFlow:     CommissioningFlow(r.read(2)),
+		DiscoveryCapabilities: DiscoveryCapabilities(r.read(8)),
+		Discriminator:         uint16(r.read(12)),
+		HasShortDiscriminator: false,
+		Passcode:              uint32(r.read(27)),
+		OptionalData:          nil,
+	}
+	if r.read(4) != 0 {
+		return nil, fmt.Errorf("%w QR code padding", ble.ErrInvalid)
+	}
+	p.OptionalData, err = parseOptionalData(b[qrCodeBits/8:])
+	if err != nil {
+		return nil, err
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ParseManualPairingCode parses an 11-digit or 21-digit manual pairing code. Dashes and spaces are ignored.
+func ParseManualPairingCode(s string) (*SetupPayload, error) {
+	digits := strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(s))
+	if len(digits) != manualCodeShortLength && len(digits) != manualCodeLongLength {
+		return nil, fmt.Errorf("%w manual pairing code length: %d", ble.ErrInvalid, len(digits))
+	}
+	for _, c := range digits {
+		if c < '0' || '9' < c {
+			return nil, fmt.Errorf("%w manual pairing code: %s", ble.ErrInvalid, s)
+		}
+	}
+	if !verhoeffValidate(digits) {
+		return nil, fmt.Errorf("%w manual pairing code check digit: %s", ble.ErrInvalid, s)
+	}
+
+	chunk := func(from, to int) uint32 {
+		v, _ := strconv.ParseUint(digits[from:to], 10, 32)
+		return uint32(v)
+	}
+	chunk1 := chunk(0, 1)
+	chunk2 := chunk(1, 6)
+	chunk3 := chunk(6, 10)
+	vidPidPresent := (chunk1 & manualCodeVIDPIDPresent) != 0
+	if vidPidPresent != (len(digits) == manualCodeLongLength) || 0xFFFF < chunk2 || 0x1FFF < chunk3 || 7 < chunk1 {
+		return nil, fmt.Errorf("%w manual pairing code: %s", ble.ErrInvalid, s)
+	}
+	shortDisc := (chunk1&0x03)<<2 | chunk2>>passcodeLowerBits
+	p := &SetupPayload{
+		Version:               0,
+		VendorID:              0,
+		ProductID:             0,
+		CommissioningFlow:     CommissioningFlowStandard,
+		DiscoveryCapabilities: 0,
+		Discriminator:         uint16(shortDisc << 8),
+		HasShortDiscriminator: true,
+		Passcode:              chunk3<<passcodeLowerBits | chunk2&passcodeLowerMask,
+		OptionalData:          nil,
+	}
+	if vidPidPresent {
+		vendorID := chunk(10, 15)
+		productID := chunk(15, 20)
+		if 0xFFFF < vendorID || 0xFFFF < productID {
+			return nil, fmt.Errorf("%w manual pairing code: %s", ble.ErrInvalid, s)
+		}
+		p.VendorID = uint16(vendorID)
+		p.ProductID = uint16(productID)
+		p.CommissioningFlow = CommissioningFlowCustom
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *SetupPayload) validate() error {
+	if DiscriminatorMask < p.Discriminator {
+		return fmt.Errorf("%w discriminator: %d", ble.ErrInvalid, p.Discriminator)
+	}
+	if !IsValidPasscode(p.Passcode) {
+		return fmt.Errorf("%w passcode: %d", ble.ErrInvalid, p.Passcode)
+	}
+	return nil
+}
+
+// IsValidPasscode returns whether the setup passcode is in the valid range and is not a trivial passcode.
+func IsValidPasscode(passcode uint32) bool {
+	switch passcode {
+	case 0, 11111111, 22222222, 33333333, 44444444, 55555555, 66666666, 77777777, 88888888, 99999999, 12345678, 87654321:
+		return false
+	}
+	return passcode <= MaxPasscode
+}
+
+// ShortDiscriminator returns the upper 4 bits of the discriminator.
+func (p *SetupPayload) ShortDiscriminator() uint8 {
+	return uint8(p.Discriminator >> 8)
+}
+
+// SerialNumber returns the serial number in the optional data if it is present.
+func (p *SetupPayload) SerialNumber() (string, bool) {
+	for _, elem := range p.OptionalData {
+		if elem.Tag != OptionalDataTagSerialNumber {
+			continue
+		}
+		switch v := elem.Value.(type) {
+		case string:
+			return v, true
+		case uint64:
+			return strconv.FormatUint(v, 10), true
+		}
+	}
+	return "", false
+}
+
+// QRCode returns the QR code payload starting with "MT:".
+func (p *SetupPayload) QRCode() (string, error) {
+	if p.HasShortDiscriminator {
+		return "", fmt.Errorf("%w QR code: the full discriminator is unknown", ble.ErrInvalid)
+	}
+	if err := p.validate(); err != nil {
+		return "", err
+	}
+	w := &bitWriter{b: make([]byte, qrCodeBits/8), offset: 0}
+	w.write(uint64(p.Version), 3)
+	w.write(uint64(p.VendorID), 16)
+	w.write(uint64(p.ProductID), 16)
+	w.write(uint64(p.CommissioningFlow), 2)
+	w.write(uint64(p.DiscoveryCapabilities), 8)
+	w.write(uint64(p.Discriminator), 12)
+	w.write(uint64(p.Passcode), 27)
+	optionalData, err := encodeOptionalData(p.OptionalData)
+	if err != nil {
+		return "", err
+	}
+	return QRCodePrefix + base38Encode(append(w.b, optionalData...)), nil
+}
+
+// ManualPairingCode returns the manual pairing code which is 21 digits long with the vendor and product IDs unless the commissioning flow is standard.
+func (p *SetupPayload) ManualPairingCode() (string, error) {
+	if err := p.validate(); err != nil {
+		return "", err
+	}
+	vidPidPresent := p.CommissioningFlow != CommissioningFlowStandard
+	shortDisc := uint32(p.ShortDiscriminator())
+	chunk1 := shortDisc >> 2
+	if vidPidPresent {
+		chunk1 |= manualCodeVIDPIDPresent
+	}
+	chunk2 := (shortDisc&0x03)<<passcodeLowerBits | p.Passcode&passcodeLowerMask
+	chunk3 := p.Passcode >> passcodeLowerBits
+	digits := fmt.Sprintf("%01d%05d%04d", chunk1, chunk2, chunk3)
+	if vidPidPresent {
+		digits += fmt.Sprintf("%05d%05d", p.VendorID, p.ProductID)
+	}
+	return digits + string(verhoeffCheckDigit(digits)), nil
+}
+
+// MatchesServiceData returns whether the advertised service data matches the discriminator and the vendor and product IDs of the payload.
+// The vendor and product IDs are compared only if both are not zero.
+func (p *SetupPayload) MatchesServiceData(sd *ServiceData) bool {
+	if p.HasShortDiscriminator {
+		if sd.ShortDiscriminator() != p.ShortDiscriminator() {
+			return false
+		}
+	} else if sd.Discriminator != p.Discriminator {
+		return false
+	}
+	if p.VendorID != 0 && sd.VendorID != 0 && p.VendorID != sd.VendorID {
+		return false
+	}
+	if p.ProductID != 0 && sd.ProductID != 0 && p.ProductID != sd.ProductID {
+		return false
+	}
+	return true
+}
+
+// MarshalObject returns an object suitable for marshaling to JSON.
+func (p *SetupPayload) MarshalObject() any {
+	optionalData := make([]any, 0, len(p.OptionalData))
+	for _, elem := range p.OptionalData {
+		optionalData = append(optionalData, struct {
+			Tag   uint8 `json:"tag"`
+			Value any   `json:"value"`
+		}{
+			Tag:   elem.Tag,
+			Value: elem.Value,
+		})
+	}
+	return struct {
+		Version               uint8  `json:"version"`
+		VendorID              uint16 `json:"vendorID"`
+		ProductID             uint16 `json:"productID"`
+		CommissioningFlow     uint8  `json:"commissioningFlow"`
+		DiscoveryCapabilities uint8  `json:"discoveryCapabilities"`
+		Discriminator         uint16 `json:"discriminator"`
+		ShortDiscriminator    bool   `json:"shortDiscriminator"`
+		Passcode              uint32 `json:"passcode"`
+		OptionalData          []any  `json:"optionalData"`
+	}{
+		Version:               p.Version,
+		VendorID:              p.VendorID,
+		ProductID:             p.ProductID,
+		CommissioningFlow:     uint8(p.CommissioningFlow),
+		DiscoveryCapabilities: uint8(p.DiscoveryCapabilities),
+		Discriminator:         p.Discriminator,
+		ShortDiscriminator:    p.HasShortDiscriminator,
+		Passcode:              p.Passcode,
+		OptionalData:          optionalData,
+	}
+}
+
+// String returns a string representation of the setup payload.
+func (p *SetupPayload) String() string {
+	b, err := json.Marshal(p.MarshalObject())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+type bitReader struct {
+	b      []byte
+	offset int
+}
+
+// read reads the specified number of bits in the least significant bit first order.
+func (r *bitReader) read(bits int) uint64 {
+	var v uint64
+	for n := range bits {
+		if (r.b[r.offset/8]>>(r.offset%8))&0x01 != 0 {
+			v |= 1 << n
+		}
+		r.offset++
+	}
+	return v
+}
+
+type bitWriter struct {
+	b      []byte
+	offset int
+}
+
+// write writes the specified number of bits in the least significant bit first order.
+func (w *bitWriter) write(v uint64, bits int) {
+	for n := range bits {
+		if (v>>n)&0x01 != 0 {
+			w.b[w.offset/8] |= 1 << (w.offset % 8)
+		}
+		w.offset++
+	}
+}
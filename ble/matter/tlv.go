@@ -0,0 +1,230 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+const (
+	tlvTagControlAnonymous = 0x00
+	tlvTagControlContext   = 0x20
+	tlvTagControlMask      = 0xE0
+	tlvTypeMask            = 0x1F
+)
+
+const (
+	tlvTypeSignedInt   = 0x00
+	tlvTypeUnsignedInt = 0x04
+	tlvTypeFalse       = 0x08
+	tlvTypeTrue        = 0x09
+	tlvTypeFloat32     = 0x0A
+	tlvTypeFloat64     = 0x0B
+	tlvTypeUTF8String  = 0x0C
+	tlvTypeByteString  = 0x10
+	tlvTypeNull        = 0x14
+	tlvTypeStructure   = 0x15
+	tlvTypeEnd         = 0x18
+)
+
+// OptionalData represents a context-tagged TLV element in the optional data of a QR code payload.
+// The value is one of int64, uint64, bool, float64, string, []byte or nil.
+type OptionalData struct {
+	// Tag is the context-specific tag. Tags from 0x80 are vendor specific.
+	Tag uint8
+	// Value is the element value.
+	Value any
+}
+
+type tlvReader struct {
+	b      []byte
+	offset int
+}
+
+func (r *tlvReader) next(n int) ([]byte, error) {
+	if n < 0 || len(r.b) < r.offset+n {
+		return nil, fmt.Errorf("%w TLV: truncated", ble.ErrInvalid)
+	}
+	v := r.b[r.offset : r.offset+n]
+	r.offset += n
+	return v, nil
+}
+
+func (r *tlvReader) uint(n int) (uint64, error) {
+	b, err := r.next(n)
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for i := n - 1; 0 <= i; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+	return v, nil
+}
+
+// parseOptionalData parses the TLV structure of the optional data which contains context-tagged primitive elements.
+func parseOptionalData(b []byte) ([]OptionalData, error) {
+	elems := []OptionalData{}
+	if len(b) == 0 {
+		return elems, nil
+	}
+	r := &tlvReader{b: b, offset: 0}
+	ctrl, err := r.next(1)
+	if err != nil {
+		return nil, err
+	}
+	if ctrl[0] != tlvTagControlAnonymous|tlvTypeStructure {
+		return nil, fmt.Errorf("%w TLV: not an anonymous structure 0x%02X", ble.ErrInvalid, ctrl[0])
+	}
+	for {
+		ctrl, err := r.next(1)
+		if err != nil {
+			return nil, err
+		}
+		elemType := ctrl[0] & tlvTypeMask
+		if elemType == tlvTypeEnd {
+			return elems, nil
+		}
+		if ctrl[0]&tlvTagControlMask != tlvTagControlContext {
+			return nil, fmt.Errorf("%w TLV tag control: 0x%02X", ble.ErrNotSupported, ctrl[0]&tlvTagControlMask)
+		}
+		tag, err := r.next(1)
+		if err != nil {
+			return nil, err
+		}
+		elem := OptionalData{Tag: tag[0], Value: nil}
+		switch {
+		case elemType < tlvTypeUnsignedInt:
+			size := 1 << (elemType - tlvTypeSignedInt)
+			v, err := r.uint(size)
+			if err != nil {
+				return nil, err
+			}
+			shift := 64 - 8*size
+			elem.Value = int64(v<<shift) >> shift
+		case elemType < tlvTypeFalse:
+			v, err := r.uint(1 << (elemType - tlvTypeUnsignedInt))
+			if err != nil {
+				return nil, err
+			}
+			elem.Value = v
+		case elemType == tlvTypeFalse || elemType == tlvTypeTrue:
+			elem.Value = elemType == tlvTypeTrue
+		case elemType == tlvTypeFloat32:
+			v, err := r.uint(4)
+			if err != nil {
+				return nil, err
+			}
+			elem.Value = float64(math.Float32frombits(uint32(v)))
+		case elemType == tlvTypeFloat64:
+			v, err := r.uint(8)
+			if err != nil {
+				return nil, err
+			}
+			elem.Value = math.Float64frombits(v)
+		case elemType < tlvTypeNull:
+			lenType := elemType & 0x03
+			n, err := r.uint(1 << lenType)
+			if err != nil {
+				return nil, err
+			}
+			v, err := r.next(int(n))
+			if err != nil {
+				return nil, err
+			}
+			if elemType < tlvTypeByteString {
+				elem.Value = string(v)
+			} else {
+				elem.Value = append([]byte{}, v...)
+			}
+		case elemType == tlvTypeNull:
+			elem.Value = nil
+		default:
+			return nil, fmt.Errorf("%w TLV element type: 0x%02X", ble.ErrNotSupported, elemType)
+		}
+		elems = append(elems, elem)
+	}
+}
+
+// encodeOptionalData encodes the optional data as a TLV structure of context-tagged elements.
+func encodeOptionalData(elems []OptionalData) ([]byte, error) {
+	if len(elems) == 0 {
+		return []byte{}, nil
+	}
+	b := []byte{tlvTagControlAnonymous | tlvTypeStructure}
+	appendUint := func(elemType byte, tag uint8, v uint64, size int) {
+		sizeType := map[int]byte{1: 0, 2: 1, 4: 2, 8: 3}[size]
+		b = append(b, tlvTagControlContext|(elemType+sizeType), tag)
+		for i := range size {
+			b = append(b, byte(v>>(8*i)))
+		}
+	}
+	uintSize := func(v uint64) int {
+		switch {
+		case v <= math.MaxUint8:
+			return 1
+		case v <= math.MaxUint16:
+			return 2
+		case v <= math.MaxUint32:
+			return 4
+		default:
+			return 8
+		}
+	}
+	appendBytes := func(elemType byte, tag uint8, v []byte) {
+		size := uintSize(uint64(len(v)))
+		appendUint(elemType, tag, uint64(len(v)), size)
+		b = append(b, v...)
+	}
+	for _, elem := range elems {
+		switch v := elem.Value.(type) {
+		case uint64:
+			appendUint(tlvTypeUnsignedInt, elem.Tag, v, uintSize(v))
+		case int64:
+			size := 8
+			switch {
+			case math.MinInt8 <= v && v <= math.MaxInt8:
+				size = 1
+			case math.MinInt16 <= v && v <= math.MaxInt16:
+				size = 2
+			case math.MinInt32 <= v && v <= math.MaxInt32:
+				size = 4
+			}
+			appendUint(tlvTypeSignedInt, elem.Tag, uint64(v), size)
+		case bool:
+			if v {
+				b = append(b, tlvTagControlContext|tlvTypeTrue, elem.Tag)
+			} else {
+				b = append(b, tlvTagControlContext|tlvTypeFalse, elem.Tag)
+			}
+		case float64:
+			b = append(b, tlvTagControlContext|tlvTypeFloat64, elem.Tag)
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+		case string:
+			appendBytes(tlvTypeUTF8String, elem.Tag, []byte(v))
+		case []byte:
+			appendBytes(tlvTypeByteString, elem.Tag, v)
+		case nil:
+			b = append(b, tlvTagControlContext|tlvTypeNull, elem.Tag)
+		default:
+			return nil, fmt.Errorf("%w TLV value type: %T", ble.ErrNotSupported, elem.Value)
+		}
+	}
+	return append(b, tlvTagControlAnonymous|tlvTypeEnd), nil
+}
@@ -0,0 +1,30 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matter
+
+import (
+	"github.com/cybergarage/go-ble/ble"
+)
+
+var (
+	// ServiceUUID is the 16-bit UUID of the Matter BLE service.
+	ServiceUUID = ble.NewUUIDFromUUID16(0xFFF6)
+	// C1UUID is the UUID of the C1 (Client TX Buffer) characteristic which the commissioner writes to.
+	C1UUID = ble.MustUUIDFromString("18EE2EF5-263D-4559-959F-4F9C429F9D11")
+	// C2UUID is the UUID of the C2 (Client RX Buffer) characteristic which the commissionee indicates on.
+	C2UUID = ble.MustUUIDFromString("18EE2EF5-263D-4559-959F-4F9C429F9D12")
+	// C3UUID is the UUID of the C3 (Additional Commissioning Data) characteristic.
+	C3UUID = ble.MustUUIDFromString("64630238-8772-45F2-B87D-748A83218F04")
+)
@@ -0,0 +1,25 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+// Peripheral represents a Bluetooth peripheral device which serves local GATT services.
+type Peripheral interface {
+	// AddService registers the local service to the peripheral.
+	AddService(service LocalService) error
+	// Services returns the registered local services.
+	Services() []LocalService
+	// LookupService looks up a registered local service by its UUID.
+	LookupService(uuid any) (LocalService, bool)
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"fmt"
+	"sync"
+)
+
+type backendPeripheral struct {
+	sync.Mutex
+	backend  PeripheralBackend
+	enabled  bool
+	services []LocalService
+}
+
+// NewPeripheral creates a new Bluetooth peripheral device with the default backend.
+func NewPeripheral() Peripheral {
+	return NewPeripheralWithBackend(sharedBackend)
+}
+
+// NewPeripheralWithBackend creates a new Bluetooth peripheral device with the specified backend.
+func NewPeripheralWithBackend(backend PeripheralBackend) Peripheral {
+	return &backendPeripheral{
+		Mutex:    sync.Mutex{},
+		backend:  backend,
+		enabled:  false,
+		services: []LocalService{},
+	}
+}
+
+// AddService registers the local service to the peripheral.
+func (p *backendPeripheral) AddService(service LocalService) error {
+	p.Lock()
+	defer p.Unlock()
+
+	if _, ok := p.lookupService(service.UUID()); ok {
+		return fmt.Errorf("service %w: %s", ErrInvalid, service.UUID())
+	}
+
+	if !p.enabled {
+		if err := p.backend.Enable(); err != nil {
+			return err
+		}
+		p.enabled = true
+	}
+
+	backendChars, err := p.backend.AddService(service)
+	if err != nil {
+		return err
+	}
+	chars := service.Characteristics()
+	if len(backendChars) != len(chars) {
+		return fmt.Errorf("%w characteristics: %d != %d", ErrInvalid, len(backendChars), len(chars))
+	}
+	for n, char := range chars {
+		if c, ok := char.(*localCharacteristic); ok {
+			c.bind(backendChars[n])
+		}
+	}
+
+	p.services = append(p.services, service)
+	return nil
+}
+
+// Services returns the registered local services.
+func (p *backendPeripheral) Services() []LocalService {
+	p.Lock()
+	defer p.Unlock()
+	return append([]LocalService{}, p.services...)
+}
+
+// LookupService looks up a registered local service by its UUID.
+func (p *backendPeripheral) LookupService(anyUUID any) (LocalService, bool) {
+	lookupUUID, err := NewUUIDFrom(anyUUID)
+	if err != nil {
+		return nil, false
+	}
+	p.Lock()
+	defer p.Unlock()
+	return p.lookupService(lookupUUID)
+}
+
+func (p *backendPeripheral) lookupService(uuid UUID) (LocalService, bool) {
+	for _, service := range p.services {
+		if uuid.Equal(service.UUID()) {
+			return service, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"regexp"
+	"strings"
+)
+
+// WithScanServiceUUID returns a scan filter which matches devices advertising the service UUID in the service UUID list or with service data.
+// The UUID can be of any type accepted such as string, uint16, uint32, []byte, or UUID, and an invalid UUID matches no devices.
+func WithScanServiceUUID(uuid any) ScanFilter {
+	filterUUID := MustUUIDFrom(uuid)
+	return func(dev Device) bool {
+		if filterUUID.IsNil() {
+			return false
+		}
+		for _, serviceUUID := range dev.Advertisement().ServiceUUIDs() {
+			if filterUUID.Equal(serviceUUID) {
+				return true
+			}
+		}
+		for _, service := range dev.Services() {
+			if filterUUID.Equal(service.UUID()) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// WithScanCompanyID returns a scan filter which matches devices advertising manufacturer specific data of the company ID.
+func WithScanCompanyID(id int) ScanFilter {
+	return func(dev Device) bool {
+		_, ok := dev.LookupManufacturer(id)
+		return ok
+	}
+}
+
+// WithScanLocalNamePrefix returns a scan filter which matches devices whose local name starts with the prefix.
+func WithScanLocalNamePrefix(prefix string) ScanFilter {
+	return func(dev Device) bool {
+		return strings.HasPrefix(dev.LocalName(), prefix)
+	}
+}
+
+// WithScanLocalNameRegexp returns a scan filter which matches devices whose local name matches the regular expression.
+func WithScanLocalNameRegexp(re *regexp.Regexp) ScanFilter {
+	return func(dev Device) bool {
+		return re.MatchString(dev.LocalName())
+	}
+}
+
+// WithScanAddresses returns a scan filter which matches devices with any of the addresses.
+func WithScanAddresses(addrs ...Address) ScanFilter {
+	addrKeys := make(map[string]bool, len(addrs))
+	for _, addr := range addrs {
+		addrKeys[addr.String()] = true
+	}
+	return func(dev Device) bool {
+		return addrKeys[dev.Address().String()]
+	}
+}
+
+// WithScanMinRSSI returns a scan filter which matches devices whose RSSI is at least the specified value in dBm.
+func WithScanMinRSSI(rssi int) ScanFilter {
+	return func(dev Device) bool {
+		return rssi <= dev.RSSI()
+	}
+}
+
+// WithScanPredicate returns a scan filter which matches devices for which the predicate returns true.
+func WithScanPredicate(predicate func(Device) bool) ScanFilter {
+	return ScanFilter(predicate)
+}
+
+// WithScanFiltersAll returns a scan filter which matches devices matching all the filters, or all devices if no filters are specified.
+// Scan filters specified as separate scanner options are combined in the same way.
+func WithScanFiltersAll(filters ...ScanFilter) ScanFilter {
+	return func(dev Device) bool {
+		for _, filter := range filters {
+			if !filter(dev) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// WithScanFiltersAny returns a scan filter which matches devices matching any of the filters, or no devices if no filters are specified.
+func WithScanFiltersAny(filters ...ScanFilter) ScanFilter {
+	return func(dev Device) bool {
+		for _, filter := range filters {
+			if filter(dev) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -30,10 +30,32 @@ type ScannerOption any
 // ScanHandler defines a handler function for scan results.
 type ScanHandler func(Device)
 
+// ScanFilter defines a filter function for scan results. Devices which do not match all filters are neither registered nor handled.
+type ScanFilter func(Device) bool
+
+// DeviceMaxAge is a scanner option which removes the discovered devices which have not advertised for the duration while scanning.
+// Connected devices are never removed since peripherals stop advertising while connected.
+type DeviceMaxAge time.Duration
+
+// MaxDevices is a scanner option which limits the number of the discovered devices while scanning.
+// When a new device exceeds the limit, the device which advertised least recently is removed. Connected devices are never removed.
+type MaxDevices int
+
 // Scanner defines the interface for a Bluetooth scanner.
 type Scanner interface {
 	// Devices returns the list of discovered devices.
 	Devices() []Device
-	// Scan starts scanning for Bluetooth devices.
+	// Scan scans for Bluetooth devices until the context ends, applying DefaultScanTimeout if the context has no deadline.
 	Scan(ctx context.Context, opts ...ScannerOption) error
+	// StartScan starts scanning for Bluetooth devices in the background and returns immediately.
+	// The scan runs until the context ends or StopScan is called. It returns ErrBusy if the scanner is already scanning.
+	StartScan(ctx context.Context, opts ...ScannerOption) error
+	// StopScan stops the running scan and waits until the backend stops scanning. It does nothing if the scanner is not scanning.
+	StopScan() error
+	// IsScanning returns whether the scanner is scanning.
+	IsScanning() bool
+	// Forget removes the device with the address from the discovered devices and returns whether it was discovered.
+	Forget(addr Address) bool
+	// Clear removes all the discovered devices.
+	Clear()
 }
@@ -0,0 +1,438 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// scanStopRetryInterval is the interval of retrying to stop a backend scan which has not started yet when the context ends.
+const scanStopRetryInterval = 100 * time.Millisecond
+
+type backendScanner struct {
+	backend      Backend
+	timeouts     Timeouts
+	devicesMutex sync.RWMutex
+	devices      map[string]*backendDevice
+	lost         map[string]bool
+	scanMutex    sync.Mutex
+	scanCancel   context.CancelFunc
+	scanDone     chan struct{}
+}
+
+// NewScanner creates a new Bluetooth scanner with the default backend.
+func NewScanner() Scanner {
+	return NewScannerWithBackend(DefaultBackend())
+}
+
+// NewScannerWithBackend creates a new Bluetooth scanner with the specified backend.
+func NewScannerWithBackend(backend Backend) Scanner {
+	return newBackendScanner(backend, NewDefaultTimeouts())
+}
+
+func newBackendScanner(backend Backend, timeouts Timeouts) *backendScanner {
+	return &backendScanner{
+		backend:      backend,
+		timeouts:     timeouts,
+		devicesMutex: sync.RWMutex{},
+		devices:      map[string]*backendDevice{},
+		lost:         map[string]bool{},
+		scanMutex:    sync.Mutex{},
+		scanCancel:   nil,
+		scanDone:     nil,
+	}
+}
+
+// Devices returns a snapshot of the discovered devices. It is safe to call while scanning.
+func (s *backendScanner) Devices() []Device {
+	s.devicesMutex.RLock()
+	defer s.devicesMutex.RUnlock()
+	devs := make([]Device, 0, len(s.devices))
+	for _, dev := range s.devices {
+		devs = append(devs, dev)
+	}
+	return devs
+}
+
+// Forget removes the device with the address from the discovered devices and returns whether it was discovered.
+func (s *backendScanner) Forget(addr Address) bool {
+	addrKey := addr.String()
+	s.devicesMutex.Lock()
+	defer s.devicesMutex.Unlock()
+	_, ok := s.devices[addrKey]
+	s.removeDevice(addrKey)
+	return ok
+}
+
+// Clear removes all the discovered devices.
+func (s *backendScanner) Clear() {
+	s.devicesMutex.Lock()
+	defer s.devicesMutex.Unlock()
+	s.devices = map[string]*backendDevice{}
+	s.lost = map[string]bool{}
+}
+
+// removeDevice removes the device with the address key. The caller must hold the lock of the devices.
+func (s *backendScanner) removeDevice(addrKey string) {
+	delete(s.devices, addrKey)
+	delete(s.lost, addrKey)
+}
+
+// registerDevice merges the scanned device into the discovered device with the same address, or registers it if it matches the filters.
+// If a new device exceeds the maximum number of devices, the device which advertised least recently is removed.
+// It returns the discovered device with the lifecycle event caused by the advertisement if any,
+// or false if the scanned device is neither discovered nor matches the filters.
+func (s *backendScanner) registerDevice(scanDev *backendDevice, matches bool, maxDevices int, now time.Time) (*backendDevice, *DeviceEvent, bool) {
+	addrKey := scanDev.Address().String()
+	s.devicesMutex.Lock()
+	defer s.devicesMutex.Unlock()
+	discoveredDev, ok := s.devices[addrKey]
+	if ok {
+		changes := discoveredDev.updateFromScanDevice(scanDev, now)
+		if s.lost[addrKey] {
+			delete(s.lost, addrKey)
+			return discoveredDev, newDeviceEvent(DeviceDiscovered, discoveredDev, 0, now), true
+		}
+		if changes != 0 {
+			return discoveredDev, newDeviceEvent(DeviceUpdated, discoveredDev, changes, now), true
+		}
+		return discoveredDev, nil, true
+	}
+	if !matches {
+		return nil, nil, false
+	}
+	s.devices[addrKey] = scanDev
+	if 0 < maxDevices && maxDevices < len(s.devices) {
+		s.evictLeastRecentlySeenDevice(addrKey)
+	}
+	return scanDev, newDeviceEvent(DeviceDiscovered, scanDev, 0, now), true
+}
+
+// evictLeastRecentlySeenDevice removes the disconnected device which advertised least recently except the device with the address key.
+// The caller must hold the lock of the devices.
+func (s *backendScanner) evictLeastRecentlySeenDevice(exceptAddrKey string) {
+	var evictKey string
+	var evictLastSeenAt time.Time
+	for addrKey, dev := range s.devices {
+		if addrKey == exceptAddrKey || dev.IsConnected() {
+			continue
+		}
+		if lastSeenAt := dev.LastSeenAt(); len(evictKey) == 0 || lastSeenAt.Before(evictLastSeenAt) {
+			evictKey = addrKey
+			evictLastSeenAt = lastSeenAt
+		}
+	}
+	if 0 < len(evictKey) {
+		s.removeDevice(evictKey)
+	}
+}
+
+// expireDevices removes the disconnected devices which have not advertised for the maximum age.
+func (s *backendScanner) expireDevices(maxAge time.Duration, now time.Time) {
+	s.devicesMutex.Lock()
+	defer s.devicesMutex.Unlock()
+	for addrKey, dev := range s.devices {
+		if !dev.IsConnected() && maxAge <= now.Sub(dev.LastSeenAt()) {
+			s.removeDevice(addrKey)
+		}
+	}
+}
+
+// markLostDevices marks the devices which have not advertised for the timeout as lost and returns them.
+func (s *backendScanner) markLostDevices(timeout time.Duration, now time.Time) []*backendDevice {
+	s.devicesMutex.Lock()
+	defer s.devicesMutex.Unlock()
+	lostDevs := []*backendDevice{}
+	for addrKey, dev := range s.devices {
+		if s.lost[addrKey] || dev.IsConnected() || now.Sub(dev.LastSeenAt()) < timeout {
+			continue
+		}
+		s.lost[addrKey] = true
+		lostDevs = append(lostDevs, dev)
+	}
+	return lostDevs
+}
+
+func newDeviceEvent(eventType DeviceEventType, dev Device, changes DeviceChanges, now time.Time) *DeviceEvent {
+	return &DeviceEvent{
+		Type:    eventType,
+		Device:  dev,
+		Changes: changes,
+		Time:    now,
+	}
+}
+
+// Scan scans for Bluetooth devices until the context ends, applying DefaultScanTimeout if the context has no deadline.
+func (s *backendScanner) Scan(ctx context.Context, opts ...ScannerOption) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultScanTimeout)
+		defer cancel()
+	}
+	errCh, err := s.startScan(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	return <-errCh
+}
+
+// StartScan starts scanning for Bluetooth devices in the background and returns immediately.
+// The scan runs until the context ends or StopScan is called. It returns ErrBusy if the scanner is already scanning.
+func (s *backendScanner) StartScan(ctx context.Context, opts ...ScannerOption) error {
+	_, err := s.startScan(ctx, opts...)
+	return err
+}
+
+// StopScan stops the running scan and waits until the backend stops scanning. It does nothing if the scanner is not scanning.
+func (s *backendScanner) StopScan() error {
+	s.scanMutex.Lock()
+	cancel := s.scanCancel
+	done := s.scanDone
+	s.scanMutex.Unlock()
+	if done == nil {
+		return nil
+	}
+	cancel()
+	<-done
+	return nil
+}
+
+// IsScanning returns whether the scanner is scanning.
+func (s *backendScanner) IsScanning() bool {
+	s.scanMutex.Lock()
+	defer s.scanMutex.Unlock()
+	return s.scanDone != nil
+}
+
+// startScan starts scanning in the background and returns the channel which receives the result of the backend scan.
+func (s *backendScanner) startScan(ctx context.Context, opts ...ScannerOption) (<-chan error, error) {
+	s.scanMutex.Lock()
+	defer s.scanMutex.Unlock()
+	if s.scanDone != nil {
+		return nil, fmt.Errorf("scan %w", ErrBusy)
+	}
+	if err := s.backend.Enable(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	errCh := make(chan error, 1)
+	s.scanCancel = cancel
+	s.scanDone = done
+	scanOpts := newScanOptions(opts...)
+	var wg sync.WaitGroup
+	if interval, ok := scanOpts.watchInterval(); ok {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.watchDevices(ctx, scanOpts, interval)
+		}()
+	}
+	go func() {
+		err := s.runScan(ctx, s.newScanHandler(ctx, scanOpts))
+		cancel()
+		// No events are delivered after the scan is reported stopped.
+		wg.Wait()
+		s.scanMutex.Lock()
+		s.scanCancel = nil
+		s.scanDone = nil
+		s.scanMutex.Unlock()
+		close(done)
+		errCh <- err
+	}()
+	return errCh, nil
+}
+
+// runScan runs the backend scan and stops it as soon as the context ends regardless of the advertisement traffic.
+func (s *backendScanner) runScan(ctx context.Context, handler BackendScanHandler) error {
+	scanned := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-scanned:
+			return
+		case <-ctx.Done():
+		}
+		// The backend may not have started scanning yet when the context ends, so stopping is retried until the scan returns.
+		for {
+			select {
+			case <-scanned:
+				return
+			default:
+			}
+			s.backend.StopScan()
+			select {
+			case <-scanned:
+				return
+			case <-time.After(scanStopRetryInterval):
+			}
+		}
+	}()
+	err := s.backend.Scan(handler)
+	close(scanned)
+	// A late stop must not reach the backend after the next scan starts.
+	<-stopped
+	return err
+}
+
+// newScanHandler returns the backend scan handler which registers the scanned devices and calls the scan handlers.
+func (s *backendScanner) newScanHandler(ctx context.Context, scanOpts *scanOptions) BackendScanHandler {
+	return func(scanRes ScanResult) {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			scanDev := newDeviceFromScanResult(s.backend, s.timeouts, scanRes)
+			// The filters are evaluated outside the lock since they may call back into the scanner.
+			discoveredDev, event, ok := s.registerDevice(scanDev, scanOpts.matches(scanDev), scanOpts.maxDevices, time.Now())
+			if !ok {
+				return
+			}
+
+			if !scanOpts.matches(discoveredDev) {
+				return
+			}
+
+			if event != nil {
+				scanOpts.dispatch(*event)
+			}
+			for _, scanHandler := range scanOpts.handlers {
+				scanHandler(discoveredDev)
+			}
+		}
+	}
+}
+
+// watchDevices reports the devices which have not advertised for the lost timeout and removes the devices older than the maximum age until the context ends.
+func (s *backendScanner) watchDevices(ctx context.Context, scanOpts *scanOptions, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if scanOpts.reportsLostDevices() {
+				for _, dev := range s.markLostDevices(scanOpts.lostTimeout, now) {
+					if scanOpts.matches(dev) {
+						scanOpts.dispatch(*newDeviceEvent(DeviceLost, dev, 0, now))
+					}
+				}
+			}
+			if 0 < scanOpts.maxAge {
+				s.expireDevices(scanOpts.maxAge, now)
+			}
+		}
+	}
+}
+
+type scanOptions struct {
+	handlers      []ScanHandler
+	filters       []ScanFilter
+	eventHandlers []DeviceEventHandler
+	eventChannels []DeviceEventChannel
+	lostTimeout   time.Duration
+	maxAge        time.Duration
+	maxDevices    int
+	eventMutex    sync.Mutex
+}
+
+func newScanOptions(opts ...ScannerOption) *scanOptions {
+	scanOpts := &scanOptions{
+		handlers:      []ScanHandler{},
+		filters:       []ScanFilter{},
+		eventHandlers: []DeviceEventHandler{},
+		eventChannels: []DeviceEventChannel{},
+		lostTimeout:   DefaultDeviceLostTimeout,
+		maxAge:        0,
+		maxDevices:    0,
+		eventMutex:    sync.Mutex{},
+	}
+	for _, opt := range opts {
+		switch v := opt.(type) {
+		case ScanHandler:
+			scanOpts.handlers = append(scanOpts.handlers, v)
+		case ScanFilter:
+			scanOpts.filters = append(scanOpts.filters, v)
+		case DeviceEventHandler:
+			scanOpts.eventHandlers = append(scanOpts.eventHandlers, v)
+		case DeviceEventChannel:
+			scanOpts.eventChannels = append(scanOpts.eventChannels, v)
+		case DeviceLostTimeout:
+			scanOpts.lostTimeout = time.Duration(v)
+		case DeviceMaxAge:
+			scanOpts.maxAge = time.Duration(v)
+		case MaxDevices:
+			scanOpts.maxDevices = int(v)
+		}
+	}
+	return scanOpts
+}
+
+// matches returns whether the device matches all the filters.
+func (scanOpts *scanOptions) matches(dev Device) bool {
+	for _, scanFilter := range scanOpts.filters {
+		if !scanFilter(dev) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasEventReceivers returns whether any event handler or channel is specified.
+func (scanOpts *scanOptions) hasEventReceivers() bool {
+	return 0 < len(scanOpts.eventHandlers) || 0 < len(scanOpts.eventChannels)
+}
+
+// reportsLostDevices returns whether lost devices are reported to the event receivers.
+func (scanOpts *scanOptions) reportsLostDevices() bool {
+	return scanOpts.hasEventReceivers() && 0 < scanOpts.lostTimeout
+}
+
+// watchInterval returns the interval of watching the devices for the lost timeout and the maximum age, or false if neither applies.
+func (scanOpts *scanOptions) watchInterval() (time.Duration, bool) {
+	timeouts := []time.Duration{}
+	if scanOpts.reportsLostDevices() {
+		timeouts = append(timeouts, scanOpts.lostTimeout)
+	}
+	if 0 < scanOpts.maxAge {
+		timeouts = append(timeouts, scanOpts.maxAge)
+	}
+	if len(timeouts) == 0 {
+		return 0, false
+	}
+	return min(max(slices.Min(timeouts)/4, time.Millisecond), time.Second), true
+}
+
+// dispatch delivers the event to the event handlers and channels one event at a time.
+func (scanOpts *scanOptions) dispatch(event DeviceEvent) {
+	scanOpts.eventMutex.Lock()
+	defer scanOpts.eventMutex.Unlock()
+	for _, handler := range scanOpts.eventHandlers {
+		handler(event)
+	}
+	for _, ch := range scanOpts.eventChannels {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/cybergarage/go-ble/ble"
+	"github.com/cybergarage/go-ble/ble/matter"
 	"github.com/cybergarage/go-logger/log"
 )
 
@@ -46,23 +47,11 @@ func ExampleScanner_matter() {
 	scanner := ble.NewScanner()
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	scanner.Scan(ctx)
-	lookupMatterDevices := func(devs []ble.Device, targetDisc uint16) (ble.Device, bool) {
-		for _, dev := range devs {
-			if service, ok := dev.LookupService(0xFFF6); ok {
-				if 3 <= len(service.Data()) {
-					serviceDisc := uint16(service.Data()[1]) | (uint16(service.Data()[2]) << 8)
-					if serviceDisc == targetDisc {
-						return dev, true
-					}
-				}
-			}
-		}
-		return nil, false
-	}
 	targetDisc := uint16(4068)
-	dev, ok := lookupMatterDevices(scanner.Devices(), targetDisc)
-	if ok {
-		fmt.Printf("Matter device found: %s", dev.String())
+	scanner.Scan(ctx, matter.WithDiscriminator(targetDisc))
+	for _, dev := range scanner.Devices() {
+		if sd, ok := matter.LookupServiceData(dev); ok {
+			fmt.Printf("Matter device found: %s (%s)", dev.String(), sd.String())
+		}
 	}
 }
@@ -15,6 +15,7 @@
 package ble
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -24,6 +25,13 @@ import (
 	"github.com/cybergarage/go-ble/ble/db"
 )
 
+var (
+	// GenericAttributeServiceUUID is the UUID of the Generic Attribute service.
+	GenericAttributeServiceUUID = NewUUIDFromUUID16(0x1801)
+	// ServiceChangedUUID is the UUID of the Service Changed characteristic of the Generic Attribute service.
+	ServiceChangedUUID = NewUUIDFromUUID16(0x2A05)
+)
+
 // Service represents a Bluetooth service.
 type Service interface {
 	// ServiceDescriptor represents a Bluetooth service descriptor.
@@ -50,6 +58,10 @@ type ServiceDescriptor interface {
 	LookupCharacteristic(uuid any) (Characteristic, bool)
 	// Characteristics returns the characteristics of the service.
 	Characteristics() []Characteristic
+	// IsPrimary returns whether the service is a primary service. Services known only from advertisements are primary.
+	IsPrimary() bool
+	// IncludedServices returns the services included by the service.
+	IncludedServices() []Service
 }
 
 type serviceTransportUUIDs struct {
@@ -86,24 +98,30 @@ func WithTransportNotifyUUID(uuid UUID) ServiceTransportOption {
 type ServiceOperator interface {
 	// Open opens a transport on the service with the specified options.
 	Open(opts ...ServiceTransportOption) (Transport, error)
+	// OpenContext opens a transport on the service with the context, which bounds the subscription to the notify characteristic.
+	OpenContext(ctx context.Context, opts ...ServiceTransportOption) (Transport, error)
 }
 
 type service struct {
 	dev Device
 	db.Service
-	uuid    UUID
-	data    []byte
-	charMap sync.Map
+	uuid     UUID
+	data     []byte
+	charMap  sync.Map
+	primary  bool
+	included []Service
 }
 
 func newService(dev Device, uuid UUID, data []byte, chars []Characteristic) *service {
 	dbService, _ := db.DefaultDatabase().LookupService(uuid)
 	s := &service{
-		Service: dbService,
-		dev:     dev,
-		uuid:    uuid,
-		data:    data,
-		charMap: sync.Map{},
+		Service:  dbService,
+		dev:      dev,
+		uuid:     uuid,
+		data:     data,
+		charMap:  sync.Map{},
+		primary:  true,
+		included: []Service{},
 	}
 	for _, char := range chars {
 		s.charMap.Store(char.UUID(), char)
@@ -156,6 +174,16 @@ func (s *service) Characteristics() []Characteristic {
 	return chars
 }
 
+// IsPrimary returns whether the service is a primary service. Services known only from advertisements are primary.
+func (s *service) IsPrimary() bool {
+	return s.primary
+}
+
+// IncludedServices returns the services included by the service.
+func (s *service) IncludedServices() []Service {
+	return s.included
+}
+
 // addDeviceCharacteristic adds a characteristic to the service.
 func (s *service) addDeviceCharacteristic(char Characteristic) {
 	s.charMap.Store(char.UUID(), char)
@@ -171,21 +199,35 @@ func (s *service) MarshalObject() any {
 		}
 		return true
 	})
+	included := make([]string, 0, len(s.included))
+	for _, service := range s.included {
+		included = append(included, service.UUID().String())
+	}
 	return struct {
-		UUID            string `json:"uuid"`
-		Name            string `json:"name"`
-		Data            string `json:"data"`
-		Characteristics []any  `json:"characteristics"`
+		UUID             string   `json:"uuid"`
+		Name             string   `json:"name"`
+		Primary          bool     `json:"primary"`
+		Data             string   `json:"data"`
+		IncludedServices []string `json:"includedServices"`
+		Characteristics  []any    `json:"characteristics"`
 	}{
-		UUID:            s.uuid.String(),
-		Name:            s.Name(),
-		Data:            strings.ToUpper(hex.EncodeToString(s.data)),
-		Characteristics: charObjs,
+		UUID:             s.uuid.String(),
+		Name:             s.Name(),
+		Primary:          s.primary,
+		Data:             strings.ToUpper(hex.EncodeToString(s.data)),
+		IncludedServices: included,
+		Characteristics:  charObjs,
 	}
 }
 
 // Open opens a transport on the service with the specified options.
 func (s *service) Open(opts ...ServiceTransportOption) (Transport, error) {
+	return s.OpenContext(context.Background(), opts...)
+}
+
+// OpenContext opens a transport on the service with the context, which bounds the subscription to the notify characteristic.
+// The transport reads with the transport timeout of the device.
+func (s *service) OpenContext(ctx context.Context, opts ...ServiceTransportOption) (Transport, error) {
 	uuids := serviceTransportUUIDs{
 		readUUID:   NewNilUUID(),
 		writeUUID:  NewNilUUID(),
@@ -195,7 +237,9 @@ func (s *service) Open(opts ...ServiceTransportOption) (Transport, error) {
 		opt(&uuids)
 	}
 
-	transportOpts := []TransportOption{}
+	transportOpts := []TransportOption{
+		WithTransportTimeout(deviceTimeouts(s.dev).Transport),
+	}
 	if !uuids.readUUID.IsNil() {
 		char, ok := s.LookupCharacteristic(uuids.readUUID)
 		if !ok {
@@ -218,8 +262,8 @@ func (s *service) Open(opts ...ServiceTransportOption) (Transport, error) {
 		transportOpts = append(transportOpts, WithTransportNotifyCharacteristic(char))
 	}
 
-	transport := NewTransport(transportOpts...)
-	if err := transport.Open(); err != nil {
+	transport := newTransport(transportOpts...)
+	if err := transport.openContext(ctx); err != nil {
 		return nil, err
 	}
 	return transport, nil
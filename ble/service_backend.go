@@ -0,0 +1,41 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+type backendService struct {
+	*service
+	backendService BackendService
+}
+
+func newBackendService(dev Device, service BackendService, uuid UUID, data []byte, chars []Characteristic) *backendService {
+	s := &backendService{
+		service:        newService(dev, uuid, data, chars),
+		backendService: service,
+	}
+	s.primary = service.IsPrimary()
+	return s
+}
+
+// resolveIncludedServices sets the included services of the service from the discovered services of the device.
+func (s *backendService) resolveIncludedServices(services []Service) {
+	for _, uuid := range s.backendService.IncludedServiceUUIDs() {
+		for _, service := range services {
+			if uuid.Equal(service.UUID()) {
+				s.included = append(s.included, service)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+// ServiceData represents a service data element in an advertisement.
+type ServiceData interface {
+	// UUID returns the service UUID.
+	UUID() UUID
+	// Data returns the service data.
+	Data() []byte
+	// MarshalObject returns an object suitable for marshaling to JSON.
+	MarshalObject() any
+	// String returns a string representation of the service data.
+	String() string
+}
+
+type serviceData struct {
+	uuid UUID
+	data []byte
+}
+
+// NewServiceData returns a new service data element.
+func NewServiceData(uuid UUID, data []byte) ServiceData {
+	return &serviceData{
+		uuid: uuid,
+		data: data,
+	}
+}
+
+// UUID returns the service UUID.
+func (sd *serviceData) UUID() UUID {
+	return sd.uuid
+}
+
+// Data returns the service data.
+func (sd *serviceData) Data() []byte {
+	return sd.data
+}
+
+// MarshalObject returns an object suitable for marshaling to JSON.
+func (sd *serviceData) MarshalObject() any {
+	return struct {
+		UUID string `json:"uuid"`
+		Data string `json:"data"`
+	}{
+		UUID: sd.uuid.String(),
+		Data: strings.ToUpper(hex.EncodeToString(sd.data)),
+	}
+}
+
+// String returns a string representation of the service data.
+func (sd *serviceData) String() string {
+	b, err := json.Marshal(sd.MarshalObject())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
@@ -0,0 +1,263 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Snapshot represents a snapshot of the discovered GATT table of a device.
+type Snapshot interface {
+	// Address returns the Bluetooth address of the device.
+	Address() Address
+	// LocalName returns the local name of the device.
+	LocalName() string
+	// TakenAt returns the time when the snapshot was taken.
+	TakenAt() time.Time
+	// Device returns a read-only device which exposes the GATT table of the snapshot.
+	// Its characteristics and descriptors return the captured values on reads and reject writes and subscriptions.
+	Device() Device
+	// Diff returns the changes of the services and characteristics from the snapshot to the specified snapshot.
+	Diff(to Snapshot) []SnapshotChange
+	// JSON returns the snapshot encoded in JSON.
+	JSON() ([]byte, error)
+	// YAML returns the snapshot encoded in YAML.
+	YAML() ([]byte, error)
+	// MarshalObject returns an object suitable for marshaling to JSON.
+	MarshalObject() any
+	// String returns a string representation of the snapshot.
+	String() string
+}
+
+// SnapshotOption represents an option for taking a snapshot.
+type SnapshotOption func(*snapshotOptions)
+
+type snapshotOptions struct {
+	values bool
+}
+
+// WithSnapshotValues reads the current values of the readable characteristics and the descriptors into the snapshot.
+// Values which cannot be read are omitted.
+func WithSnapshotValues() SnapshotOption {
+	return func(opts *snapshotOptions) {
+		opts.values = true
+	}
+}
+
+// The snapshot objects share the keys of the MarshalObject structures of the device, services, characteristics and descriptors.
+
+// nolint: tagliatelle
+type snapshotObject struct {
+	Address   string                   `json:"address"   yaml:"address"`
+	LocalName string                   `json:"localName" yaml:"localName"`
+	TakenAt   string                   `json:"takenAt"   yaml:"takenAt"`
+	Services  []*snapshotServiceObject `json:"services"  yaml:"services"`
+}
+
+// nolint: tagliatelle
+type snapshotServiceObject struct {
+	UUID             string                          `json:"uuid"             yaml:"uuid"`
+	Name             string                          `json:"name"             yaml:"name"`
+	Primary          bool                            `json:"primary"          yaml:"primary"`
+	Data             string                          `json:"data"             yaml:"data"`
+	IncludedServices []string                        `json:"includedServices" yaml:"includedServices"`
+	Characteristics  []*snapshotCharacteristicObject `json:"characteristics"  yaml:"characteristics"`
+}
+
+// nolint: tagliatelle
+type snapshotCharacteristicObject struct {
+	UUID        string                      `json:"uuid"            yaml:"uuid"`
+	Name        string                      `json:"name"            yaml:"name"`
+	ID          string                      `json:"id"              yaml:"id"`
+	Properties  []string                    `json:"properties"      yaml:"properties"`
+	Value       *string                     `json:"value,omitempty" yaml:"value,omitempty"`
+	Descriptors []*snapshotDescriptorObject `json:"descriptors"     yaml:"descriptors"`
+}
+
+// nolint: tagliatelle
+type snapshotDescriptorObject struct {
+	UUID  string  `json:"uuid"            yaml:"uuid"`
+	Name  string  `json:"name"            yaml:"name"`
+	ID    string  `json:"id"              yaml:"id"`
+	Value *string `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+type snapshot struct {
+	obj *snapshotObject
+	dev *snapshotDevice
+}
+
+// NewSnapshot discovers the GATT table of the connected device and returns the snapshot of it.
+// The operation timeout applies to each operation if the context has no deadline.
+func NewSnapshot(ctx context.Context, dev Device, opts ...SnapshotOption) (Snapshot, error) {
+	options := &snapshotOptions{
+		values: false,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	services, err := dev.DiscoverServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &snapshotObject{
+		Address:   dev.Address().String(),
+		LocalName: dev.LocalName(),
+		TakenAt:   time.Now().Format(time.RFC3339),
+		Services:  make([]*snapshotServiceObject, 0, len(services)),
+	}
+	for _, service := range services {
+		serviceObj := &snapshotServiceObject{} // nolint: exhaustruct
+		if err := decodeMarshalObject(service.MarshalObject(), serviceObj); err != nil {
+			return nil, err
+		}
+		// The characteristics are sorted since services do not keep the discovered order.
+		sort.Slice(serviceObj.Characteristics, func(i, j int) bool {
+			return serviceObj.Characteristics[i].UUID < serviceObj.Characteristics[j].UUID
+		})
+		if options.values {
+			readSnapshotValues(ctx, service, serviceObj)
+		}
+		obj.Services = append(obj.Services, serviceObj)
+	}
+	return newSnapshot(obj)
+}
+
+// NewSnapshotFromJSON returns the snapshot decoded from JSON.
+func NewSnapshotFromJSON(b []byte) (Snapshot, error) {
+	obj := &snapshotObject{} // nolint: exhaustruct
+	if err := json.Unmarshal(b, obj); err != nil {
+		return nil, fmt.Errorf("%w snapshot: %s", ErrInvalid, err)
+	}
+	return newSnapshot(obj)
+}
+
+// NewSnapshotFromYAML returns the snapshot decoded from YAML.
+func NewSnapshotFromYAML(b []byte) (Snapshot, error) {
+	obj := &snapshotObject{} // nolint: exhaustruct
+	if err := yaml.Unmarshal(b, obj); err != nil {
+		return nil, fmt.Errorf("%w snapshot: %s", ErrInvalid, err)
+	}
+	return newSnapshot(obj)
+}
+
+func newSnapshot(obj *snapshotObject) (*snapshot, error) {
+	dev, err := newSnapshotDevice(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		obj: obj,
+		dev: dev,
+	}, nil
+}
+
+// decodeMarshalObject decodes the object returned by MarshalObject into the snapshot object.
+func decodeMarshalObject(obj any, v any) error {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// readSnapshotValues reads the values of the readable characteristics and the descriptors of the service into the snapshot object.
+// Characteristics without reported properties are read as well since some backends do not report them, and a failed read leaves the value uncaptured.
+func readSnapshotValues(ctx context.Context, service Service, serviceObj *snapshotServiceObject) {
+	// An empty value is kept distinct from an uncaptured one.
+	encodeValue := func(b []byte) *string {
+		s := strings.ToUpper(hex.EncodeToString(b))
+		return &s
+	}
+	for _, charObj := range serviceObj.Characteristics {
+		char, ok := service.LookupCharacteristic(charObj.UUID)
+		if !ok {
+			continue
+		}
+		if props := char.Properties(); props == 0 || props.IsReadable() {
+			if b, err := char.ReadContext(ctx); err == nil {
+				charObj.Value = encodeValue(b)
+			}
+		}
+		for _, descObj := range charObj.Descriptors {
+			desc, ok := char.LookupDescriptor(descObj.UUID)
+			if !ok {
+				continue
+			}
+			if b, err := desc.ReadContext(ctx); err == nil {
+				descObj.Value = encodeValue(b)
+			}
+		}
+	}
+}
+
+// Address returns the Bluetooth address of the device.
+func (s *snapshot) Address() Address {
+	return s.dev.Address()
+}
+
+// LocalName returns the local name of the device.
+func (s *snapshot) LocalName() string {
+	return s.obj.LocalName
+}
+
+// TakenAt returns the time when the snapshot was taken.
+func (s *snapshot) TakenAt() time.Time {
+	return s.dev.DiscoveredAt()
+}
+
+// Device returns a read-only device which exposes the GATT table of the snapshot.
+func (s *snapshot) Device() Device {
+	return s.dev
+}
+
+// Diff returns the changes of the services and characteristics from the snapshot to the specified snapshot.
+func (s *snapshot) Diff(to Snapshot) []SnapshotChange {
+	return diffServices(s.Device().Services(), to.Device().Services())
+}
+
+// JSON returns the snapshot encoded in JSON.
+func (s *snapshot) JSON() ([]byte, error) {
+	return json.MarshalIndent(s.obj, "", "  ")
+}
+
+// YAML returns the snapshot encoded in YAML.
+func (s *snapshot) YAML() ([]byte, error) {
+	return yaml.Marshal(s.obj)
+}
+
+// MarshalObject returns an object suitable for marshaling to JSON.
+func (s *snapshot) MarshalObject() any {
+	return s.obj
+}
+
+// String returns a string representation of the snapshot.
+func (s *snapshot) String() string {
+	b, err := json.Marshal(s.MarshalObject())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
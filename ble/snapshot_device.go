@@ -0,0 +1,401 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// snapshotDevice represents a read-only device which exposes the GATT table of a snapshot.
+type snapshotDevice struct {
+	*baseDevice
+	obj      *snapshotObject
+	addr     Address
+	services []Service
+}
+
+func newSnapshotDevice(obj *snapshotObject) (*snapshotDevice, error) {
+	addr, err := NewAddressFromString(obj.Address)
+	if err != nil {
+		return nil, err
+	}
+	takenAt, err := time.Parse(time.RFC3339, obj.TakenAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w snapshot time: %s", ErrInvalid, obj.TakenAt)
+	}
+	dev := &snapshotDevice{
+		baseDevice: newBaseDeviceAt(takenAt),
+		obj:        obj,
+		addr:       addr,
+		services:   []Service{},
+	}
+
+	// The services are built from the snapshot objects like the services discovered using a backend.
+	services := make([]*backendService, 0, len(obj.Services))
+	for _, serviceObj := range obj.Services {
+		snapshotService, err := newSnapshotBackendService(serviceObj)
+		if err != nil {
+			return nil, err
+		}
+		data, err := hex.DecodeString(serviceObj.Data)
+		if err != nil {
+			return nil, fmt.Errorf("%w service data: %s", ErrInvalid, serviceObj.Data)
+		}
+		service := newBackendService(dev, snapshotService, snapshotService.uuid, data, []Characteristic{})
+		for _, snapshotChar := range snapshotService.chars {
+			char := newBackendCharacteristic(service, snapshotChar.uuid, snapshotChar)
+			if err := char.discoverDescriptors(); err != nil {
+				return nil, err
+			}
+			service.addDeviceCharacteristic(char)
+		}
+		services = append(services, service)
+		dev.services = append(dev.services, service)
+	}
+	for _, service := range services {
+		service.resolveIncludedServices(dev.services)
+	}
+	return dev, nil
+}
+
+// Manufacturer returns no manufacturer since snapshots do not record advertisements.
+func (dev *snapshotDevice) Manufacturer() Manufacturer {
+	return newNilManufacturer()
+}
+
+// Manufacturers returns no manufacturers since snapshots do not record advertisements.
+func (dev *snapshotDevice) Manufacturers() []Manufacturer {
+	return []Manufacturer{}
+}
+
+// LookupManufacturer returns false since snapshots do not record advertisements.
+func (dev *snapshotDevice) LookupManufacturer(id int) (Manufacturer, bool) {
+	return nil, false
+}
+
+// LocalName returns the local name of the device.
+func (dev *snapshotDevice) LocalName() string {
+	return dev.obj.LocalName
+}
+
+// Address returns the Bluetooth address of the device.
+func (dev *snapshotDevice) Address() Address {
+	return dev.addr
+}
+
+// Services returns the GATT services of the snapshot.
+func (dev *snapshotDevice) Services() []Service {
+	return dev.services
+}
+
+// RSSI returns zero since snapshots do not record advertisements.
+func (dev *snapshotDevice) RSSI() int {
+	return 0
+}
+
+// Advertisement returns an empty advertisement since snapshots do not record advertisements.
+func (dev *snapshotDevice) Advertisement() Advertisement {
+	return newAdvertisement([]byte{})
+}
+
+// Connect returns ErrNotPermitted since the device is read-only.
+func (dev *snapshotDevice) Connect(ctx context.Context, opts ...ConnectOption) error {
+	return fmt.Errorf("%w connect: snapshot of %s", ErrNotPermitted, dev.addr)
+}
+
+// Disconnect does nothing since the device is never connected.
+func (dev *snapshotDevice) Disconnect() error {
+	return nil
+}
+
+// DisconnectContext does nothing since the device is never connected.
+func (dev *snapshotDevice) DisconnectContext(ctx context.Context) error {
+	return nil
+}
+
+// IsConnected returns false since the device is never connected.
+func (dev *snapshotDevice) IsConnected() bool {
+	return false
+}
+
+// MTU returns DefaultATTMTU since the device is never connected.
+func (dev *snapshotDevice) MTU() int {
+	return DefaultATTMTU
+}
+
+// WritePacer returns a write queue without pacing since the device rejects writes.
+func (dev *snapshotDevice) WritePacer() WritePacer {
+	return NewWritePacer(WritePacing{}) // nolint: exhaustruct
+}
+
+// LookupService looks up a service of the snapshot by its UUID.
+func (dev *snapshotDevice) LookupService(anyUUID any) (Service, bool) {
+	service, err := dev.LookupServiceContext(context.Background(), anyUUID)
+	if err != nil {
+		return nil, false
+	}
+	return service, true
+}
+
+// LookupServiceContext looks up a service of the snapshot by its UUID.
+func (dev *snapshotDevice) LookupServiceContext(ctx context.Context, anyUUID any) (Service, error) {
+	lookupUUID, err := NewUUIDFrom(anyUUID)
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range dev.services {
+		if lookupUUID.Equal(service.UUID()) {
+			return service, nil
+		}
+	}
+	return nil, fmt.Errorf("service %w: %s", ErrNotFound, lookupUUID.String())
+}
+
+// DiscoverServices returns the GATT services of the snapshot.
+func (dev *snapshotDevice) DiscoverServices(ctx context.Context) ([]Service, error) {
+	return dev.services, nil
+}
+
+// Timeouts returns the default timeouts.
+func (dev *snapshotDevice) Timeouts() Timeouts {
+	return NewDefaultTimeouts()
+}
+
+// MarshalObject returns an object suitable for marshaling to JSON.
+func (dev *snapshotDevice) MarshalObject() any {
+	return dev.obj
+}
+
+// String returns a string representation of the device.
+func (dev *snapshotDevice) String() string {
+	b, err := json.Marshal(dev.MarshalObject())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// snapshotBackendService represents a service of a snapshot as a backend service.
+type snapshotBackendService struct {
+	uuid     UUID
+	primary  bool
+	included []UUID
+	chars    []*snapshotBackendCharacteristic
+}
+
+func newSnapshotBackendService(obj *snapshotServiceObject) (*snapshotBackendService, error) {
+	uuid, err := NewUUIDFromString(obj.UUID)
+	if err != nil {
+		return nil, err
+	}
+	service := &snapshotBackendService{
+		uuid:     uuid,
+		primary:  obj.Primary,
+		included: make([]UUID, 0, len(obj.IncludedServices)),
+		chars:    make([]*snapshotBackendCharacteristic, 0, len(obj.Characteristics)),
+	}
+	for _, included := range obj.IncludedServices {
+		uuid, err := NewUUIDFromString(included)
+		if err != nil {
+			return nil, err
+		}
+		service.included = append(service.included, uuid)
+	}
+	for _, charObj := range obj.Characteristics {
+		char, err := newSnapshotBackendCharacteristic(charObj)
+		if err != nil {
+			return nil, err
+		}
+		service.chars = append(service.chars, char)
+	}
+	return service, nil
+}
+
+// UUID returns the service UUID.
+func (s *snapshotBackendService) UUID() UUID {
+	return s.uuid
+}
+
+// IsPrimary returns whether the service is a primary service.
+func (s *snapshotBackendService) IsPrimary() bool {
+	return s.primary
+}
+
+// IncludedServiceUUIDs returns the UUIDs of the included services.
+func (s *snapshotBackendService) IncludedServiceUUIDs() []UUID {
+	return s.included
+}
+
+// DiscoverCharacteristics returns the specified characteristics of the snapshot. All characteristics are returned if no UUIDs are specified.
+func (s *snapshotBackendService) DiscoverCharacteristics(uuids []UUID) ([]BackendCharacteristic, error) {
+	chars := make([]BackendCharacteristic, 0, len(s.chars))
+	for _, char := range s.chars {
+		if len(uuids) == 0 || containsUUID(uuids, char.uuid) {
+			chars = append(chars, char)
+		}
+	}
+	return chars, nil
+}
+
+// snapshotBackendCharacteristic represents a characteristic of a snapshot as a backend characteristic.
+type snapshotBackendCharacteristic struct {
+	uuid  UUID
+	props CharacteristicProperties
+	value []byte
+	descs []*snapshotBackendDescriptor
+}
+
+func newSnapshotBackendCharacteristic(obj *snapshotCharacteristicObject) (*snapshotBackendCharacteristic, error) {
+	uuid, err := NewUUIDFromString(obj.UUID)
+	if err != nil {
+		return nil, err
+	}
+	value, err := decodeSnapshotValue(obj.Value)
+	if err != nil {
+		return nil, err
+	}
+	char := &snapshotBackendCharacteristic{
+		uuid:  uuid,
+		props: NewCharacteristicPropertiesFromNames(obj.Properties...),
+		value: value,
+		descs: make([]*snapshotBackendDescriptor, 0, len(obj.Descriptors)),
+	}
+	for _, descObj := range obj.Descriptors {
+		uuid, err := NewUUIDFromString(descObj.UUID)
+		if err != nil {
+			return nil, err
+		}
+		value, err := decodeSnapshotValue(descObj.Value)
+		if err != nil {
+			return nil, err
+		}
+		char.descs = append(char.descs, &snapshotBackendDescriptor{
+			uuid:  uuid,
+			value: value,
+		})
+	}
+	return char, nil
+}
+
+// UUID returns the characteristic UUID.
+func (char *snapshotBackendCharacteristic) UUID() UUID {
+	return char.uuid
+}
+
+// Properties returns the characteristic properties.
+func (char *snapshotBackendCharacteristic) Properties() CharacteristicProperties {
+	return char.props
+}
+
+// Read returns the captured value, or ErrNotSet if the snapshot has no value.
+func (char *snapshotBackendCharacteristic) Read() ([]byte, error) {
+	return readSnapshotValue(char.value)
+}
+
+// Write returns ErrNotPermitted since the snapshot is read-only.
+func (char *snapshotBackendCharacteristic) Write(data []byte) (int, error) {
+	return 0, fmt.Errorf("%w write: snapshot", ErrNotPermitted)
+}
+
+// WriteWithoutResponse returns ErrNotPermitted since the snapshot is read-only.
+func (char *snapshotBackendCharacteristic) WriteWithoutResponse(data []byte) (int, error) {
+	return 0, fmt.Errorf("%w write: snapshot", ErrNotPermitted)
+}
+
+// EnableNotifications returns ErrNotPermitted since the snapshot sends no notifications.
+func (char *snapshotBackendCharacteristic) EnableNotifications(callback func(buf []byte)) error {
+	if callback == nil {
+		return nil
+	}
+	return fmt.Errorf("%w notify: snapshot", ErrNotPermitted)
+}
+
+// EnableIndications returns ErrNotPermitted since the snapshot sends no indications.
+func (char *snapshotBackendCharacteristic) EnableIndications(callback func(buf []byte)) error {
+	if callback == nil {
+		return nil
+	}
+	return fmt.Errorf("%w indicate: snapshot", ErrNotPermitted)
+}
+
+// DiscoverDescriptors returns the specified descriptors of the snapshot. All descriptors are returned if no UUIDs are specified.
+func (char *snapshotBackendCharacteristic) DiscoverDescriptors(uuids []UUID) ([]BackendDescriptor, error) {
+	descs := make([]BackendDescriptor, 0, len(char.descs))
+	for _, desc := range char.descs {
+		if len(uuids) == 0 || containsUUID(uuids, desc.uuid) {
+			descs = append(descs, desc)
+		}
+	}
+	return descs, nil
+}
+
+// snapshotBackendDescriptor represents a descriptor of a snapshot as a backend descriptor.
+type snapshotBackendDescriptor struct {
+	uuid  UUID
+	value []byte
+}
+
+// UUID returns the descriptor UUID.
+func (desc *snapshotBackendDescriptor) UUID() UUID {
+	return desc.uuid
+}
+
+// Read returns the captured value, or ErrNotSet if the snapshot has no value.
+func (desc *snapshotBackendDescriptor) Read() ([]byte, error) {
+	return readSnapshotValue(desc.value)
+}
+
+// Write returns ErrNotPermitted since the snapshot is read-only.
+func (desc *snapshotBackendDescriptor) Write(data []byte) (int, error) {
+	return 0, fmt.Errorf("%w write: snapshot", ErrNotPermitted)
+}
+
+// decodeSnapshotValue decodes the hex value of the snapshot, or returns nil if the value is not captured.
+// A captured empty value is returned as a non-nil empty slice.
+func decodeSnapshotValue(s *string) ([]byte, error) {
+	if s == nil {
+		return nil, nil
+	}
+	b, err := hex.DecodeString(*s)
+	if err != nil {
+		return nil, fmt.Errorf("%w value: %s", ErrInvalid, *s)
+	}
+	if b == nil {
+		b = []byte{}
+	}
+	return b, nil
+}
+
+func readSnapshotValue(value []byte) ([]byte, error) {
+	if value == nil {
+		return nil, fmt.Errorf("value %w: snapshot", ErrNotSet)
+	}
+	b := make([]byte, len(value))
+	copy(b, value)
+	return b, nil
+}
+
+func containsUUID(uuids []UUID, uuid UUID) bool {
+	for _, u := range uuids {
+		if u.Equal(uuid) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cybergarage/go-ble/ble/db"
+)
+
+// SnapshotChangeType represents the type of a change between GATT snapshots.
+type SnapshotChangeType int
+
+const (
+	// SnapshotChangeAdded indicates that the service or characteristic was added.
+	SnapshotChangeAdded SnapshotChangeType = iota
+	// SnapshotChangeRemoved indicates that the service or characteristic was removed.
+	SnapshotChangeRemoved
+	// SnapshotChangeChanged indicates that the service or characteristic was changed.
+	SnapshotChangeChanged
+)
+
+// String returns the string representation of the change type.
+func (t SnapshotChangeType) String() string {
+	switch t {
+	case SnapshotChangeAdded:
+		return "added"
+	case SnapshotChangeRemoved:
+		return "removed"
+	case SnapshotChangeChanged:
+		return "changed"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(t))
+	}
+}
+
+// SnapshotChange represents a change of a service or a characteristic between GATT snapshots.
+type SnapshotChange struct {
+	// Type is the type of the change.
+	Type SnapshotChangeType
+	// Service is the UUID of the service.
+	Service UUID
+	// ServiceName is the name of the service resolved by the database.
+	ServiceName string
+	// Characteristic is the UUID of the characteristic, or the nil UUID if the change is of the service.
+	Characteristic UUID
+	// CharacteristicName is the name of the characteristic resolved by the database.
+	CharacteristicName string
+	// Details describes what changed, such as the properties, the descriptors or the value.
+	Details []string
+}
+
+// IsServiceChange returns whether the change is of the service rather than of a characteristic.
+func (change SnapshotChange) IsServiceChange() bool {
+	return change.Characteristic.IsNil()
+}
+
+// String returns a string representation of the change.
+func (change SnapshotChange) String() string {
+	target := fmt.Sprintf("service %s", attributeLabel(change.Service, change.ServiceName))
+	if !change.IsServiceChange() {
+		target += fmt.Sprintf(" characteristic %s", attributeLabel(change.Characteristic, change.CharacteristicName))
+	}
+	s := fmt.Sprintf("%s %s", change.Type, target)
+	if 0 < len(change.Details) {
+		s += ": " + strings.Join(change.Details, ", ")
+	}
+	return s
+}
+
+func attributeLabel(uuid UUID, name string) string {
+	if len(name) == 0 {
+		return uuid.String()
+	}
+	return fmt.Sprintf("%s (%s)", uuid.String(), name)
+}
+
+// diffServices returns the changes of the services and their characteristics from the services to the other services.
+func diffServices(from []Service, to []Service) []SnapshotChange {
+	changes := []SnapshotChange{}
+	for _, fromService := range from {
+		toService, ok := lookupServiceIn(to, fromService.UUID())
+		if !ok {
+			changes = append(changes, newServiceChange(SnapshotChangeRemoved, fromService.UUID(), nil))
+			continue
+		}
+		if details := diffServiceDetails(fromService, toService); 0 < len(details) {
+			changes = append(changes, newServiceChange(SnapshotChangeChanged, fromService.UUID(), details))
+		}
+		changes = append(changes, diffCharacteristics(fromService, toService)...)
+	}
+	for _, toService := range to {
+		if _, ok := lookupServiceIn(from, toService.UUID()); !ok {
+			changes = append(changes, newServiceChange(SnapshotChangeAdded, toService.UUID(), nil))
+		}
+	}
+	return changes
+}
+
+func diffServiceDetails(from Service, to Service) []string {
+	details := []string{}
+	if from.IsPrimary() != to.IsPrimary() {
+		details = append(details, fmt.Sprintf("primary: %t -> %t", from.IsPrimary(), to.IsPrimary()))
+	}
+	fromIncluded := serviceUUIDStrings(from.IncludedServices())
+	toIncluded := serviceUUIDStrings(to.IncludedServices())
+	if fromIncluded != toIncluded {
+		details = append(details, fmt.Sprintf("included services: [%s] -> [%s]", fromIncluded, toIncluded))
+	}
+	return details
+}
+
+func diffCharacteristics(from Service, to Service) []SnapshotChange {
+	changes := []SnapshotChange{}
+	serviceUUID := from.UUID()
+	for _, fromChar := range sortedCharacteristics(from) {
+		toChar, ok := to.LookupCharacteristic(fromChar.UUID())
+		if !ok {
+			changes = append(changes, newCharacteristicChange(SnapshotChangeRemoved, serviceUUID, fromChar.UUID(), nil))
+			continue
+		}
+		if details := diffCharacteristicDetails(fromChar, toChar); 0 < len(details) {
+			changes = append(changes, newCharacteristicChange(SnapshotChangeChanged, serviceUUID, fromChar.UUID(), details))
+		}
+	}
+	for _, toChar := range sortedCharacteristics(to) {
+		if _, ok := from.LookupCharacteristic(toChar.UUID()); !ok {
+			changes = append(changes, newCharacteristicChange(SnapshotChangeAdded, serviceUUID, toChar.UUID(), nil))
+		}
+	}
+	return changes
+}
+
+func diffCharacteristicDetails(from Characteristic, to Characteristic) []string {
+	details := []string{}
+	if from.Properties() != to.Properties() {
+		details = append(details, fmt.Sprintf("properties: [%s] -> [%s]", from.Properties(), to.Properties()))
+	}
+	fromDescs := descriptorUUIDStrings(from.Descriptors())
+	toDescs := descriptorUUIDStrings(to.Descriptors())
+	if fromDescs != toDescs {
+		details = append(details, fmt.Sprintf("descriptors: [%s] -> [%s]", fromDescs, toDescs))
+	}
+	// Values are compared only if both snapshots captured them.
+	fromValue, fromErr := from.Read()
+	toValue, toErr := to.Read()
+	if fromErr == nil && toErr == nil && !bytes.Equal(fromValue, toValue) {
+		details = append(details, fmt.Sprintf("value: %X -> %X", fromValue, toValue))
+	}
+	return details
+}
+
+func newServiceChange(changeType SnapshotChangeType, serviceUUID UUID, details []string) SnapshotChange {
+	dbService, _ := db.DefaultDatabase().LookupService(serviceUUID)
+	return SnapshotChange{
+		Type:               changeType,
+		Service:            serviceUUID,
+		ServiceName:        dbService.Name(),
+		Characteristic:     NewNilUUID(),
+		CharacteristicName: "",
+		Details:            details,
+	}
+}
+
+func newCharacteristicChange(changeType SnapshotChangeType, serviceUUID UUID, charUUID UUID, details []string) SnapshotChange {
+	change := newServiceChange(changeType, serviceUUID, details)
+	dbChar, _ := db.DefaultDatabase().LookupCharacteristic(charUUID)
+	change.Characteristic = charUUID
+	change.CharacteristicName = dbChar.Name()
+	return change
+}
+
+func lookupServiceIn(services []Service, uuid UUID) (Service, bool) {
+	for _, service := range services {
+		if uuid.Equal(service.UUID()) {
+			return service, true
+		}
+	}
+	return nil, false
+}
+
+func sortedCharacteristics(service Service) []Characteristic {
+	chars := service.Characteristics()
+	sort.Slice(chars, func(i, j int) bool {
+		return chars[i].UUID().String() < chars[j].UUID().String()
+	})
+	return chars
+}
+
+func serviceUUIDStrings(services []Service) string {
+	uuids := make([]string, 0, len(services))
+	for _, service := range services {
+		uuids = append(uuids, service.UUID().String())
+	}
+	sort.Strings(uuids)
+	return strings.Join(uuids, ", ")
+}
+
+func descriptorUUIDStrings(descs []Descriptor) string {
+	uuids := make([]string, 0, len(descs))
+	for _, desc := range descs {
+		uuids = append(uuids, desc.UUID().String())
+	}
+	sort.Strings(uuids)
+	return strings.Join(uuids, ", ")
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultSubscriptionChannelSize is the default number of notifications buffered in a subscription channel.
+const DefaultSubscriptionChannelSize = 16
+
+// NotificationMode represents how the peripheral sends characteristic values to a subscribed central.
+type NotificationMode int
+
+const (
+	// NotificationModeNotify receives notifications which the central does not acknowledge.
+	NotificationModeNotify NotificationMode = iota
+	// NotificationModeIndicate receives indications which the central confirms.
+	NotificationModeIndicate
+)
+
+// String returns a string representation of the notification mode.
+func (mode NotificationMode) String() string {
+	switch mode {
+	case NotificationModeNotify:
+		return "notify"
+	case NotificationModeIndicate:
+		return "indicate"
+	default:
+		return "unknown"
+	}
+}
+
+// Subscription represents a subscription to characteristic notifications or indications.
+type Subscription interface {
+	// Characteristic returns the subscribed characteristic.
+	Characteristic() Characteristic
+	// Mode returns the notification mode of the subscription.
+	Mode() NotificationMode
+	// Unsubscribe stops calling the callback of the subscription. The last subscription of the characteristic disables notifications.
+	Unsubscribe() error
+}
+
+// SubscribeOption represents an option for subscribing to a characteristic.
+type SubscribeOption func(*subscribeParams)
+
+type subscribeParams struct {
+	mode        NotificationMode
+	channelSize int
+}
+
+// WithSubscribeIndication subscribes to indications instead of notifications.
+func WithSubscribeIndication() SubscribeOption {
+	return func(params *subscribeParams) {
+		params.mode = NotificationModeIndicate
+	}
+}
+
+// WithSubscribeChannelSize sets the number of notifications buffered in the channel returned by SubscribeChannel.
+func WithSubscribeChannelSize(size int) SubscribeOption {
+	return func(params *subscribeParams) {
+		params.channelSize = size
+	}
+}
+
+func newSubscribeParams(opts ...SubscribeOption) *subscribeParams {
+	params := &subscribeParams{
+		mode:        NotificationModeNotify,
+		channelSize: DefaultSubscriptionChannelSize,
+	}
+	for _, opt := range opts {
+		opt(params)
+	}
+	if params.channelSize < 1 {
+		params.channelSize = 1
+	}
+	return params
+}
+
+type subscription struct {
+	sync.Mutex
+	char     *backendCharacteristic
+	mode     NotificationMode
+	callback OnCharacteristicNotification
+	once     sync.Once
+}
+
+func newSubscription(char *backendCharacteristic, mode NotificationMode, callback OnCharacteristicNotification) *subscription {
+	return &subscription{
+		Mutex:    sync.Mutex{},
+		char:     char,
+		mode:     mode,
+		callback: callback,
+		once:     sync.Once{},
+	}
+}
+
+// Characteristic returns the subscribed characteristic.
+func (sub *subscription) Characteristic() Characteristic {
+	return sub.char
+}
+
+// Mode returns the notification mode of the subscription.
+func (sub *subscription) Mode() NotificationMode {
+	return sub.mode
+}
+
+// Unsubscribe stops calling the callback of the subscription. The last subscription of the characteristic disables notifications.
+func (sub *subscription) Unsubscribe() error {
+	return sub.unsubscribe(context.Background())
+}
+
+func (sub *subscription) unsubscribe(ctx context.Context) error {
+	var err error
+	sub.once.Do(func() {
+		err = sub.char.unsubscribe(ctx, sub)
+	})
+	return err
+}
+
+func (sub *subscription) setCallback(callback OnCharacteristicNotification) {
+	sub.Lock()
+	defer sub.Unlock()
+	sub.callback = callback
+}
+
+func (sub *subscription) notify(buf []byte) {
+	sub.Lock()
+	callback := sub.callback
+	sub.Unlock()
+	callback(sub.char, buf)
+}
+
+// subscribeChannel subscribes to the characteristic and returns a channel which receives the notifications until the context is done.
+func subscribeChannel(ctx context.Context, char Characteristic, opts ...SubscribeOption) (<-chan []byte, error) {
+	params := newSubscribeParams(opts...)
+	ch := make(chan []byte, params.channelSize)
+	var mutex sync.Mutex
+	closed := false
+	callback := func(char Characteristic, buf []byte) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case ch <- buf:
+		default:
+			// The notification is dropped so that a slow reader does not stall the other subscribers.
+		}
+	}
+	sub, err := char.Subscribe(callback, opts...)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		sub.Unsubscribe()
+		mutex.Lock()
+		closed = true
+		close(ch)
+		mutex.Unlock()
+	}()
+	return ch, nil
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultConnectTimeout is the default timeout for connecting to a device.
+	DefaultConnectTimeout = 10 * time.Second
+	// DefaultOperationTimeout is the default timeout for GATT operations and disconnection.
+	DefaultOperationTimeout = 5 * time.Second
+)
+
+// Timeouts represents the timeouts applied to operations whose context has no deadline. A zero timeout waits without limit.
+type Timeouts struct {
+	// Connect is the timeout for connecting to a device.
+	Connect time.Duration
+	// Operation is the timeout for GATT operations such as service discovery, reads, writes and subscriptions, and for disconnection.
+	Operation time.Duration
+	// Transport is the timeout for reading from a transport.
+	Transport time.Duration
+}
+
+// NewDefaultTimeouts returns the default timeouts.
+func NewDefaultTimeouts() Timeouts {
+	return Timeouts{
+		Connect:   DefaultConnectTimeout,
+		Operation: DefaultOperationTimeout,
+		Transport: DefaultTransportTimeout,
+	}
+}
+
+// deviceTimeouts returns the timeouts of the device, or the default timeouts if the device is not set.
+func deviceTimeouts(dev Device) Timeouts {
+	if dev == nil {
+		return NewDefaultTimeouts()
+	}
+	return dev.Timeouts()
+}
+
+// contextWithTimeout returns the context with the timeout if the context has no deadline and the timeout is positive.
+func contextWithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// doContext calls the blocking backend function and returns early with the context error if the context is done first.
+// The backend function keeps running in the background and its result is discarded, so it is used only for functions which change no state tracked by the caller.
+func doContext[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	if err := ctx.Err(); err != nil {
+		var zero T
+		return zero, err
+	}
+	type result struct {
+		v   T
+		err error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		v, err := fn()
+		resCh <- result{v: v, err: err}
+	}()
+	select {
+	case res := <-resCh:
+		return res.v, res.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// doStateContext calls the blocking backend function which changes state and returns early with the context error if the context is done first.
+// The backend function keeps running in the background, and the late function is called with its result when it returns so that the caller can undo or apply the state change.
+// The returned channel is closed after the late function returns, or is nil if the backend function returned in time.
+func doStateContext[T any](ctx context.Context, fn func() (T, error), late func(T, error)) (T, <-chan struct{}, error) {
+	if err := ctx.Err(); err != nil {
+		var zero T
+		return zero, nil, err
+	}
+	type result struct {
+		v   T
+		err error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		v, err := fn()
+		resCh <- result{v: v, err: err}
+	}()
+	select {
+	case res := <-resCh:
+		return res.v, nil, res.err
+	case <-ctx.Done():
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			res := <-resCh
+			late(res.v, res.err)
+		}()
+		var zero T
+		return zero, done, ctx.Err()
+	}
+}
+
+// pendingCall tracks the backend call which changes state and was left running when its context ended, so that the next call waits for it.
+type pendingCall struct {
+	mutex sync.Mutex
+	done  <-chan struct{}
+}
+
+// set records the running backend call whose late result is handled when the done channel is closed. A nil channel is ignored.
+func (p *pendingCall) set(done <-chan struct{}) {
+	if done == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.done = done
+}
+
+// isPending returns whether a backend call is still running.
+func (p *pendingCall) isPending() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.done == nil {
+		return false
+	}
+	select {
+	case <-p.done:
+		p.done = nil
+		return false
+	default:
+		return true
+	}
+}
+
+// wait waits until the running backend call returns and its late result is handled, or returns the context error if the context is done first.
+func (p *pendingCall) wait(ctx context.Context) error {
+	p.mutex.Lock()
+	done := p.done
+	p.mutex.Unlock()
+	if done == nil {
+		return nil
+	}
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.done == done {
+		p.done = nil
+	}
+	return nil
+}
@@ -15,16 +15,30 @@
 package ble
 
 import (
-	"container/list"
 	"context"
-	"fmt"
+	"io"
 	"sync"
 	"time"
 )
 
 const (
-	// DefaultTransportTimeout is the default timeout for transport operations.
+	// DefaultTransportTimeout is the default timeout for reading from a transport.
 	DefaultTransportTimeout = 5 * time.Second
+	// DefaultTransportQueueSize is the default number of notifications queued for reading.
+	DefaultTransportQueueSize = 64
+)
+
+// TransportOverflowPolicy represents the behavior when a notification arrives while the queue is full.
+type TransportOverflowPolicy int
+
+const (
+	// TransportOverflowBlock blocks the notification until the queue has space or the transport is closed.
+	// Since it blocks the delivery of the backend, it also stalls the other subscribers of the notify characteristic.
+	TransportOverflowBlock TransportOverflowPolicy = iota
+	// TransportOverflowDropOldest drops the oldest queued notification to make space.
+	TransportOverflowDropOldest
+	// TransportOverflowError drops the notification and the next Read returns ErrOverflow. It is the default policy.
+	TransportOverflowError
 )
 
 // TransportOption represents a function type to set transport options.
@@ -32,9 +46,9 @@ type TransportOption func(*transport)
 
 // Transport represents the BLE transport layer.
 type Transport interface {
-	// Open opens the transport for communication.
+	// Open opens the transport for communication. Opening the open transport does nothing.
 	Open() error
-	// Close closes the transport and releases resources.
+	// Close disables notifications, closes the transport and releases queued data.
 	Close() error
 	// WriterCharacteristic returns the characteristic used for writing data.
 	WriteCharacteristic() (Characteristic, error)
@@ -44,18 +58,28 @@ type Transport interface {
 	NotifyCharacteristic() (Characteristic, error)
 	// Read reads bytes from the transport.
 	Read(ctx context.Context) ([]byte, error)
-	// Write writes the specified bytes to the transport.
+	// Write writes the specified bytes to the transport, split into writes of the ATT payload size of the connection.
 	Write(ctx context.Context, data []byte) (int, error)
-	// WriteWithoutResponse writes the specified bytes to the transport without waiting for a response.
+	// WriteWithoutResponse writes the specified bytes to the transport without waiting for a response, split into writes of the ATT payload size of the connection.
 	WriteWithoutResponse(ctx context.Context, data []byte) (int, error)
 }
 
 type transport struct {
 	sync.Mutex
-	notifyBytes *list.List
-	readCh      Characteristic
-	writeCh     Characteristic
-	notifyCh    Characteristic
+	queue          [][]byte
+	queueSize      int
+	timeout        time.Duration
+	overflowPolicy TransportOverflowPolicy
+	overflowed     bool
+	readSignal     chan struct{}
+	writeSignal    chan struct{}
+	done           chan struct{}
+	closeOnce      sync.Once
+	readCh         Characteristic
+	writeCh        Characteristic
+	notifyCh       Characteristic
+	notifyMutex    sync.Mutex
+	notifySub      Subscription
 }
 
 // WithTransportReadCharacteristic sets the characteristic used for reading data.
@@ -79,41 +103,173 @@ func WithTransportNotifyCharacteristic(char Characteristic) TransportOption {
 	}
 }
 
+// WithTransportTimeout sets the timeout for reading from the transport if the context has no deadline. A zero timeout waits without limit.
+func WithTransportTimeout(timeout time.Duration) TransportOption {
+	return func(t *transport) {
+		t.timeout = timeout
+	}
+}
+
+// WithTransportQueueSize sets the number of notifications queued for reading.
+func WithTransportQueueSize(size int) TransportOption {
+	return func(t *transport) {
+		t.queueSize = size
+	}
+}
+
+// WithTransportOverflowPolicy sets the behavior when a notification arrives while the queue is full.
+func WithTransportOverflowPolicy(policy TransportOverflowPolicy) TransportOption {
+	return func(t *transport) {
+		t.overflowPolicy = policy
+	}
+}
+
 // NewTransport returns a new Transport instance.
 func NewTransport(opts ...TransportOption) Transport {
+	return newTransport(opts...)
+}
+
+func newTransport(opts ...TransportOption) *transport {
 	t := &transport{
-		Mutex:       sync.Mutex{},
-		notifyBytes: list.New(),
-		readCh:      nil,
-		writeCh:     nil,
-		notifyCh:    nil,
+		Mutex:          sync.Mutex{},
+		queue:          [][]byte{},
+		queueSize:      DefaultTransportQueueSize,
+		timeout:        DefaultTransportTimeout,
+		overflowPolicy: TransportOverflowError,
+		overflowed:     false,
+		readSignal:     make(chan struct{}, 1),
+		writeSignal:    make(chan struct{}, 1),
+		done:           make(chan struct{}),
+		closeOnce:      sync.Once{},
+		readCh:         nil,
+		writeCh:        nil,
+		notifyCh:       nil,
+		notifyMutex:    sync.Mutex{},
+		notifySub:      nil,
 	}
 	for _, opt := range opts {
 		opt(t)
 	}
+	if t.queueSize < 1 {
+		t.queueSize = 1
+	}
 	return t
 }
 
-// Open opens the transport for communication.
+// Open opens the transport for communication. Opening the open transport does nothing.
 func (t *transport) Open() error {
-	if t.notifyCh != nil {
+	return t.openContext(context.Background())
+}
+
+// openContext subscribes to the notify characteristic with the context unless it is subscribed already.
+func (t *transport) openContext(ctx context.Context) error {
+	t.notifyMutex.Lock()
+	defer t.notifyMutex.Unlock()
+	if t.notifyCh != nil && t.notifySub == nil {
 		notifyHandler := func(char Characteristic, buf []byte) {
 			data := make([]byte, len(buf))
 			copy(data, buf)
-			t.Lock()
-			t.notifyBytes.PushBack(data)
-			t.Unlock()
+			t.enqueue(data)
 		}
-		if err := t.notifyCh.Notify(notifyHandler); err != nil {
+		sub, err := t.notifyCh.SubscribeContext(ctx, notifyHandler)
+		if err != nil {
 			return err
 		}
+		t.notifySub = sub
 	}
 	return nil
 }
 
-// Close closes the transport and releases resources.
+// Close disables notifications, closes the transport and releases queued data.
 func (t *transport) Close() error {
-	return nil
+	var err error
+	t.closeOnce.Do(func() {
+		// The notification blocked on the full queue is released before unsubscribing waits for the delivery.
+		close(t.done)
+		t.notifyMutex.Lock()
+		if t.notifySub != nil {
+			err = t.notifySub.Unsubscribe()
+		}
+		t.notifyMutex.Unlock()
+		t.Lock()
+		t.queue = nil
+		t.overflowed = false
+		t.Unlock()
+	})
+	return err
+}
+
+// enqueue queues the notification according to the overflow policy.
+func (t *transport) enqueue(data []byte) {
+	t.Lock()
+	if t.isClosed() {
+		t.Unlock()
+		return
+	}
+	for t.queueSize <= len(t.queue) {
+		switch t.overflowPolicy {
+		case TransportOverflowDropOldest:
+			t.queue[0] = nil
+			t.queue = t.queue[1:]
+		case TransportOverflowError:
+			t.overflowed = true
+			t.Unlock()
+			return
+		default:
+			t.Unlock()
+			select {
+			case <-t.writeSignal:
+			case <-t.done:
+				return
+			}
+			t.Lock()
+			if t.isClosed() {
+				t.Unlock()
+				return
+			}
+		}
+	}
+	t.queue = append(t.queue, data)
+	t.Unlock()
+	signalChannel(t.readSignal)
+}
+
+// dequeue returns the oldest queued notification if any.
+func (t *transport) dequeue() ([]byte, bool, error) {
+	t.Lock()
+	defer t.Unlock()
+	if t.isClosed() {
+		return nil, false, ErrClosed
+	}
+	if t.overflowed {
+		t.overflowed = false
+		return nil, false, ErrOverflow
+	}
+	if len(t.queue) == 0 {
+		return nil, false, nil
+	}
+	data := t.queue[0]
+	t.queue[0] = nil
+	t.queue = t.queue[1:]
+	signalChannel(t.writeSignal)
+	return data, true, nil
+}
+
+// isClosed returns whether the transport is closed.
+func (t *transport) isClosed() bool {
+	select {
+	case <-t.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func signalChannel(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
 }
 
 // WriteCharacteristic returns the characteristic used for writing data.
@@ -142,53 +298,107 @@ func (t *transport) NotifyCharacteristic() (Characteristic, error) {
 
 // Read reads bytes from the transport.
 func (t *transport) Read(ctx context.Context) ([]byte, error) {
-	if _, ok := ctx.Deadline(); !ok {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, DefaultTransportTimeout)
-		defer cancel()
-	}
+	ctx, cancel := contextWithTimeout(ctx, t.timeout)
+	defer cancel()
 
 	switch {
 	case t.notifyCh != nil:
 		for {
+			data, ok, err := t.dequeue()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return data, nil
+			}
 			select {
+			case <-t.readSignal:
+			case <-t.done:
+				return nil, ErrClosed
 			case <-ctx.Done():
 				return nil, ctx.Err()
-			default:
-				t.Lock()
-				if 0 < t.notifyBytes.Len() {
-					elem := t.notifyBytes.Front()
-					t.notifyBytes.Remove(elem)
-					t.Unlock()
-					b, ok := elem.Value.([]byte)
-					if !ok {
-						return nil, fmt.Errorf("%w type: %T", ErrInvalid, elem.Value)
-					}
-					return b, nil
-				}
-				t.Unlock()
 			}
-			time.Sleep(100 * time.Millisecond)
 		}
 	case t.readCh != nil:
-		return t.readCh.Read()
+		if t.isClosed() {
+			return nil, ErrClosed
+		}
+		return t.readCh.ReadContext(ctx)
 	}
 
 	return nil, ErrNotSet
 }
 
-// Write writes the specified bytes to the transport.
+// Write writes the specified bytes to the transport, split into writes of the ATT payload size of the connection.
 func (t *transport) Write(ctx context.Context, data []byte) (int, error) {
-	if t.writeCh == nil {
-		return 0, ErrNotSet
-	}
-	return t.writeCh.Write(data)
+	return t.write(ctx, data, false)
 }
 
-// WriteWithoutResponse writes the specified bytes to the transport without waiting for a response.
+// WriteWithoutResponse writes the specified bytes to the transport without waiting for a response, split into writes of the ATT payload size of the connection.
 func (t *transport) WriteWithoutResponse(ctx context.Context, data []byte) (int, error) {
+	return t.write(ctx, data, true)
+}
+
+func (t *transport) write(ctx context.Context, data []byte, withoutResponse bool) (int, error) {
 	if t.writeCh == nil {
 		return 0, ErrNotSet
 	}
-	return t.writeCh.WriteWithoutResponse(data)
+	chunkSize := DefaultATTMTU
+	if service := t.writeCh.Service(); service != nil && service.Device() != nil {
+		chunkSize = service.Device().MTU()
+	}
+	chunkSize = attPayloadSize(chunkSize)
+	written := 0
+	for {
+		if t.isClosed() {
+			return written, ErrClosed
+		}
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+		end := min(written+chunkSize, len(data))
+		var n int
+		var err error
+		if withoutResponse {
+			n, err = t.writeCh.WriteWithoutResponseContext(ctx, data[written:end])
+		} else {
+			n, err = t.writeCh.WriteContext(ctx, data[written:end])
+		}
+		written += n
+		if err != nil || len(data) <= written {
+			return written, err
+		}
+		if n == 0 {
+			return written, io.ErrShortWrite
+		}
+	}
+}
+
+// writeTransportChunks writes the data to the transport in chunks of the specified size, or in a single write if the size is not positive.
+func writeTransportChunks(ctx context.Context, t Transport, data []byte, size int, withoutResponse bool) (int, error) {
+	if size <= 0 {
+		size = max(len(data), 1)
+	}
+	written := 0
+	for written < len(data) {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+		end := min(written+size, len(data))
+		var n int
+		var err error
+		if withoutResponse {
+			n, err = t.WriteWithoutResponse(ctx, data[written:end])
+		} else {
+			n, err = t.Write(ctx, data[written:end])
+		}
+		written += n
+		if err != nil {
+			return written, err
+		}
+		if n == 0 {
+			return written, io.ErrShortWrite
+		}
+	}
+	return written, nil
 }
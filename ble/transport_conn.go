@@ -0,0 +1,287 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// TransportConnNetwork is the network name returned by the addresses of a transport connection.
+	TransportConnNetwork = "ble"
+)
+
+// TransportConn represents a byte stream over a transport, usable as an io.ReadWriteCloser or a net.Conn.
+type TransportConn interface {
+	net.Conn
+	// Transport returns the underlying transport.
+	Transport() Transport
+}
+
+// TransportConnOption represents a function type to set transport connection options.
+type TransportConnOption func(*transportConn)
+
+// WithTransportConnWriteSize sets the maximum number of bytes written per transport write.
+// By default, the transport splits writes according to the ATT MTU of the connection.
+func WithTransportConnWriteSize(size int) TransportConnOption {
+	return func(c *transportConn) {
+		c.writeSize = size
+	}
+}
+
+// WithTransportConnWriteWithoutResponse writes the stream using write without response.
+func WithTransportConnWriteWithoutResponse() TransportConnOption {
+	return func(c *transportConn) {
+		c.withoutResponse = true
+	}
+}
+
+type transportConn struct {
+	sync.Mutex
+	transport       Transport
+	writeSize       int
+	withoutResponse bool
+	readMutex       sync.Mutex
+	readBuf         []byte
+	writeMutex      sync.Mutex
+	readDeadline    time.Time
+	writeDeadline   time.Time
+	deadlineChanged chan struct{}
+	done            chan struct{}
+	closeOnce       sync.Once
+}
+
+// NewTransportConn returns a new byte stream connection over the specified transport.
+// Notifications are read as a continuous stream, and writes are split into chunks of the write size if it is set.
+func NewTransportConn(transport Transport, opts ...TransportConnOption) TransportConn {
+	c := &transportConn{
+		Mutex:           sync.Mutex{},
+		transport:       transport,
+		writeSize:       0,
+		withoutResponse: false,
+		readMutex:       sync.Mutex{},
+		readBuf:         nil,
+		writeMutex:      sync.Mutex{},
+		readDeadline:    time.Time{},
+		writeDeadline:   time.Time{},
+		deadlineChanged: make(chan struct{}),
+		done:            make(chan struct{}),
+		closeOnce:       sync.Once{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Transport returns the underlying transport.
+func (c *transportConn) Transport() Transport {
+	return c.transport
+}
+
+// Read reads up to len(p) bytes from the notification stream.
+// It returns io.EOF once the underlying transport is closed.
+func (c *transportConn) Read(p []byte) (int, error) {
+	c.readMutex.Lock()
+	defer c.readMutex.Unlock()
+	for {
+		if c.isClosed() {
+			return 0, net.ErrClosed
+		}
+		if len(p) == 0 {
+			return 0, nil
+		}
+		if 0 < len(c.readBuf) {
+			n := copy(p, c.readBuf)
+			c.readBuf = c.readBuf[n:]
+			return n, nil
+		}
+
+		c.Lock()
+		deadline := c.readDeadline
+		deadlineChanged := c.deadlineChanged
+		c.Unlock()
+
+		ctx, cancel := contextWithDeadline(deadline)
+		go func() {
+			select {
+			case <-deadlineChanged:
+			case <-c.done:
+			case <-ctx.Done():
+			}
+			cancel()
+		}()
+		data, err := c.transport.Read(ctx)
+		cancel()
+
+		switch {
+		case err == nil:
+			c.readBuf = data
+		case errors.Is(err, ErrClosed):
+			if c.isClosed() {
+				return 0, net.ErrClosed
+			}
+			return 0, io.EOF
+		case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+			// The read is retried unless the read deadline has passed; the transport applies its own timeout when no deadline is set, and a deadline change cancels the pending read.
+			if isDeadlineExceeded(deadline) {
+				return 0, os.ErrDeadlineExceeded
+			}
+		default:
+			return 0, err
+		}
+	}
+}
+
+// Write writes p to the write characteristic in chunks of the write size.
+func (c *transportConn) Write(p []byte) (int, error) {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+
+	c.Lock()
+	deadline := c.writeDeadline
+	c.Unlock()
+
+	ctx, cancel := contextWithDeadline(deadline)
+	defer cancel()
+
+	if c.isClosed() {
+		return 0, net.ErrClosed
+	}
+	n, err := writeTransportChunks(ctx, c.transport, p, c.writeSize, c.withoutResponse)
+	switch {
+	case err == nil:
+		return n, nil
+	case errors.Is(err, context.DeadlineExceeded):
+		return n, os.ErrDeadlineExceeded
+	case errors.Is(err, ErrClosed):
+		return n, net.ErrClosed
+	}
+	return n, err
+}
+
+// Close closes the connection and the underlying transport, and releases buffered data.
+func (c *transportConn) Close() error {
+	err := net.ErrClosed
+	c.closeOnce.Do(func() {
+		close(c.done)
+		err = c.transport.Close()
+		c.readMutex.Lock()
+		c.readBuf = nil
+		c.readMutex.Unlock()
+	})
+	return err
+}
+
+// LocalAddr returns the local network address, which is not known for BLE links.
+func (c *transportConn) LocalAddr() net.Addr {
+	return newTransportConnAddr("")
+}
+
+// RemoteAddr returns the address of the peer device.
+func (c *transportConn) RemoteAddr() net.Addr {
+	chars := []func() (Characteristic, error){
+		c.transport.NotifyCharacteristic,
+		c.transport.WriteCharacteristic,
+		c.transport.ReadCharacteristic,
+	}
+	for _, char := range chars {
+		ch, err := char()
+		if err != nil || ch.Service() == nil || ch.Service().Device() == nil {
+			continue
+		}
+		return newTransportConnAddr(ch.Service().Device().Address().String())
+	}
+	return newTransportConnAddr("")
+}
+
+// SetDeadline sets the read and write deadlines.
+func (c *transportConn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.SetWriteDeadline(t)
+}
+
+// SetReadDeadline sets the read deadline, which also applies to a pending Read. A zero value disables the deadline.
+func (c *transportConn) SetReadDeadline(t time.Time) error {
+	if c.isClosed() {
+		return net.ErrClosed
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.readDeadline = t
+	close(c.deadlineChanged)
+	c.deadlineChanged = make(chan struct{})
+	return nil
+}
+
+// SetWriteDeadline sets the write deadline. A zero value disables the deadline.
+func (c *transportConn) SetWriteDeadline(t time.Time) error {
+	if c.isClosed() {
+		return net.ErrClosed
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.writeDeadline = t
+	return nil
+}
+
+// isClosed returns whether the connection is closed.
+func (c *transportConn) isClosed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// contextWithDeadline returns a cancelable context with the deadline if it is set.
+func contextWithDeadline(deadline time.Time) (context.Context, context.CancelFunc) {
+	if deadline.IsZero() {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithDeadline(context.Background(), deadline)
+}
+
+// isDeadlineExceeded returns whether the deadline is set and has passed.
+func isDeadlineExceeded(deadline time.Time) bool {
+	return !deadline.IsZero() && !time.Now().Before(deadline)
+}
+
+type transportConnAddr struct {
+	addr string
+}
+
+func newTransportConnAddr(addr string) net.Addr {
+	return &transportConnAddr{addr: addr}
+}
+
+// Network returns the network name.
+func (addr *transportConnAddr) Network() string {
+	return TransportConnNetwork
+}
+
+// String returns the device address.
+func (addr *transportConnAddr) String() string {
+	return addr.addr
+}
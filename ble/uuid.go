@@ -41,6 +41,11 @@ func NewUUIDFromUUID16(u uint16) UUID {
 	return types.NewUUIDFromUUID16(u)
 }
 
+// NewUUIDFromUUID32 creates a new UUID from the given 32-bit UUID.
+func NewUUIDFromUUID32(u uint32) UUID {
+	return types.NewUUIDFromUUID32(u)
+}
+
 // NewUUIDFrom creates a UUID from various types.
 func NewUUIDFrom(v any) (UUID, error) {
 	return types.NewUUIDFrom(v)
@@ -0,0 +1,58 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"tinygo.org/x/bluetooth"
+)
+
+func newUUIDFromTiny(tinyUUID bluetooth.UUID) UUID {
+	switch {
+	case tinyUUID.Is16Bit():
+		return NewUUIDFromUUID16(tinyUUID.Get16Bit())
+	case tinyUUID.Is32Bit():
+		return NewUUIDFromUUID32(tinyUUID.Get32Bit())
+	}
+	uuid, err := NewUUIDFromString(tinyUUID.String())
+	if err != nil {
+		return NewNilUUID()
+	}
+	return uuid
+}
+
+func uuidToTiny(uuid UUID) bluetooth.UUID {
+	if u16, ok := uuid.UUID16(); ok {
+		return bluetooth.New16BitUUID(u16)
+	}
+	if u32, ok := uuid.UUID32(); ok {
+		return bluetooth.New32BitUUID(u32)
+	}
+	tinyUUID, err := bluetooth.ParseUUID(uuid.String())
+	if err != nil {
+		return bluetooth.UUID{}
+	}
+	return tinyUUID
+}
+
+func uuidsToTiny(uuids []UUID) []bluetooth.UUID {
+	if len(uuids) == 0 {
+		return nil
+	}
+	tinyUUIDs := make([]bluetooth.UUID, 0, len(uuids))
+	for _, uuid := range uuids {
+		tinyUUIDs = append(tinyUUIDs, uuidToTiny(uuid))
+	}
+	return tinyUUIDs
+}
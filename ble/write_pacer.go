@@ -0,0 +1,209 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ble
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultWritePacingAckTimeout is the default time to wait for an acknowledgement in the acknowledgement-paced mode.
+	DefaultWritePacingAckTimeout = 5 * time.Second
+)
+
+// WritePacing represents the flow control of writes without response on a connection.
+// The zero value sends writes as fast as the backend accepts them.
+type WritePacing struct {
+	// Gap is the minimum interval between consecutive writes.
+	Gap time.Duration
+	// Burst is the number of writes which can be sent back-to-back, or zero to use Credits.
+	Burst int
+	// Credits is the number of writes allowed per Interval, or zero to use Burst.
+	Credits int
+	// Interval is the connection interval over which the credits are replenished, or zero to disable credit pacing.
+	Interval time.Duration
+	// AckWindow is the number of writes which can be outstanding until they are acknowledged with WritePacer.Ack, or zero to disable acknowledgement pacing.
+	AckWindow int
+	// AckTimeout is the time to wait for an acknowledgement, or zero to use DefaultWritePacingAckTimeout.
+	AckTimeout time.Duration
+}
+
+// WritePacer represents a paced write queue which serializes and throttles writes without response on a connection.
+type WritePacer interface {
+	// Pacing returns the pacing of the write queue.
+	Pacing() WritePacing
+	// Wait blocks until the next write is allowed by the pacing, and accounts it as sent.
+	Wait(ctx context.Context) error
+	// Ack acknowledges the specified number of outstanding writes in the acknowledgement-paced mode.
+	Ack(n int)
+	// Outstanding returns the number of writes waiting for an acknowledgement.
+	Outstanding() int
+}
+
+type writePacer struct {
+	sync.Mutex
+	queue       chan struct{}
+	pacing      WritePacing
+	tokens      float64
+	refilledAt  time.Time
+	lastWriteAt time.Time
+	outstanding int
+	ackSignal   chan struct{}
+}
+
+// NewWritePacer returns a new paced write queue with the specified pacing.
+func NewWritePacer(pacing WritePacing) WritePacer {
+	p := &writePacer{
+		Mutex:       sync.Mutex{},
+		queue:       make(chan struct{}, 1),
+		pacing:      pacing,
+		tokens:      0,
+		refilledAt:  time.Now(),
+		lastWriteAt: time.Time{},
+		outstanding: 0,
+		ackSignal:   make(chan struct{}, 1),
+	}
+	p.tokens = float64(p.capacity())
+	return p
+}
+
+// Pacing returns the pacing of the write queue.
+func (p *writePacer) Pacing() WritePacing {
+	return p.pacing
+}
+
+// Wait blocks until the next write is allowed by the pacing, and accounts it as sent.
+// Waiting writers are released one at a time in no particular order, and a writer stops waiting when its context is done.
+func (p *writePacer) Wait(ctx context.Context) error {
+	select {
+	case p.queue <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-p.queue }()
+
+	if err := p.waitAck(ctx); err != nil {
+		return err
+	}
+	if err := p.waitCredit(ctx); err != nil {
+		return err
+	}
+	if 0 < p.pacing.Gap && !p.lastWriteAt.IsZero() {
+		if err := sleepContext(ctx, time.Until(p.lastWriteAt.Add(p.pacing.Gap))); err != nil {
+			return err
+		}
+	}
+
+	p.Lock()
+	p.lastWriteAt = time.Now()
+	if 0 < p.pacing.AckWindow {
+		p.outstanding++
+	}
+	p.Unlock()
+	return nil
+}
+
+func (p *writePacer) waitAck(ctx context.Context) error {
+	if p.pacing.AckWindow <= 0 {
+		return nil
+	}
+	timeout := p.pacing.AckTimeout
+	if timeout <= 0 {
+		timeout = DefaultWritePacingAckTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		p.Lock()
+		outstanding := p.outstanding
+		p.Unlock()
+		if outstanding < p.pacing.AckWindow {
+			return nil
+		}
+		select {
+		case <-p.ackSignal:
+		case <-timer.C:
+			return fmt.Errorf("%w write ack: %d writes unacknowledged", ErrTimeout, outstanding)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (p *writePacer) waitCredit(ctx context.Context) error {
+	capacity := p.capacity()
+	if p.pacing.Interval <= 0 || capacity <= 0 {
+		return nil
+	}
+	credits := p.pacing.Credits
+	if credits <= 0 {
+		credits = capacity
+	}
+	rate := float64(credits) / float64(p.pacing.Interval)
+	for {
+		now := time.Now()
+		p.tokens = min(float64(capacity), p.tokens+float64(now.Sub(p.refilledAt))*rate)
+		p.refilledAt = now
+		if 1 <= p.tokens {
+			p.tokens--
+			return nil
+		}
+		wait := time.Duration((1 - p.tokens) / rate)
+		if err := sleepContext(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// capacity returns the number of writes which can be sent back-to-back.
+func (p *writePacer) capacity() int {
+	if 0 < p.pacing.Burst {
+		return p.pacing.Burst
+	}
+	return p.pacing.Credits
+}
+
+// Ack acknowledges the specified number of outstanding writes in the acknowledgement-paced mode.
+func (p *writePacer) Ack(n int) {
+	p.Lock()
+	p.outstanding = max(0, p.outstanding-n)
+	p.Unlock()
+	signalChannel(p.ackSignal)
+}
+
+// Outstanding returns the number of writes waiting for an acknowledgement.
+func (p *writePacer) Outstanding() int {
+	p.Lock()
+	defer p.Unlock()
+	return p.outstanding
+}
+
+// sleepContext sleeps for the duration or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestAdvertisementParser(t *testing.T) {
+	raw, _ := hex.DecodeString(
+		"020106" + // Flags
+			"05020F180A18" + // Incomplete 16-bit service UUIDs
+			"1107119D9F429C4F9F9559453D26F52EEE18" + // Complete 128-bit service UUIDs
+			"03140D18" + // 16-bit solicitation UUIDs
+			"0308676F" + "0709676F2D626C65" + // Shortened and complete local names
+			"020AFC" + // TX power level
+			"0319C103" + // Appearance
+			"092417657861" + "6D706C65" + // URI
+			"021C02" + // LE role
+			"031AA000" + // Advertising interval
+			"051206000C00" + // Peripheral connection interval range
+			"0616F6FF00E40F" + // Service data
+			"04FFFFFF01" + "04FF0100AB" + // Manufacturer data
+			"03F0AABB" + // Unknown
+			"0000") // Padding
+	adv, err := ble.ParseAdvertisement(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if flags, ok := adv.Flags(); !ok || !flags.Has(ble.ADFlagLEGeneralDiscoverable|ble.ADFlagBREDRNotSupported) {
+		t.Errorf("unexpected flags: %v", flags)
+	}
+	if adv.LocalName() != "go-ble" || adv.IsShortenedLocalName() {
+		t.Errorf("unexpected local name: %s", adv.LocalName())
+	}
+	expectedUUIDs := []ble.UUID{
+		ble.NewUUIDFromUUID16(0x180F),
+		ble.NewUUIDFromUUID16(0x180A),
+		ble.MustUUIDFromString("18EE2EF5-263D-4559-959F-4F9C429F9D11"),
+	}
+	if len(adv.ServiceUUIDs()) != len(expectedUUIDs) {
+		t.Fatalf("expected %d service UUIDs, got %d", len(expectedUUIDs), len(adv.ServiceUUIDs()))
+	}
+	for n, uuid := range adv.ServiceUUIDs() {
+		if !uuid.Equal(expectedUUIDs[n]) {
+			t.Errorf("expected service UUID %s, got %s", expectedUUIDs[n], uuid)
+		}
+	}
+	if uuids := adv.SolicitationUUIDs(); len(uuids) != 1 || !uuids[0].Equal(ble.NewUUIDFromUUID16(0x180D)) {
+		t.Errorf("unexpected solicitation UUIDs: %v", uuids)
+	}
+	if txPower, ok := adv.TxPower(); !ok || txPower != -4 {
+		t.Errorf("unexpected TX power: %d", txPower)
+	}
+	if appearance, ok := adv.Appearance(); !ok || appearance != 0x03C1 {
+		t.Errorf("unexpected appearance: 0x%04X", appearance)
+	}
+	if uri, ok := adv.URI(); !ok || uri != "https:example" {
+		t.Errorf("unexpected URI: %s", uri)
+	}
+	if role, ok := adv.LERole(); !ok || role != ble.LERolePeripheralPreferred {
+		t.Errorf("unexpected LE role: %s", role)
+	}
+	if interval, ok := adv.AdvertisingInterval(); !ok || interval != 100*time.Millisecond {
+		t.Errorf("unexpected advertising interval: %s", interval)
+	}
+	if minInterval, maxInterval, ok := adv.ConnectionIntervalRange(); !ok || minInterval != 7500*time.Microsecond || maxInterval != 15*time.Millisecond {
+		t.Errorf("unexpected connection interval range: %s - %s", minInterval, maxInterval)
+	}
+	if sds := adv.ServiceData(); len(sds) != 1 || !sds[0].UUID().Equal(ble.NewUUIDFromUUID16(0xFFF6)) {
+		t.Errorf("unexpected service data: %v", sds)
+	}
+	if mds := adv.ManufacturerData(); len(mds) != 2 || mds[0].ID() != 0xFFFF || mds[1].ID() != 0x0001 {
+		t.Errorf("unexpected manufacturer data: %v", mds)
+	}
+	unknowns := adv.UnknownStructures()
+	if len(unknowns) != 1 || unknowns[0].Type() != ble.ADType(0xF0) || hex.EncodeToString(unknowns[0].Data()) != "aabb" {
+		t.Errorf("unexpected unknown structures: %v", unknowns)
+	}
+	if len(adv.Structures()) != 16 {
+		t.Errorf("expected 16 structures, got %d", len(adv.Structures()))
+	}
+
+	t.Run("truncated", func(t *testing.T) {
+		adv, err := ble.ParseAdvertisement([]byte{0x02, 0x01, 0x06, 0x05, 0x09, 0x67})
+		if !errors.Is(err, ble.ErrInvalid) {
+			t.Errorf("expected ErrInvalid, got %v", err)
+		}
+		if adv == nil || len(adv.Structures()) != 1 {
+			t.Errorf("expected the structures before the truncated one")
+		}
+	})
+
+	t.Run("device", func(t *testing.T) {
+		p := NewVirtualPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}, WithRawAdvertisement(raw))
+		scanner := ble.NewScannerWithBackend(NewSimulator(p))
+		scanOnce(t, scanner)
+		devs := scanner.Devices()
+		if len(devs) != 1 {
+			t.Fatalf("expected 1 device, got %d", len(devs))
+		}
+		if devs[0].LocalName() != "go-ble" {
+			t.Errorf("unexpected local name: %s", devs[0].LocalName())
+		}
+		if uri, ok := devs[0].Advertisement().URI(); !ok || uri != "https:example" {
+			t.Errorf("unexpected URI: %s", uri)
+		}
+	})
+
+	t.Run("synthesized", func(t *testing.T) {
+		p, _, _ := newTestMatterPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06})
+		scanner := ble.NewScannerWithBackend(NewSimulator(p))
+		scanOnce(t, scanner)
+		adv := scanner.Devices()[0].Advertisement()
+		if adv.LocalName() != "matter-test" {
+			t.Errorf("unexpected local name: %s", adv.LocalName())
+		}
+		if sds := adv.ServiceData(); len(sds) != 1 || !sds[0].UUID().Equal(testMatterServiceUUID) {
+			t.Errorf("unexpected service data: %v", sds)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestAdvertisingData(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []ble.AdvertisingDataOption
+		expected string
+	}{
+		{
+			name: "typed",
+			opts: []ble.AdvertisingDataOption{
+				ble.WithADFlags(ble.ADFlagLEGeneralDiscoverable | ble.ADFlagBREDRNotSupported),
+				ble.WithADServiceUUIDs(ble.NewUUIDFromUUID16(0x180F)),
+				ble.WithADLocalName("go"),
+				ble.WithADTxPower(-4),
+				ble.WithADAppearance(0x03C1),
+				ble.WithADServiceData(ble.NewUUIDFromUUID16(0xFFF6), []byte{0x00, 0xE4, 0x0F}),
+				ble.WithADManufacturerData(0xFFFF, []byte{0x00}),
+				ble.WithADManufacturerData(0xFFFF, []byte{0x01}),
+			},
+			expected: "020106" + "03030F18" + "0309676F" + "020AFC" + "0319C103" + "0616F6FF00E40F" + "04FFFFFF01",
+		},
+		{
+			name: "uuid128",
+			opts: []ble.AdvertisingDataOption{
+				ble.WithADServiceUUIDs(ble.MustUUIDFromString("18EE2EF5-263D-4559-959F-4F9C429F9D11")),
+				ble.WithADShortenedLocalName("m"),
+			},
+			expected: "1107119D9F429C4F9F9559453D26F52EEE18" + "02086D",
+		},
+		{
+			name: "uuid32",
+			opts: []ble.AdvertisingDataOption{
+				ble.WithADServiceUUIDs(ble.NewUUIDFromUUID32(0x0001FFF6)),
+				ble.WithADServiceData(ble.NewUUIDFromUUID32(0x0001FFF6), []byte{0xAA}),
+			},
+			expected: "0505F6FF0100" + "0620F6FF0100AA",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := ble.NewAdvertisingData(tt.opts...).Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.ToUpper(hex.EncodeToString(b)); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		invalids := []ble.AdvertisingData{
+			ble.NewAdvertisingData(ble.WithADLocalName(strings.Repeat("x", 30))),
+			ble.NewAdvertisingData(ble.WithADTxPower(128)),
+			ble.NewAdvertisingData(ble.WithADManufacturerData(0x10000, nil)),
+		}
+		for _, ad := range invalids {
+			if _, err := ad.Bytes(); !errors.Is(err, ble.ErrInvalid) {
+				t.Errorf("expected %v, got %v", ble.ErrInvalid, err)
+			}
+		}
+	})
+
+	t.Run("manufacturer", func(t *testing.T) {
+		ad := ble.NewAdvertisingData(ble.WithADManufacturerData(0x0001, []byte{0x01}))
+		mds := ad.ManufacturerData()
+		if len(mds) != 1 || mds[0].Name() != "Nokia Mobile Phones" {
+			t.Errorf("expected manufacturer 'Nokia Mobile Phones', got %v", mds)
+		}
+	})
+}
+
+func TestAdvertiser(t *testing.T) {
+	sim := NewSimulator()
+	adv := ble.NewAdvertiserWithBackend(sim)
+
+	data := ble.NewAdvertisingData(
+		ble.WithADFlags(ble.ADFlagLEGeneralDiscoverable),
+		ble.WithADLocalName("go-ble"),
+	)
+	scanRes := ble.NewAdvertisingData(ble.WithADManufacturerData(0xFFFF, []byte{0x01, 0x02}))
+	err := adv.StartAdvertising(data,
+		ble.WithAdvertisingInterval(200*time.Millisecond),
+		ble.WithAdvertisingConnectable(false),
+		ble.WithAdvertisingScanResponse(scanRes),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !adv.IsAdvertising() {
+		t.Errorf("expected advertising")
+	}
+	advData, params, ok := sim.Advertisement()
+	if !ok {
+		t.Fatalf("expected running advertisement")
+	}
+	if advData.LocalName() != "go-ble" {
+		t.Errorf("expected local name 'go-ble', got '%s'", advData.LocalName())
+	}
+	if params.Interval != 200*time.Millisecond || params.Connectable || params.ScanResponse != scanRes {
+		t.Errorf("unexpected parameters: %v", params)
+	}
+
+	tooLong := ble.NewAdvertisingData(ble.WithADLocalName(strings.Repeat("x", 30)))
+	if err := adv.StartAdvertising(tooLong); !errors.Is(err, ble.ErrInvalid) {
+		t.Errorf("expected %v, got %v", ble.ErrInvalid, err)
+	}
+	if running, _, ok := sim.Advertisement(); !ok || running != data || !adv.IsAdvertising() {
+		t.Errorf("expected the running advertisement to be kept")
+	}
+
+	if err := adv.StopAdvertising(); err != nil {
+		t.Fatal(err)
+	}
+	if adv.IsAdvertising() {
+		t.Errorf("expected not advertising")
+	}
+	if _, _, ok := sim.Advertisement(); ok {
+		t.Errorf("expected advertisement to be stopped")
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestATTError(t *testing.T) {
+	t.Run("code", func(t *testing.T) {
+		err := ble.NewATTError(ble.ATTErrorWriteNotPermitted, nil)
+		if !errors.Is(err, ble.ErrNotPermitted) {
+			t.Errorf("expected %v to match ErrNotPermitted", err)
+		}
+		if err.Error() != "ATT error 0x03 (write not permitted)" {
+			t.Errorf("unexpected message: %s", err.Error())
+		}
+		if !ble.ATTErrorCode(0x80).IsApplicationError() || ble.ATTErrorCode(0x80).String() != "application error" {
+			t.Errorf("expected 0x80 to be an application error")
+		}
+		if !errors.Is(ble.NewATTError(ble.ATTErrorInvalidAttributeValueLength, nil), ble.ErrInvalid) {
+			t.Errorf("expected invalid attribute value length to match ErrInvalid")
+		}
+	})
+
+	t.Run("write", func(t *testing.T) {
+		rejected := ble.ATTErrorCode(0x80)
+		char := NewVirtualCharacteristic(testMatterC1UUID,
+			WithCharacteristicWritable(),
+			WithCharacteristicWriteValidator(func(char VirtualCharacteristic, data []byte) error {
+				if len(data) == 0 || data[0] != 0x01 {
+					return ble.NewATTError(rejected, nil)
+				}
+				return nil
+			}),
+		)
+		readOnly := NewVirtualCharacteristic(testMatterC2UUID, WithCharacteristicReadable())
+		p := NewVirtualPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+			WithServices(NewVirtualService(testMatterServiceUUID, char, readOnly)),
+		)
+		dev := connectTestDevice(t, p)
+		service, _ := dev.LookupService(testMatterServiceUUID)
+		c1, _ := service.LookupCharacteristic(testMatterC1UUID)
+		c2, _ := service.LookupCharacteristic(testMatterC2UUID)
+
+		if _, err := c1.Write([]byte{0x01, 0x02}); err != nil {
+			t.Fatal(err)
+		}
+		_, err := c1.Write([]byte{0x02})
+		var attErr *ble.ATTError
+		if !errors.As(err, &attErr) || attErr.Code != rejected {
+			t.Fatalf("expected ATT error 0x80, got %v", err)
+		}
+		if !bytes.Equal(char.Value(), []byte{0x01, 0x02}) {
+			t.Errorf("expected the rejected value to not be written, got %X", char.Value())
+		}
+
+		// A write without response is not acknowledged, so the rejection is not reported.
+		if _, err := c1.WriteWithoutResponse([]byte{0x02}); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+
+		_, err = c2.Write([]byte{0x01})
+		if !errors.As(err, &attErr) || attErr.Code != ble.ATTErrorWriteNotPermitted {
+			t.Errorf("expected write not permitted, got %v", err)
+		}
+		if !errors.Is(err, ble.ErrNotPermitted) {
+			t.Errorf("expected %v to match ErrNotPermitted", err)
+		}
+	})
+}
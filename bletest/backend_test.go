@@ -0,0 +1,103 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestBackend(t *testing.T) {
+	serviceUUID := ble.NewUUIDFromUUID16(0xFFF6)
+	charUUID := ble.MustUUIDFromString("18EE2EF5-263D-4559-959F-4F9C429F9D11")
+	addr := ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}
+
+	var char VirtualCharacteristic
+	char = NewVirtualCharacteristic(charUUID,
+		WithCharacteristicReadable(),
+		WithCharacteristicWritable(),
+		WithCharacteristicNotifying(),
+		WithCharacteristicWriteHandler(func(_ VirtualCharacteristic, data []byte) {
+			char.NotifyValue(data)
+		}),
+	)
+	p := NewVirtualPeripheral(addr,
+		WithLocalName("fake"),
+		WithServices(NewVirtualService(serviceUUID, char)),
+	)
+
+	central := ble.NewCentralWithBackend(NewSimulator(p))
+	scanOnce(t, central)
+	// The service data of a later advertisement is merged into the discovered device.
+	p.AddServiceData(serviceUUID, []byte{0x00, 0xE4, 0x0F})
+	scanOnce(t, central)
+
+	devs := central.Devices()
+	if len(devs) != 1 {
+		t.Fatalf("expected 1 device, got %d", len(devs))
+	}
+	dev := devs[0]
+	if _, ok := dev.LookupService(serviceUUID); !ok {
+		t.Fatalf("expected advertised service %s", serviceUUID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := central.Connect(ctx, dev); err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Disconnect()
+
+	service, ok := dev.LookupService(serviceUUID)
+	if !ok {
+		t.Fatalf("expected discovered service %s", serviceUUID)
+	}
+	devChar, ok := service.LookupCharacteristic(charUUID)
+	if !ok {
+		t.Fatalf("expected characteristic %s", charUUID)
+	}
+
+	notified := make(chan []byte, 1)
+	err := devChar.Notify(func(char ble.Characteristic, buf []byte) {
+		notified <- buf
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte{0x01, 0x02}
+	if _, err := devChar.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	value, err := devChar.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(value, data) {
+		t.Errorf("expected %X, got %X", data, value)
+	}
+	select {
+	case buf := <-notified:
+		if !bytes.Equal(buf, data) {
+			t.Errorf("expected notification %X, got %X", data, buf)
+		}
+	case <-ctx.Done():
+		t.Errorf("expected notification %X", data)
+	}
+}
@@ -104,6 +104,58 @@ func TestEmbeddedDatabase(t *testing.T) {
 				t.Errorf("expected characteristic 0xFFFF to not be found")
 			}
 		})
+
+		t.Run("Descriptor", func(t *testing.T) {
+			descTests := []struct {
+				UUID ble.UUID
+				Name string
+			}{
+				{UUID: ble.NewUUIDFromUUID16(0x2901), Name: "Characteristic User Description"},
+				{UUID: ble.NewUUIDFromUUID16(0x2902), Name: "Client Characteristic Configuration"},
+			}
+			for _, tt := range descTests {
+				desc, ok := db.LookupDescriptor(tt.UUID)
+				if !ok {
+					t.Errorf("expected descriptor 0x%04X to be found", tt.UUID)
+					continue
+				}
+				if desc.Name() != tt.Name {
+					t.Errorf("expected descriptor name to be '%s', got '%s'", tt.Name, desc.Name())
+				}
+			}
+
+			// Check a non-existent descriptor.
+			_, ok := db.LookupDescriptor(ble.NewUUIDFromUUID16(0xFFFF))
+			if ok {
+				t.Errorf("expected descriptor 0xFFFF to not be found")
+			}
+		})
+
+		t.Run("ADType", func(t *testing.T) {
+			adTypeTests := []struct {
+				ID   int
+				Name string
+			}{
+				{ID: 0x01, Name: "Flags"},
+				{ID: 0xFF, Name: "Manufacturer Specific Data"},
+			}
+			for _, tt := range adTypeTests {
+				adType, ok := db.LookupADType(tt.ID)
+				if !ok {
+					t.Errorf("expected AD type 0x%02X to be found", tt.ID)
+					continue
+				}
+				if adType.Name() != tt.Name {
+					t.Errorf("expected AD type name to be '%s', got '%s'", tt.Name, adType.Name())
+				}
+			}
+
+			// Check a non-existent AD type.
+			_, ok := db.LookupADType(0xF0)
+			if ok {
+				t.Errorf("expected AD type 0xF0 to not be found")
+			}
+		})
 	})
 	t.Run("VendorSpecific", func(t *testing.T) {
 		t.Run("Matter", func(t *testing.T) {
@@ -0,0 +1,96 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestCharacteristicDescriptors(t *testing.T) {
+	userDescUUID := ble.NewUUIDFromUUID16(0x2901)
+	formatUUID := ble.NewUUIDFromUUID16(0x2904)
+	char := NewVirtualCharacteristic(testMatterC2UUID,
+		WithCharacteristicReadable(),
+		WithCharacteristicNotifying(),
+		WithCharacteristicDescriptors(
+			NewVirtualDescriptor(userDescUUID, WithDescriptorValue([]byte("temperature"))),
+			NewVirtualDescriptor(formatUUID, WithDescriptorWritable()),
+		),
+	)
+	p := NewVirtualPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+		WithServices(NewVirtualService(testMatterServiceUUID, char)),
+	)
+	dev := connectTestDevice(t, p)
+	service, _ := dev.LookupService(testMatterServiceUUID)
+	c2, ok := service.LookupCharacteristic(testMatterC2UUID)
+	if !ok {
+		t.Fatalf("expected characteristic %s", testMatterC2UUID)
+	}
+
+	props := c2.Properties()
+	if !props.IsReadable() || !props.IsNotifiable() || props.IsWritable() || props.IsWritableWithoutResponse() {
+		t.Errorf("unexpected properties: %s", props)
+	}
+
+	if n := len(c2.Descriptors()); n != 2 {
+		t.Fatalf("expected 2 descriptors, got %d", n)
+	}
+	userDesc, ok := c2.LookupDescriptor(0x2901)
+	if !ok {
+		t.Fatalf("expected descriptor %s", userDescUUID)
+	}
+	if userDesc.Name() != "Characteristic User Description" {
+		t.Errorf("unexpected descriptor name: %s", userDesc.Name())
+	}
+	if b, err := userDesc.Read(); err != nil || string(b) != "temperature" {
+		t.Errorf("expected 'temperature', got '%s' (%v)", b, err)
+	}
+	if _, err := userDesc.Write([]byte("x")); !errors.Is(err, ble.ErrNotPermitted) {
+		t.Errorf("expected ErrNotPermitted, got %v", err)
+	}
+
+	format, _ := c2.LookupDescriptor(formatUUID)
+	value := []byte{0x0E, 0xFE, 0x2F, 0x27, 0x01, 0x00, 0x00}
+	if _, err := format.Write(value); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := format.Read(); string(b) != string(value) {
+		t.Errorf("expected %X, got %X", value, b)
+	}
+	if _, ok := c2.LookupDescriptor(0x2902); ok {
+		t.Errorf("expected descriptor 0x2902 to not be found")
+	}
+
+	var obj struct {
+		Properties  []string `json:"properties"`
+		Descriptors []struct {
+			UUID string `json:"uuid"`
+			Name string `json:"name"`
+		} `json:"descriptors"`
+	}
+	if err := json.Unmarshal([]byte(c2.String()), &obj); err != nil {
+		t.Fatal(err)
+	}
+	if len(obj.Properties) != 2 || obj.Properties[0] != "read" || obj.Properties[1] != "notify" {
+		t.Errorf("unexpected properties: %v", obj.Properties)
+	}
+	if len(obj.Descriptors) != 2 || obj.Descriptors[0].Name != "Characteristic User Description" {
+		t.Errorf("unexpected descriptors: %v", obj.Descriptors)
+	}
+}
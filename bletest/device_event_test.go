@@ -0,0 +1,130 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestDeviceEvents(t *testing.T) {
+	addr1 := ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}
+	addr2 := ble.Address{0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F}
+	p1 := NewVirtualPeripheral(addr1, WithLocalName("p1"), WithRSSI(-60))
+	p2 := NewVirtualPeripheral(addr2, WithLocalName("p2"), WithRSSI(-60))
+	sim := NewSimulator(p1, p2)
+	sim.SetAdvertisingInterval(5 * time.Millisecond)
+	scanner := ble.NewScannerWithBackend(sim)
+
+	events := make(chan ble.DeviceEvent, 256)
+	handled := make(chan ble.DeviceEvent, 256)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := scanner.StartScan(ctx,
+		ble.DeviceEventChannel(events),
+		ble.DeviceEventHandler(func(event ble.DeviceEvent) {
+			handled <- event
+		}),
+		ble.DeviceLostTimeout(100*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer scanner.StopScan()
+
+	// waitEvent waits for the event of the device with the type, skipping the other events.
+	waitEvent := func(t *testing.T, eventType ble.DeviceEventType, addr ble.Address, changes ble.DeviceChanges) ble.DeviceEvent {
+		t.Helper()
+		timeout := time.After(2 * time.Second)
+		for {
+			select {
+			case event := <-events:
+				if event.Type == eventType && event.Device.Address().String() == addr.String() && event.Changes.Has(changes) {
+					return event
+				}
+			case <-timeout:
+				t.Fatalf("expected %s event of %s with %s", eventType, addr, changes)
+			}
+		}
+	}
+
+	t.Run("discovered", func(t *testing.T) {
+		for _, addr := range []ble.Address{addr1, addr2} {
+			event := waitEvent(t, ble.DeviceDiscovered, addr, 0)
+			if event.Changes != 0 {
+				t.Errorf("expected no changes, got %s", event.Changes)
+			}
+		}
+		event := <-handled
+		if event.Type != ble.DeviceDiscovered {
+			t.Errorf("expected the handler to receive %s first, got %s", ble.DeviceDiscovered, event)
+		}
+	})
+
+	t.Run("updated", func(t *testing.T) {
+		p1.SetRSSI(-30)
+		p1.SetLocalName("p1-renamed")
+		event := waitEvent(t, ble.DeviceUpdated, addr1, ble.DeviceChangeRSSI|ble.DeviceChangeLocalName)
+		if event.Device.LocalName() != "p1-renamed" || event.Device.RSSI() != -30 {
+			t.Errorf("expected the device to be updated, got %s", event.Device)
+		}
+		if event.Device.ModifiedAt().Before(event.Device.DiscoveredAt()) {
+			t.Errorf("expected the device to be modified after it was discovered")
+		}
+
+		p1.AddServiceData(testMatterServiceUUID, []byte{0x01})
+		waitEvent(t, ble.DeviceUpdated, addr1, ble.DeviceChangeServices)
+		p1.AddServiceData(testMatterServiceUUID, []byte{0x02})
+		waitEvent(t, ble.DeviceUpdated, addr1, ble.DeviceChangeServices)
+		p1.SetServiceUUIDs(testMatterServiceUUID)
+		event = waitEvent(t, ble.DeviceUpdated, addr1, ble.DeviceChangeServices)
+		if uuids := event.Device.Advertisement().ServiceUUIDs(); len(uuids) != 1 || !uuids[0].Equal(testMatterServiceUUID) {
+			t.Errorf("expected the advertised service UUID %s, got %v", testMatterServiceUUID, uuids)
+		}
+		p1.AddManufacturerData(0x0059, []byte{0x01})
+		event = waitEvent(t, ble.DeviceUpdated, addr1, ble.DeviceChangeManufacturerData)
+		if event.Device.Manufacturer().ID() != 0x0059 {
+			t.Errorf("expected manufacturer 0x0059, got %s", event.Device.Manufacturer())
+		}
+	})
+
+	t.Run("lost", func(t *testing.T) {
+		sim.RemovePeripheral(p2)
+		event := waitEvent(t, ble.DeviceLost, addr2, 0)
+		if time.Since(event.Device.LastSeenAt()) < 100*time.Millisecond {
+			t.Errorf("expected the device to be lost after the timeout")
+		}
+		if n := len(scanner.Devices()); n != 2 {
+			t.Errorf("expected the lost device to stay registered, got %d devices", n)
+		}
+
+		sim.AddPeripheral(p2)
+		waitEvent(t, ble.DeviceDiscovered, addr2, 0)
+	})
+
+	if err := scanner.StopScan(); err != nil {
+		t.Fatal(err)
+	}
+	for len(events) != 0 {
+		<-events
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := len(events); n != 0 {
+		t.Errorf("expected no events after the scan stopped, got %d", n)
+	}
+}
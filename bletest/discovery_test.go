@@ -0,0 +1,165 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestDiscoverServicesUnreportedProperties(t *testing.T) {
+	serviceChanged := NewVirtualCharacteristic(ble.ServiceChangedUUID,
+		WithCharacteristicIndicating(),
+		WithCharacteristicUnreportedProperties(),
+	)
+	p := NewVirtualPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+		WithServices(NewVirtualService(ble.GenericAttributeServiceUUID, serviceChanged)),
+	)
+	dev := connectTestDevice(t, p)
+	if _, err := dev.DiscoverServices(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !serviceChanged.IsSubscribed() {
+		t.Errorf("expected Service Changed indications to be enabled")
+	}
+}
+
+func TestDiscoverServices(t *testing.T) {
+	batteryUUID := ble.NewUUIDFromUUID16(0x180F)
+	batteryLevelUUID := ble.NewUUIDFromUUID16(0x2A19)
+	secondaryUUID := ble.NewUUIDFromUUID16(0xFFF7)
+
+	serviceChanged := NewVirtualCharacteristic(ble.ServiceChangedUUID, WithCharacteristicIndicating())
+	gatt := NewVirtualService(ble.GenericAttributeServiceUUID, serviceChanged)
+	secondary := NewVirtualSecondaryService(secondaryUUID)
+	matter := NewVirtualService(testMatterServiceUUID,
+		NewVirtualCharacteristic(testMatterC1UUID, WithCharacteristicWritable()),
+		NewVirtualCharacteristic(testMatterC2UUID,
+			WithCharacteristicNotifying(),
+			WithCharacteristicDescriptors(NewVirtualDescriptor(ble.NewUUIDFromUUID16(0x2901), WithDescriptorValue([]byte("c2")))),
+		),
+	)
+	matter.IncludeServices(secondary)
+	p := NewVirtualPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+		WithServiceData(testMatterServiceUUID, []byte{0x00}),
+		WithServices(gatt, matter, secondary),
+	)
+	central := ble.NewCentralWithBackend(NewSimulator(p))
+	scanOnce(t, central)
+	dev := central.Devices()[0]
+
+	ctx := context.Background()
+	if _, err := dev.DiscoverServices(ctx); !errors.Is(err, ble.ErrNotConnected) {
+		t.Errorf("expected ErrNotConnected, got %v", err)
+	}
+	if n := len(dev.Services()); n != 1 {
+		t.Errorf("expected 1 advertised service, got %d", n)
+	}
+
+	if err := central.Connect(ctx, dev); err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Disconnect()
+
+	services, err := dev.DiscoverServices(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(services) != 3 || len(dev.Services()) != 3 {
+		t.Fatalf("expected 3 discovered services, got %d", len(services))
+	}
+	if !serviceChanged.IsSubscribed() {
+		t.Errorf("expected Service Changed indications to be enabled")
+	}
+
+	service, err := dev.LookupServiceContext(ctx, testMatterServiceUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached, _ := dev.LookupService(testMatterServiceUUID); cached != service {
+		t.Errorf("expected the cached service to be returned")
+	}
+	if !service.IsPrimary() || len(service.Data()) != 1 {
+		t.Errorf("expected the primary service with the advertised data")
+	}
+	included := service.IncludedServices()
+	if len(included) != 1 || !included[0].UUID().Equal(secondaryUUID) || included[0].IsPrimary() {
+		t.Errorf("expected the included secondary service %s, got %v", secondaryUUID, included)
+	}
+	char, ok := service.LookupCharacteristic(testMatterC2UUID)
+	if !ok || len(char.Descriptors()) != 1 {
+		t.Errorf("expected the characteristic with the descriptor")
+	}
+
+	// The peripheral changes the GATT table and indicates a Service Changed.
+	battery := NewVirtualService(batteryUUID, NewVirtualCharacteristic(batteryLevelUUID, WithCharacteristicReadable()))
+	p.SetServices(gatt, battery)
+	if err := serviceChanged.IndicateValue([]byte{0x01, 0x00, 0xFF, 0xFF}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := dev.LookupService(batteryUUID); !ok {
+		t.Errorf("expected the changed service %s to be discovered", batteryUUID)
+	}
+	if _, err := dev.LookupServiceContext(ctx, testMatterServiceUUID); !errors.Is(err, ble.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for the removed service, got %v", err)
+	}
+	if n := len(dev.Services()); n != 2 {
+		t.Errorf("expected 2 discovered services, got %d", n)
+	}
+
+	// Connecting again closes the connection and clears the cache.
+	if err := central.Connect(ctx, dev); err != nil {
+		t.Fatal(err)
+	}
+	if serviceChanged.IsSubscribed() {
+		t.Errorf("expected Service Changed indications to be disabled")
+	}
+	if n := len(dev.Services()); n != 1 {
+		t.Errorf("expected 1 advertised service, got %d", n)
+	}
+	if !dev.IsConnected() || !p.IsConnected() {
+		t.Errorf("expected the device to be connected again")
+	}
+	// Concurrent discoveries share a single Service Changed subscription.
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := dev.DiscoverServices(ctx); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if !serviceChanged.IsSubscribed() {
+		t.Errorf("expected Service Changed indications to be enabled")
+	}
+
+	// Disconnecting clears the cache.
+	if err := dev.Disconnect(); err != nil {
+		t.Fatal(err)
+	}
+	if serviceChanged.IsSubscribed() {
+		t.Errorf("expected Service Changed indications to be disabled")
+	}
+	if n := len(dev.Services()); n != 1 {
+		t.Errorf("expected 1 advertised service, got %d", n)
+	}
+}
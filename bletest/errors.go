@@ -12,22 +12,15 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//go:build !linux
-
-package ble
+package bletest
 
 import (
-	"fmt"
+	"errors"
 )
 
-// Write writes the characteristic value.
-func (char *tinyCharacteristic) Write(data []byte) (int, error) {
-	if char.tinyChar == nil {
-		return 0, fmt.Errorf("%w: %s", ErrNotConnected, char.String())
-	}
-	nWrote, err := char.tinyChar.Write(data)
-	if err != nil {
-		return nWrote, fmt.Errorf("%w: %s", err, char.String())
-	}
-	return nWrote, nil
-}
+var (
+	// ErrNotSubscribed indicates that no central has subscribed to the virtual characteristic.
+	ErrNotSubscribed = errors.New("not subscribed")
+	// ErrNotConnectable indicates that the virtual peripheral does not accept connections.
+	ErrNotConnectable = errors.New("not connectable")
+)
@@ -0,0 +1,214 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func testCodecs() map[string]ble.Codec {
+	return map[string]ble.Codec{
+		"length prefix": ble.NewLengthPrefixCodec(2, binary.BigEndian),
+		"slip":          ble.NewSLIPCodec(),
+		"cobs":          ble.NewCOBSCodec(),
+		"newline":       ble.NewNewlineCodec(),
+		"header":        ble.NewHeaderCodec(ble.WithHeaderCodecMagic(0xA5, 0x5A)),
+		"header crc32":  ble.NewHeaderCodec(ble.WithHeaderCodecCRC(ble.HeaderCodecCRC32)),
+	}
+}
+
+func TestCodec(t *testing.T) {
+	long := make([]byte, 600)
+	for n := range long {
+		long[n] = byte(n % 0xFF)
+	}
+	msgs := [][]byte{
+		{0x01},
+		{0x00, 0xC0, 0xDB, 0xDC, 0xDD, 0x00},
+		[]byte("hello world"),
+		long,
+	}
+
+	for name, codec := range testCodecs() {
+		t.Run(name, func(t *testing.T) {
+			stream := []byte{}
+			for _, msg := range msgs {
+				if name == "newline" && bytes.IndexByte(msg, '\n') >= 0 {
+					continue
+				}
+				frame, err := codec.Encode(msg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				stream = append(stream, frame...)
+			}
+			for _, msg := range msgs {
+				if name == "newline" && bytes.IndexByte(msg, '\n') >= 0 {
+					continue
+				}
+				var decoded []byte
+				for decoded == nil {
+					var n int
+					var err error
+					decoded, n, err = codec.Decode(stream)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if n == 0 {
+						t.Fatalf("expected a complete frame for %X", msg)
+					}
+					stream = stream[n:]
+				}
+				if !bytes.Equal(decoded, msg) {
+					t.Errorf("expected %X, got %X", msg, decoded)
+				}
+			}
+			if _, n, _ := codec.Decode(stream); n != 0 || len(stream) != 0 {
+				t.Errorf("expected the stream to be consumed, %d bytes left", len(stream))
+			}
+		})
+	}
+
+	t.Run("vectors", func(t *testing.T) {
+		vectors := []struct {
+			codec    ble.Codec
+			msg      []byte
+			expected []byte
+		}{
+			{ble.NewCOBSCodec(), []byte{0x11, 0x22, 0x00, 0x33}, []byte{0x03, 0x11, 0x22, 0x02, 0x33, 0x00}},
+			{ble.NewCOBSCodec(), []byte{0x00, 0x00}, []byte{0x01, 0x01, 0x01, 0x00}},
+			{ble.NewCOBSCodec(), bytes.Repeat([]byte{0x01}, 254), append(append([]byte{0xFF}, bytes.Repeat([]byte{0x01}, 254)...), 0x00)},
+			{ble.NewSLIPCodec(), []byte{0x01, 0xC0, 0xDB}, []byte{0xC0, 0x01, 0xDB, 0xDC, 0xDB, 0xDD, 0xC0}},
+			{ble.NewLengthPrefixCodec(2, binary.LittleEndian), []byte{0x01, 0x02}, []byte{0x02, 0x00, 0x01, 0x02}},
+			// The CRC-16/CCITT-FALSE checksum covers the length field and the message.
+			{ble.NewHeaderCodec(ble.WithHeaderCodecByteOrder(binary.BigEndian)), []byte("3456789"), []byte("\x00\x073456789\x38\xF2")},
+		}
+		for _, v := range vectors {
+			frame, err := v.codec.Encode(v.msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(frame, v.expected) {
+				t.Errorf("expected %X, got %X", v.expected, frame)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		if _, err := ble.NewNewlineCodec().Encode([]byte("a\nb")); !errors.Is(err, ble.ErrInvalid) {
+			t.Errorf("expected ErrInvalid, got %v", err)
+		}
+		if _, err := ble.NewLengthPrefixCodec(1, binary.BigEndian).Encode(make([]byte, 256)); !errors.Is(err, ble.ErrInvalid) {
+			t.Errorf("expected ErrInvalid, got %v", err)
+		}
+		if _, err := ble.NewLengthPrefixCodec(3, binary.BigEndian).Encode([]byte{0x01}); !errors.Is(err, ble.ErrInvalid) {
+			t.Errorf("expected ErrInvalid, got %v", err)
+		}
+		frame, _ := ble.NewHeaderCodec().Encode([]byte{0x01, 0x02})
+		frame[2] ^= 0xFF
+		if _, n, err := ble.NewHeaderCodec().Decode(frame); !errors.Is(err, ble.ErrInvalid) || n != len(frame) {
+			t.Errorf("expected ErrInvalid discarding the frame, got %d %v", n, err)
+		}
+	})
+}
+
+func TestFramedTransport(t *testing.T) {
+	msg := bytes.Repeat([]byte{0x00, 0x0A, 0xC0, 0x41}, 30)
+	msg[1] = 0x42
+
+	for name, codec := range testCodecs() {
+		t.Run(name, func(t *testing.T) {
+			if name == "newline" {
+				msg := bytes.ReplaceAll(msg, []byte{'\n'}, []byte{'.'})
+				testFramedTransportEcho(t, codec, msg)
+				return
+			}
+			testFramedTransportEcho(t, codec, msg)
+		})
+	}
+
+	t.Run("resync", func(t *testing.T) {
+		c2, transport := openTestTransport(t)
+		codec := ble.NewHeaderCodec(ble.WithHeaderCodecMagic(0xA5, 0x5A))
+		framed := ble.NewFramedTransport(transport, codec)
+		defer framed.Close()
+
+		frame, _ := codec.Encode([]byte("ok"))
+		corrupted := bytes.Clone(frame)
+		corrupted[len(corrupted)-1] ^= 0xFF
+		c2.NotifyValue(append([]byte{0x01, 0x02}, corrupted...))
+		c2.NotifyValue(frame)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if _, err := framed.ReadMessage(ctx); !errors.Is(err, ble.ErrInvalid) {
+			t.Fatalf("expected ErrInvalid, got %v", err)
+		}
+		b, err := framed.ReadMessage(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != "ok" {
+			t.Errorf("expected 'ok', got %q", b)
+		}
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		c2, transport := openTestTransport(t)
+		framed := ble.NewFramedTransport(transport, ble.NewNewlineCodec(), ble.WithFramedTransportMaxFrameSize(8))
+		defer framed.Close()
+
+		c2.NotifyValue([]byte("0123456789"))
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if _, err := framed.ReadMessage(ctx); !errors.Is(err, ble.ErrOverflow) {
+			t.Errorf("expected ErrOverflow, got %v", err)
+		}
+	})
+}
+
+func testFramedTransportEcho(t *testing.T, codec ble.Codec, msg []byte) {
+	t.Helper()
+	c2, transport := openTestTransport(t)
+	framed := ble.NewFramedTransport(transport, codec, ble.WithFramedTransportWriteSize(20))
+	defer framed.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for range 2 {
+		if err := framed.WriteMessage(ctx, msg); err != nil {
+			t.Fatal(err)
+		}
+		if 20 < len(c2.Value()) {
+			t.Errorf("expected writes of at most 20 bytes, got %d", len(c2.Value()))
+		}
+	}
+	for range 2 {
+		b, err := framed.ReadMessage(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, msg) {
+			t.Errorf("expected %X, got %X", msg, b)
+		}
+	}
+}
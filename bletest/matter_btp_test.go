@@ -0,0 +1,311 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+	"github.com/cybergarage/go-ble/ble/matter"
+)
+
+// testBTPPeer is an in-process BTP peer on the C1/C2 characteristics of a virtual peripheral which echoes received messages.
+type testBTPPeer struct {
+	sync.Mutex
+	c2          VirtualCharacteristic
+	segmentSize uint16
+	window      uint8
+	silent      bool
+	txSeq       uint8
+	rxSeq       uint8
+	rxUnacked   bool
+	message     []byte
+	handshake   *matter.BTPHandshakeRequest
+	keepAlives  int
+	packets     int
+}
+
+func newTestBTPPeripheral(addr ble.Address, peer *testBTPPeer) VirtualPeripheral {
+	peer.c2 = NewVirtualCharacteristic(matter.C2UUID,
+		WithCharacteristicNotifying(),
+	)
+	c1 := NewVirtualCharacteristic(matter.C1UUID,
+		WithCharacteristicWritable(),
+		WithCharacteristicWriteHandler(func(char VirtualCharacteristic, data []byte) {
+			peer.receive(data)
+		}),
+	)
+	sd := &matter.ServiceData{
+		Opcode:               matter.OpcodeCommissionable,
+		Version:              0,
+		Discriminator:        3840,
+		VendorID:             0xFFF1,
+		ProductID:            0x8000,
+		AdditionalData:       false,
+		ExtendedAnnouncement: false,
+	}
+	return NewVirtualPeripheral(addr,
+		WithServiceData(matter.ServiceUUID, sd.Bytes()),
+		WithServices(NewVirtualService(matter.ServiceUUID, c1, peer.c2)),
+	)
+}
+
+func (peer *testBTPPeer) receive(data []byte) {
+	peer.Lock()
+	defer peer.Unlock()
+
+	if req, err := matter.ParseBTPHandshakeRequest(data); err == nil {
+		peer.handshake = req
+		res := &matter.BTPHandshakeResponse{
+			Version:     matter.BTPVersion,
+			SegmentSize: peer.segmentSize,
+			WindowSize:  peer.window,
+		}
+		peer.c2.NotifyValue(res.Bytes())
+		peer.txSeq = 1
+		return
+	}
+
+	pkt, err := matter.ParseBTPPacket(data)
+	if err != nil {
+		return
+	}
+	peer.packets++
+	if pkt.IsKeepAlive() {
+		peer.keepAlives++
+	}
+	if peer.silent {
+		return
+	}
+	peer.rxSeq = pkt.Seq
+	if pkt.IsStandaloneAck() {
+		return
+	}
+	peer.rxUnacked = true
+	if pkt.Flags.Has(matter.BTPFlagBeginning) {
+		peer.message = []byte{}
+	}
+	peer.message = append(peer.message, pkt.Payload...)
+	if !pkt.Flags.Has(matter.BTPFlagEnding) {
+		peer.notify(&matter.BTPPacket{Flags: 0}) // nolint: exhaustruct
+		return
+	}
+
+	// Echo the received message.
+	msg := peer.message
+	offset := 0
+	for first := true; first || offset < len(msg); first = false {
+		pkt := &matter.BTPPacket{Flags: matter.BTPFlagContinuing} // nolint: exhaustruct
+		if first {
+			pkt.Flags = matter.BTPFlagBeginning
+			pkt.MessageLength = uint16(len(msg))
+		}
+		n := min(int(peer.segmentSize)-5, len(msg)-offset)
+		if offset+n == len(msg) {
+			pkt.Flags |= matter.BTPFlagEnding
+		}
+		pkt.Payload = msg[offset : offset+n]
+		peer.notify(pkt)
+		offset += n
+	}
+}
+
+func (peer *testBTPPeer) notify(pkt *matter.BTPPacket) {
+	if peer.rxUnacked {
+		pkt.Flags |= matter.BTPFlagAck
+		pkt.Ack = peer.rxSeq
+		peer.rxUnacked = false
+	}
+	pkt.Seq = peer.txSeq
+	peer.txSeq++
+	peer.c2.NotifyValue(pkt.Bytes())
+}
+
+func openTestBTPSession(t *testing.T, peer *testBTPPeer, opts ...matter.BTPOption) (ble.Device, matter.BTPSession) {
+	t.Helper()
+	p := newTestBTPPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}, peer)
+	central := ble.NewCentralWithBackend(NewSimulator(p))
+	scanOnce(t, central, matter.WithDiscriminator(3840))
+	devs := central.Devices()
+	if len(devs) != 1 {
+		t.Fatalf("expected 1 device, got %d", len(devs))
+	}
+	dev := devs[0]
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := central.Connect(ctx, dev); err != nil {
+		t.Fatal(err)
+	}
+	session, err := matter.OpenBTPSession(ctx, dev, opts...)
+	if err != nil {
+		dev.Disconnect()
+		t.Fatal(err)
+	}
+	return dev, session
+}
+
+func TestMatterBTPPacket(t *testing.T) {
+	req := &matter.BTPHandshakeRequest{Versions: []uint8{4, 3}, MTU: 247, WindowSize: 6}
+	if got := req.Bytes(); !bytes.Equal(got, []byte{0x65, 0x6C, 0x34, 0x00, 0x00, 0x00, 0xF7, 0x00, 0x06}) {
+		t.Errorf("unexpected handshake request: %X", got)
+	}
+	parsedReq, err := matter.ParseBTPHandshakeRequest(req.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(parsedReq.Versions, req.Versions) || parsedReq.MTU != req.MTU || parsedReq.WindowSize != req.WindowSize {
+		t.Errorf("unexpected handshake request: %v", parsedReq)
+	}
+
+	pkt := &matter.BTPPacket{
+		Flags:         matter.BTPFlagBeginning | matter.BTPFlagEnding | matter.BTPFlagAck,
+		Opcode:        0,
+		Ack:           0x01,
+		Seq:           0x02,
+		MessageLength: 3,
+		Payload:       []byte{0xAA, 0xBB, 0xCC},
+	}
+	if got := pkt.Bytes(); !bytes.Equal(got, []byte{0x0D, 0x01, 0x02, 0x03, 0x00, 0xAA, 0xBB, 0xCC}) {
+		t.Errorf("unexpected packet: %X", got)
+	}
+	parsedPkt, err := matter.ParseBTPPacket(pkt.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsedPkt.Ack != pkt.Ack || parsedPkt.Seq != pkt.Seq || parsedPkt.MessageLength != pkt.MessageLength || !bytes.Equal(parsedPkt.Payload, pkt.Payload) {
+		t.Errorf("unexpected packet: %v", parsedPkt)
+	}
+
+	if _, err := matter.ParseBTPPacket([]byte{0x0D, 0x01}); !errors.Is(err, ble.ErrInvalid) {
+		t.Errorf("expected ErrInvalid, got %v", err)
+	}
+}
+
+func TestMatterBTPSession(t *testing.T) {
+	peer := &testBTPPeer{segmentSize: 20, window: 4} // nolint: exhaustruct
+	dev, session := openTestBTPSession(t, peer, matter.WithBTPMTU(100), matter.WithBTPWindowSize(5))
+	defer dev.Disconnect()
+	defer session.Close()
+
+	if session.Version() != matter.BTPVersion || session.SegmentSize() != 20 || session.WindowSize() != 4 {
+		t.Errorf("unexpected negotiation: version=%d segment=%d window=%d", session.Version(), session.SegmentSize(), session.WindowSize())
+	}
+	if peer.handshake.MTU != 100 || peer.handshake.WindowSize != 5 {
+		t.Errorf("unexpected handshake request: %v", peer.handshake)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, size := range []int{0, 3, 15, 16, 200} {
+		msg := make([]byte, size)
+		for n := range msg {
+			msg[n] = byte(n)
+		}
+		if err := session.WriteMessage(ctx, msg); err != nil {
+			t.Fatal(err)
+		}
+		echo, err := session.ReadMessage(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(echo, msg) {
+			t.Errorf("expected %X, got %X", msg, echo)
+		}
+	}
+
+	t.Run("io", func(t *testing.T) {
+		if _, err := session.Write([]byte("hello")); err != nil {
+			t.Fatal(err)
+		}
+		buf := make([]byte, 2)
+		if _, err := session.Read(buf); !errors.Is(err, io.ErrShortBuffer) {
+			t.Errorf("expected io.ErrShortBuffer, got %v", err)
+		}
+	})
+
+	if err := session.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := session.Read(make([]byte, 8)); !errors.Is(err, io.EOF) {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestMatterBTPKeepAlive(t *testing.T) {
+	peer := &testBTPPeer{segmentSize: 20, window: 4} // nolint: exhaustruct
+	dev, session := openTestBTPSession(t, peer,
+		matter.WithBTPSendAckTimeout(20*time.Millisecond),
+		matter.WithBTPKeepAliveInterval(50*time.Millisecond),
+	)
+	defer dev.Disconnect()
+	defer session.Close()
+
+	time.Sleep(500 * time.Millisecond)
+	peer.Lock()
+	keepAlives := peer.keepAlives
+	peer.Unlock()
+	if keepAlives == 0 {
+		t.Errorf("expected keep-alives to be sent")
+	}
+	if err := session.WriteMessage(context.Background(), []byte{0x01}); err != nil {
+		t.Errorf("expected the session to be alive, got %v", err)
+	}
+}
+
+func TestMatterBTPTimeout(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []matter.BTPOption
+	}{
+		{
+			name: "ack",
+			opts: []matter.BTPOption{
+				matter.WithBTPAckTimeout(200 * time.Millisecond),
+				matter.WithBTPKeepAliveInterval(0),
+			},
+		},
+		{
+			name: "idle",
+			opts: []matter.BTPOption{
+				matter.WithBTPIdleTimeout(200 * time.Millisecond),
+				matter.WithBTPKeepAliveInterval(0),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			peer := &testBTPPeer{segmentSize: 20, window: 4, silent: true} // nolint: exhaustruct
+			dev, session := openTestBTPSession(t, peer, tt.opts...)
+			defer dev.Disconnect()
+			defer session.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := session.WriteMessage(ctx, []byte{0x01}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := session.ReadMessage(ctx); !errors.Is(err, matter.ErrTimeout) {
+				t.Errorf("expected ErrTimeout, got %v", err)
+			}
+		})
+	}
+}
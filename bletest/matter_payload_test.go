@@ -0,0 +1,190 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-ble/ble"
+	"github.com/cybergarage/go-ble/ble/matter"
+)
+
+func TestMatterSetupPayload(t *testing.T) {
+	tests := []struct {
+		qrCode     string
+		manualCode string
+		expected   matter.SetupPayload
+	}{
+		{
+			qrCode:     "MT:Y.K9042C00KA0648G00",
+			manualCode: "34970112332",
+			expected: matter.SetupPayload{
+				Version:               0,
+				VendorID:              0xFFF1,
+				ProductID:             0x8000,
+				CommissioningFlow:     matter.CommissioningFlowStandard,
+				DiscoveryCapabilities: matter.DiscoveryCapabilityBLE,
+				Discriminator:         3840,
+				HasShortDiscriminator: false,
+				Passcode:              20202021,
+				OptionalData:          nil,
+			},
+		},
+		{
+			qrCode:     "MT:M5L90MP500K64J00000",
+			manualCode: "00204800002",
+			expected: matter.SetupPayload{
+				Version:               0,
+				VendorID:              12,
+				ProductID:             1,
+				CommissioningFlow:     matter.CommissioningFlowStandard,
+				DiscoveryCapabilities: matter.DiscoveryCapabilitySoftAP,
+				Discriminator:         128,
+				HasShortDiscriminator: false,
+				Passcode:              2048,
+				OptionalData:          nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.qrCode, func(t *testing.T) {
+			p, err := matter.ParseSetupPayload(tt.qrCode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.VendorID != tt.expected.VendorID || p.ProductID != tt.expected.ProductID ||
+				p.CommissioningFlow != tt.expected.CommissioningFlow || p.DiscoveryCapabilities != tt.expected.DiscoveryCapabilities ||
+				p.Discriminator != tt.expected.Discriminator || p.Passcode != tt.expected.Passcode {
+				t.Errorf("unexpected payload: %s", p)
+			}
+			if qrCode, err := p.QRCode(); err != nil || qrCode != tt.qrCode {
+				t.Errorf("expected %s, got %s (%v)", tt.qrCode, qrCode, err)
+			}
+			if manualCode, err := p.ManualPairingCode(); err != nil || manualCode != tt.manualCode {
+				t.Errorf("expected %s, got %s (%v)", tt.manualCode, manualCode, err)
+			}
+
+			m, err := matter.ParseSetupPayload(tt.manualCode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !m.HasShortDiscriminator || m.ShortDiscriminator() != p.ShortDiscriminator() || m.Passcode != p.Passcode {
+				t.Errorf("unexpected payload: %s", m)
+			}
+		})
+	}
+
+	t.Run("long manual code", func(t *testing.T) {
+		p := &matter.SetupPayload{
+			Version:               0,
+			VendorID:              0xFFF1,
+			ProductID:             0x8001,
+			CommissioningFlow:     matter.CommissioningFlowCustom,
+			DiscoveryCapabilities: matter.DiscoveryCapabilityBLE,
+			Discriminator:         3840,
+			HasShortDiscriminator: false,
+			Passcode:              20202021,
+			OptionalData:          nil,
+		}
+		code, err := p.ManualPairingCode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(code) != 21 {
+			t.Fatalf("expected 21 digits, got %s", code)
+		}
+		m, err := matter.ParseManualPairingCode(code[:4] + "-" + code[4:11] + " " + code[11:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.VendorID != p.VendorID || m.ProductID != p.ProductID || m.CommissioningFlow != matter.CommissioningFlowCustom {
+			t.Errorf("unexpected payload: %s", m)
+		}
+	})
+
+	t.Run("optional data", func(t *testing.T) {
+		p := &matter.SetupPayload{
+			Version:               0,
+			VendorID:              0xFFF1,
+			ProductID:             0x8000,
+			CommissioningFlow:     matter.CommissioningFlowStandard,
+			DiscoveryCapabilities: matter.DiscoveryCapabilityBLE,
+			Discriminator:         3840,
+			HasShortDiscriminator: false,
+			Passcode:              20202021,
+			OptionalData: []matter.OptionalData{
+				{Tag: matter.OptionalDataTagSerialNumber, Value: "SN-0001"},
+				{Tag: 0x80, Value: uint64(0x1234)},
+				{Tag: 0x81, Value: int64(-2)},
+			},
+		}
+		qrCode, err := p.QRCode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		q, err := matter.ParseQRCode(qrCode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sn, ok := q.SerialNumber(); !ok || sn != "SN-0001" {
+			t.Errorf("unexpected serial number: %s", sn)
+		}
+		if len(q.OptionalData) != 3 || q.OptionalData[1].Value != uint64(0x1234) || q.OptionalData[2].Value != int64(-2) {
+			t.Errorf("unexpected optional data: %v", q.OptionalData)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, s := range []string{
+			"34970112333",           // check digit
+			"3497011233",            // length
+			"MT:Y.K9042C00KA0648G0", // base38 length
+			"MT:Y.K9042C00KA0648G0!",
+			"MT:00000000000000000000", // passcode
+		} {
+			if _, err := matter.ParseSetupPayload(s); !errors.Is(err, ble.ErrInvalid) {
+				t.Errorf("%s: expected ErrInvalid, got %v", s, err)
+			}
+		}
+	})
+}
+
+func TestMatterSetupPayloadScanFilter(t *testing.T) {
+	p, _, _ := newTestMatterPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06})
+
+	tests := []struct {
+		payload  string
+		expected int
+	}{
+		{payload: "MT:-24J0AFN00KA0648G00", expected: 0}, // discriminator 3840
+		{payload: "34970112332", expected: 1},            // short discriminator 15
+		{payload: "00204800002", expected: 0},            // short discriminator 0
+	}
+	for _, tt := range tests {
+		t.Run(tt.payload, func(t *testing.T) {
+			payload, err := matter.ParseSetupPayload(tt.payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			scanner := ble.NewScannerWithBackend(NewSimulator(p))
+			scanOnce(t, scanner, matter.WithSetupPayload(payload))
+			if n := len(scanner.Devices()); n != tt.expected {
+				t.Errorf("expected %d devices, got %d", tt.expected, n)
+			}
+		})
+	}
+}
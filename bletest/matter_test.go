@@ -0,0 +1,89 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-ble/ble"
+	"github.com/cybergarage/go-ble/ble/matter"
+)
+
+func TestMatterServiceData(t *testing.T) {
+	b := []byte{0x00, 0xE4, 0x1F, 0xF1, 0xFF, 0x01, 0x80, 0x03}
+	sd, err := matter.ParseServiceData(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sd.IsCommissionable() {
+		t.Errorf("expected commissionable opcode, got 0x%02X", sd.Opcode)
+	}
+	if sd.Version != 1 {
+		t.Errorf("expected version 1, got %d", sd.Version)
+	}
+	if sd.Discriminator != 4068 {
+		t.Errorf("expected discriminator 4068, got %d", sd.Discriminator)
+	}
+	if sd.ShortDiscriminator() != 0x0F {
+		t.Errorf("expected short discriminator 15, got %d", sd.ShortDiscriminator())
+	}
+	if sd.VendorID != 0xFFF1 || sd.ProductID != 0x8001 {
+		t.Errorf("unexpected vendor/product ID: 0x%04X/0x%04X", sd.VendorID, sd.ProductID)
+	}
+	if !sd.AdditionalData || !sd.ExtendedAnnouncement {
+		t.Errorf("expected additional data and extended announcement flags")
+	}
+	if !bytes.Equal(sd.Bytes(), b) {
+		t.Errorf("expected %X, got %X", b, sd.Bytes())
+	}
+
+	if _, err := matter.ParseServiceData(b[:3]); !errors.Is(err, ble.ErrInvalid) {
+		t.Errorf("expected ErrInvalid, got %v", err)
+	}
+}
+
+func TestMatterScanFilter(t *testing.T) {
+	p, _, _ := newTestMatterPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06})
+	other := NewVirtualPeripheral(ble.Address{0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F})
+
+	tests := []struct {
+		name     string
+		filter   ble.ScanFilter
+		expected int
+	}{
+		{name: "commissionable", filter: matter.WithCommissionable(), expected: 1},
+		{name: "discriminator", filter: matter.WithDiscriminator(4068), expected: 1},
+		{name: "discriminator mismatch", filter: matter.WithDiscriminator(3840), expected: 0},
+		{name: "vendor", filter: matter.WithVendorID(0xFFF1), expected: 1},
+		{name: "product mismatch", filter: matter.WithProductID(0x8000), expected: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := ble.NewScannerWithBackend(NewSimulator(p, other))
+			handled := 0
+			scanOnce(t, scanner, tt.filter, ble.ScanHandler(func(dev ble.Device) {
+				handled++
+			}))
+			if n := len(scanner.Devices()); n != tt.expected {
+				t.Errorf("expected %d devices, got %d", tt.expected, n)
+			}
+			if handled != tt.expected {
+				t.Errorf("expected %d handled advertisements, got %d", tt.expected, handled)
+			}
+		})
+	}
+}
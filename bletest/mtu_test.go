@@ -0,0 +1,165 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func connectTestDevice(t *testing.T, p VirtualPeripheral, opts ...ble.ConnectOption) ble.Device {
+	t.Helper()
+	central := ble.NewCentralWithBackend(NewSimulator(p))
+	scanOnce(t, central)
+	dev := central.Devices()[0]
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := central.Connect(ctx, dev, opts...); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dev.Disconnect() })
+	return dev
+}
+
+func TestMTU(t *testing.T) {
+	addr := ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}
+
+	t.Run("negotiation", func(t *testing.T) {
+		tests := []struct {
+			peripheralOpts []VirtualPeripheralOption
+			connectOpts    []ble.ConnectOption
+			expected       int
+		}{
+			{nil, nil, ble.MaxATTMTU},
+			{nil, []ble.ConnectOption{ble.WithConnectMTU(185)}, 185},
+			{[]VirtualPeripheralOption{WithMTU(100)}, nil, 100},
+			{[]VirtualPeripheralOption{WithMTU(100)}, []ble.ConnectOption{ble.WithConnectMTU(247)}, 100},
+		}
+		for _, test := range tests {
+			p := NewVirtualPeripheral(addr, test.peripheralOpts...)
+			central := ble.NewCentralWithBackend(NewSimulator(p))
+			scanOnce(t, central)
+			dev := central.Devices()[0]
+			if dev.MTU() != ble.DefaultATTMTU {
+				t.Errorf("expected MTU %d before connecting, got %d", ble.DefaultATTMTU, dev.MTU())
+			}
+			if err := central.Connect(context.Background(), dev, test.connectOpts...); err != nil {
+				t.Fatal(err)
+			}
+			if dev.MTU() != test.expected {
+				t.Errorf("expected MTU %d, got %d", test.expected, dev.MTU())
+			}
+			dev.Disconnect()
+		}
+	})
+
+	var mutex sync.Mutex
+	writes := [][]byte{}
+	value := make([]byte, 100)
+	for n := range value {
+		value[n] = byte(n)
+	}
+	char := NewVirtualCharacteristic(testMatterC1UUID,
+		WithCharacteristicValue(value),
+		WithCharacteristicReadable(),
+		WithCharacteristicWritable(),
+		WithCharacteristicWriteHandler(func(char VirtualCharacteristic, data []byte) {
+			mutex.Lock()
+			writes = append(writes, data)
+			mutex.Unlock()
+		}),
+	)
+	p := NewVirtualPeripheral(addr,
+		WithMTU(ble.DefaultATTMTU),
+		WithServices(NewVirtualService(testMatterServiceUUID, char)),
+	)
+	dev := connectTestDevice(t, p)
+	service, ok := dev.LookupService(testMatterServiceUUID)
+	if !ok {
+		t.Fatalf("expected discovered service %s", testMatterServiceUUID)
+	}
+	c1, ok := service.LookupCharacteristic(testMatterC1UUID)
+	if !ok {
+		t.Fatalf("expected characteristic %s", testMatterC1UUID)
+	}
+	takeWrites := func() [][]byte {
+		mutex.Lock()
+		defer mutex.Unlock()
+		w := writes
+		writes = [][]byte{}
+		return w
+	}
+
+	t.Run("default link", func(t *testing.T) {
+		// The reported MTU includes the ATT header, so a default link leaves 20 bytes for the value.
+		if dev.MTU() != ble.DefaultATTMTU {
+			t.Errorf("expected MTU %d, got %d", ble.DefaultATTMTU, dev.MTU())
+		}
+	})
+
+	t.Run("long read", func(t *testing.T) {
+		b, err := c1.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, value) {
+			t.Errorf("expected %X, got %X", value, b)
+		}
+	})
+
+	t.Run("long write", func(t *testing.T) {
+		data := bytes.Repeat([]byte{0xAB}, 100)
+		if n, err := c1.Write(data); err != nil || n != len(data) {
+			t.Fatalf("expected %d bytes written, got %d %v", len(data), n, err)
+		}
+		if w := takeWrites(); len(w) != 1 || !bytes.Equal(w[0], data) {
+			t.Errorf("expected a single long write, got %d writes", len(w))
+		}
+		if _, err := c1.Write(make([]byte, ble.MaxAttributeValueSize+1)); !errors.Is(err, ble.ErrInvalid) {
+			t.Errorf("expected ErrInvalid, got %v", err)
+		}
+	})
+
+	t.Run("write without response", func(t *testing.T) {
+		data := bytes.Repeat([]byte{0xCD}, 50)
+		if n, err := c1.WriteWithoutResponse(data); err != nil || n != len(data) {
+			t.Fatalf("expected %d bytes written, got %d %v", len(data), n, err)
+		}
+		w := takeWrites()
+		if len(w) != 3 || len(w[0]) != 20 || len(w[1]) != 20 || len(w[2]) != 10 {
+			t.Errorf("expected writes of 20, 20 and 10 bytes, got %d writes", len(w))
+		}
+	})
+
+	t.Run("transport", func(t *testing.T) {
+		transport, err := service.Open(ble.WithTransportWriteUUID(testMatterC1UUID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer transport.Close()
+		if _, err := transport.Write(context.Background(), make([]byte, 45)); err != nil {
+			t.Fatal(err)
+		}
+		if w := takeWrites(); len(w) != 3 {
+			t.Errorf("expected 3 writes, got %d", len(w))
+		}
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestPeripheral(t *testing.T) {
+	serviceUUID := ble.NewUUIDFromUUID16(0x180F)
+	levelUUID := ble.NewUUIDFromUUID16(0x2A19)
+	cmdUUID := ble.MustUUIDFromString("7E1A0001-0000-1000-8000-00805F9B34FB")
+
+	written := [][]byte{}
+	rejected := []error{}
+	level := ble.NewLocalCharacteristic(levelUUID,
+		ble.WithLocalCharacteristicProperties(ble.CharacteristicPropertyRead|ble.CharacteristicPropertyNotify),
+		ble.WithLocalCharacteristicValue([]byte{100}),
+		ble.WithLocalCharacteristicWriteErrorHandler(func(char ble.LocalCharacteristic, err error) {
+			rejected = append(rejected, err)
+		}),
+	)
+	cmd := ble.NewLocalCharacteristic(cmdUUID,
+		ble.WithLocalCharacteristicProperties(ble.CharacteristicPropertyWrite),
+		ble.WithLocalCharacteristicWriteHandler(func(char ble.LocalCharacteristic, data []byte) {
+			written = append(written, data)
+		}),
+	)
+	counter := byte(0)
+	dynamic := ble.NewLocalCharacteristic(ble.NewUUIDFromUUID16(0x2A6E),
+		ble.WithLocalCharacteristicProperties(ble.CharacteristicPropertyRead),
+		ble.WithLocalCharacteristicReadHandler(func(char ble.LocalCharacteristic) ([]byte, error) {
+			counter++
+			return []byte{counter}, nil
+		}),
+	)
+	service := ble.NewLocalService(serviceUUID, level, cmd, dynamic)
+
+	sim := NewSimulator()
+	peripheral := ble.NewPeripheralWithBackend(sim)
+	if err := peripheral.AddService(service); err != nil {
+		t.Fatal(err)
+	}
+	if err := peripheral.AddService(service); err == nil {
+		t.Errorf("expected duplicate service to be rejected")
+	}
+	if _, ok := peripheral.LookupService(0x180F); !ok {
+		t.Errorf("expected service %s", serviceUUID)
+	}
+	if len(sim.LocalServices()) != 1 {
+		t.Errorf("expected 1 registered service, got %d", len(sim.LocalServices()))
+	}
+	if level.Service() != service {
+		t.Errorf("expected characteristic to belong to the service")
+	}
+	if level.Name() != "Battery Level" {
+		t.Errorf("expected name 'Battery Level', got '%s'", level.Name())
+	}
+
+	t.Run("Read", func(t *testing.T) {
+		value, err := level.ReadValue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(value, []byte{100}) {
+			t.Errorf("expected 64, got %X", value)
+		}
+		for n := 1; n <= 2; n++ {
+			value, err := dynamic.ReadValue()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(value, []byte{byte(n)}) {
+				t.Errorf("expected %02X, got %X", n, value)
+			}
+		}
+		if _, err := cmd.ReadValue(); !errors.Is(err, ble.ErrNotPermitted) {
+			t.Errorf("expected %v, got %v", ble.ErrNotPermitted, err)
+		}
+	})
+
+	t.Run("Write", func(t *testing.T) {
+		if err := cmd.WriteValue(0, []byte{0x01, 0x02}); err != nil {
+			t.Fatal(err)
+		}
+		if err := cmd.WriteValue(1, []byte{0x03, 0x04}); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(cmd.Value(), []byte{0x01, 0x03, 0x04}) {
+			t.Errorf("expected 010304, got %X", cmd.Value())
+		}
+		if len(written) != 2 {
+			t.Errorf("expected 2 write events, got %d", len(written))
+		}
+		if err := cmd.WriteValue(10, []byte{0x00}); !errors.Is(err, ble.ErrInvalid) {
+			t.Errorf("expected %v, got %v", ble.ErrInvalid, err)
+		}
+		if err := level.WriteValue(0, []byte{0x00}); !errors.Is(err, ble.ErrNotPermitted) {
+			t.Errorf("expected %v, got %v", ble.ErrNotPermitted, err)
+		}
+		if len(rejected) != 1 || !errors.Is(rejected[0], ble.ErrNotPermitted) {
+			t.Errorf("expected the rejected write to be reported, got %v", rejected)
+		}
+	})
+
+	t.Run("Notify", func(t *testing.T) {
+		notified := [][]byte{}
+		err := sim.SubscribeLocalCharacteristic(levelUUID, func(data []byte) {
+			notified = append(notified, data)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := level.Notify([]byte{42}); err != nil {
+			t.Fatal(err)
+		}
+		if len(notified) != 1 || !bytes.Equal(notified[0], []byte{42}) {
+			t.Errorf("expected notification 2A, got %X", notified)
+		}
+		if !bytes.Equal(level.Value(), []byte{42}) {
+			t.Errorf("expected value 2A, got %X", level.Value())
+		}
+		if err := cmd.Notify([]byte{0x00}); !errors.Is(err, ble.ErrNotPermitted) {
+			t.Errorf("expected %v, got %v", ble.ErrNotPermitted, err)
+		}
+	})
+}
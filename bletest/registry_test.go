@@ -0,0 +1,186 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestScannerConcurrentDevices(t *testing.T) {
+	const numPeripherals = 16
+	peripherals := make([]VirtualPeripheral, 0, numPeripherals)
+	for n := range numPeripherals {
+		peripherals = append(peripherals, NewVirtualPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, byte(n)},
+			WithServiceData(testMatterServiceUUID, []byte{byte(n)}),
+		))
+	}
+	scanner := ble.NewScannerWithBackend(NewSimulator(peripherals...))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	// Scans run alongside readers of the registry such as HTTP handlers.
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range 50 {
+				for _, p := range peripherals {
+					p.SetRSSI(-40 - n)
+					p.AddServiceData(ble.NewUUIDFromUUID16(uint16(0xFE00+n%4)), []byte{byte(n)})
+				}
+				// Only one scan runs at a time and the other is rejected.
+				if err := scanner.Scan(ctx); err != nil && !errors.Is(err, ble.ErrBusy) {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				for _, dev := range scanner.Devices() {
+					_ = dev.RSSI()
+					_ = dev.LastSeenAt()
+					_ = dev.ModifiedAt()
+					_ = dev.Advertisement()
+					_ = dev.Services()
+					_, _ = dev.LookupService(testMatterServiceUUID)
+					_ = dev.String()
+				}
+			}
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(done)
+	wg.Wait()
+
+	scanOnce(t, scanner)
+	devs := scanner.Devices()
+	if len(devs) != numPeripherals {
+		t.Fatalf("expected %d devices, got %d", numPeripherals, len(devs))
+	}
+	for _, dev := range devs {
+		if dev.LastSeenAt().Before(dev.DiscoveredAt()) {
+			t.Errorf("expected the device to be seen after it was discovered")
+		}
+		if len(dev.Services()) < 2 {
+			t.Errorf("expected merged services, got %d", len(dev.Services()))
+		}
+	}
+}
+
+func TestScannerRegistryBounds(t *testing.T) {
+	peripherals := make([]VirtualPeripheral, 0, 4)
+	for n := range 4 {
+		peripherals = append(peripherals, NewVirtualPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, byte(n)}))
+	}
+	sim := NewSimulator(peripherals...)
+	scanner := ble.NewScannerWithBackend(sim)
+
+	hasDevice := func(p VirtualPeripheral) bool {
+		for _, dev := range scanner.Devices() {
+			if dev.Address().String() == p.Address().String() {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("max devices", func(t *testing.T) {
+		scanOnce(t, scanner, ble.MaxDevices(2))
+		if n := len(scanner.Devices()); n != 2 {
+			t.Fatalf("expected 2 devices, got %d", n)
+		}
+		// The devices which advertised least recently are evicted.
+		if !hasDevice(peripherals[2]) || !hasDevice(peripherals[3]) {
+			t.Errorf("expected the most recently seen devices to be kept")
+		}
+	})
+
+	t.Run("forget", func(t *testing.T) {
+		if !scanner.Forget(peripherals[3].Address()) {
+			t.Errorf("expected the device to be forgotten")
+		}
+		if scanner.Forget(peripherals[3].Address()) {
+			t.Errorf("expected the forgotten device to be unknown")
+		}
+		if n := len(scanner.Devices()); n != 1 {
+			t.Errorf("expected 1 device, got %d", n)
+		}
+		scanner.Clear()
+		if n := len(scanner.Devices()); n != 0 {
+			t.Errorf("expected no devices, got %d", n)
+		}
+	})
+
+	t.Run("max age", func(t *testing.T) {
+		sim.SetAdvertisingInterval(5 * time.Millisecond)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := scanner.StartScan(ctx, ble.DeviceMaxAge(50*time.Millisecond)); err != nil {
+			t.Fatal(err)
+		}
+		defer scanner.StopScan()
+
+		waitDevices := func(t *testing.T, n int) {
+			t.Helper()
+			deadline := time.Now().Add(time.Second)
+			for len(scanner.Devices()) != n {
+				if deadline.Before(time.Now()) {
+					t.Fatalf("expected %d devices, got %d", n, len(scanner.Devices()))
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}
+		waitDevices(t, 4)
+
+		// A connected peripheral stops advertising but its device is kept.
+		var connected ble.Device
+		for _, dev := range scanner.Devices() {
+			if dev.Address().String() == peripherals[0].Address().String() {
+				connected = dev
+			}
+		}
+		if err := connected.Connect(ctx); err != nil {
+			t.Fatal(err)
+		}
+		defer connected.Disconnect()
+		sim.RemovePeripheral(peripherals[0])
+		sim.RemovePeripheral(peripherals[1])
+		waitDevices(t, 3)
+		time.Sleep(100 * time.Millisecond)
+		if !hasDevice(peripherals[0]) || hasDevice(peripherals[1]) {
+			t.Errorf("expected only the connected device to be kept")
+		}
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestStartScan(t *testing.T) {
+	p := NewVirtualPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06})
+	sim := NewSimulator(p)
+	// The peripheral advertises once and the air stays quiet afterwards.
+	sim.SetAdvertisingInterval(time.Hour)
+	scanner := ble.NewScannerWithBackend(sim)
+
+	waitScanStopped := func(t *testing.T, timeout time.Duration) {
+		t.Helper()
+		deadline := time.Now().Add(timeout)
+		for scanner.IsScanning() {
+			if deadline.Before(time.Now()) {
+				t.Fatalf("expected the scan to stop within %s", timeout)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	t.Run("stop", func(t *testing.T) {
+		found := make(chan ble.Device, 1)
+		err := scanner.StartScan(context.Background(), ble.ScanHandler(func(dev ble.Device) {
+			found <- dev
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !scanner.IsScanning() {
+			t.Errorf("expected the scanner to be scanning")
+		}
+		if err := scanner.StartScan(context.Background()); !errors.Is(err, ble.ErrBusy) {
+			t.Errorf("expected ErrBusy, got %v", err)
+		}
+		select {
+		case <-found:
+		case <-time.After(time.Second):
+			t.Fatalf("expected the device to be found")
+		}
+		if err := scanner.StopScan(); err != nil {
+			t.Fatal(err)
+		}
+		if scanner.IsScanning() {
+			t.Errorf("expected the scanner to be stopped")
+		}
+		if err := scanner.StopScan(); err != nil {
+			t.Errorf("expected stopping a stopped scanner to succeed, got %v", err)
+		}
+	})
+
+	t.Run("context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		if err := scanner.StartScan(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if 50*time.Millisecond <= time.Since(start) {
+			t.Errorf("expected StartScan to return immediately")
+		}
+		waitScanStopped(t, time.Second)
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		if err := scanner.Scan(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if elapsed := time.Since(start); time.Second <= elapsed {
+			t.Errorf("expected Scan to return after the deadline without advertisements, took %s", elapsed)
+		}
+		if scanner.IsScanning() {
+			t.Errorf("expected the scanner to be stopped")
+		}
+	})
+
+	if n := len(scanner.Devices()); n != 1 {
+		t.Errorf("expected 1 device, got %d", n)
+	}
+}
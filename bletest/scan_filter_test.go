@@ -0,0 +1,87 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestScanFilter(t *testing.T) {
+	matterAddr := ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}
+	sensorAddr := ble.Address{0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F}
+	batteryUUID := ble.NewUUIDFromUUID16(0x180F)
+
+	p, _, _ := newTestMatterPeripheral(matterAddr)
+	sensor := NewVirtualPeripheral(sensorAddr,
+		WithLocalName("sensor-01"),
+		WithRSSI(-80),
+		WithServiceUUIDs(batteryUUID),
+		WithManufacturerData(0x0059, []byte{0x01}),
+	)
+	other := NewVirtualPeripheral(ble.Address{0x10, 0x20, 0x30, 0x40, 0x50, 0x60}, WithRSSI(-40))
+
+	tests := []struct {
+		name     string
+		filter   ble.ScanFilter
+		expected int
+	}{
+		{name: "service data UUID", filter: ble.WithScanServiceUUID(0xFFF6), expected: 1},
+		{name: "service UUID", filter: ble.WithScanServiceUUID(batteryUUID), expected: 1},
+		{name: "invalid service UUID", filter: ble.WithScanServiceUUID(1.0), expected: 0},
+		{name: "company ID", filter: ble.WithScanCompanyID(0x0059), expected: 1},
+		{name: "company ID mismatch", filter: ble.WithScanCompanyID(0x0001), expected: 0},
+		{name: "name prefix", filter: ble.WithScanLocalNamePrefix("matter-"), expected: 1},
+		{name: "name regexp", filter: ble.WithScanLocalNameRegexp(regexp.MustCompile(`-(test|\d+)$`)), expected: 2},
+		{name: "addresses", filter: ble.WithScanAddresses(matterAddr, sensorAddr), expected: 2},
+		{name: "min RSSI", filter: ble.WithScanMinRSSI(-60), expected: 2},
+		{name: "predicate", filter: ble.WithScanPredicate(func(dev ble.Device) bool { return dev.LocalName() == "" }), expected: 1},
+		{
+			name: "all of",
+			filter: ble.WithScanFiltersAll(
+				ble.WithScanMinRSSI(-60),
+				ble.WithScanLocalNamePrefix("matter-"),
+			),
+			expected: 1,
+		},
+		{
+			name: "any of",
+			filter: ble.WithScanFiltersAny(
+				ble.WithScanServiceUUID(0xFFF6),
+				ble.WithScanCompanyID(0x0059),
+			),
+			expected: 2,
+		},
+		{name: "empty all of", filter: ble.WithScanFiltersAll(), expected: 3},
+		{name: "empty any of", filter: ble.WithScanFiltersAny(), expected: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner := ble.NewScannerWithBackend(NewSimulator(p, sensor, other))
+			handled := 0
+			scanOnce(t, scanner, tt.filter, ble.ScanHandler(func(dev ble.Device) {
+				handled++
+			}))
+			if n := len(scanner.Devices()); n != tt.expected {
+				t.Errorf("expected %d devices, got %d", tt.expected, n)
+			}
+			if handled != tt.expected {
+				t.Errorf("expected %d handled advertisements, got %d", tt.expected, handled)
+			}
+		})
+	}
+}
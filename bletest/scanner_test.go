@@ -31,6 +31,7 @@ func TestScanner(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	err := scanner.Scan(ctx,
+		ble.WithScanServiceUUID(0xFFF6),
 		ble.ScanHandler(func(dev ble.Device) {
 			// log.Infof("Device found: dev=%s", dev.String())
 		}))
@@ -40,10 +41,6 @@ func TestScanner(t *testing.T) {
 
 	log.Infof("Discovered devices:")
 	for n, dev := range scanner.Devices() {
-		if _, ok := dev.LookupService(0xFFF6); !ok {
-			// skip devices without the target service
-			continue
-		}
 		log.Infof("[%d] %s", n, dev.String())
 	}
 }
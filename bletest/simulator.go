@@ -0,0 +1,288 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+// Simulator represents an in-memory Bluetooth backend which serves virtual peripherals.
+// The simulator also acts as a peripheral backend and a virtual central for local services.
+type Simulator interface {
+	ble.Backend
+	ble.PeripheralBackend
+	ble.AdvertiserBackend
+	// AddPeripheral adds a virtual peripheral to the simulator.
+	AddPeripheral(p VirtualPeripheral)
+	// RemovePeripheral removes a virtual peripheral from the simulator, which stops advertising it.
+	RemovePeripheral(p VirtualPeripheral)
+	// Peripherals returns the virtual peripherals of the simulator.
+	Peripherals() []VirtualPeripheral
+	// LocalServices returns the local services registered by a peripheral.
+	LocalServices() []ble.LocalService
+	// SubscribeLocalCharacteristic subscribes to notifications of the registered local characteristic as a virtual central.
+	SubscribeLocalCharacteristic(uuid ble.UUID, callback func([]byte)) error
+	// Advertisement returns the advertising data and parameters of the running advertisement.
+	Advertisement() (ble.AdvertisingData, ble.AdvertisingParameters, bool)
+	// SetAdvertisingInterval sets the interval at which the virtual peripherals advertise again until the scan is stopped.
+	// With the zero interval, which is the default, a scan delivers one advertisement of each peripheral and returns.
+	SetAdvertisingInterval(interval time.Duration)
+}
+
+type simulator struct {
+	sync.Mutex
+	peripherals   []*virtualPeripheral
+	scanning      bool
+	scanStop      chan struct{}
+	advInterval   time.Duration
+	localServices []ble.LocalService
+	localChars    map[ble.UUID]*simulatorLocalCharacteristic
+	advData       ble.AdvertisingData
+	advParams     ble.AdvertisingParameters
+}
+
+// NewSimulator returns a new simulator with the specified virtual peripherals.
+func NewSimulator(peripherals ...VirtualPeripheral) Simulator {
+	sim := &simulator{
+		Mutex:         sync.Mutex{},
+		peripherals:   []*virtualPeripheral{},
+		scanning:      false,
+		scanStop:      nil,
+		advInterval:   0,
+		localServices: []ble.LocalService{},
+		localChars:    map[ble.UUID]*simulatorLocalCharacteristic{},
+		advData:       nil,
+		advParams:     ble.AdvertisingParameters{}, // nolint: exhaustruct
+	}
+	for _, p := range peripherals {
+		sim.AddPeripheral(p)
+	}
+	return sim
+}
+
+// AddPeripheral adds a virtual peripheral to the simulator.
+func (sim *simulator) AddPeripheral(p VirtualPeripheral) {
+	vp, ok := p.(*virtualPeripheral)
+	if !ok {
+		return
+	}
+	sim.Lock()
+	defer sim.Unlock()
+	sim.peripherals = append(sim.peripherals, vp)
+}
+
+// RemovePeripheral removes a virtual peripheral from the simulator, which stops advertising it.
+func (sim *simulator) RemovePeripheral(p VirtualPeripheral) {
+	sim.Lock()
+	defer sim.Unlock()
+	peripherals := make([]*virtualPeripheral, 0, len(sim.peripherals))
+	for _, vp := range sim.peripherals {
+		if vp != p {
+			peripherals = append(peripherals, vp)
+		}
+	}
+	sim.peripherals = peripherals
+}
+
+// Peripherals returns the virtual peripherals of the simulator.
+func (sim *simulator) Peripherals() []VirtualPeripheral {
+	sim.Lock()
+	defer sim.Unlock()
+	peripherals := make([]VirtualPeripheral, 0, len(sim.peripherals))
+	for _, p := range sim.peripherals {
+		peripherals = append(peripherals, p)
+	}
+	return peripherals
+}
+
+// Enable enables the Bluetooth adapter.
+func (sim *simulator) Enable() error {
+	return nil
+}
+
+// SetAdvertisingInterval sets the interval at which the virtual peripherals advertise again until the scan is stopped.
+// With the zero interval, which is the default, a scan delivers one advertisement of each peripheral and returns.
+func (sim *simulator) SetAdvertisingInterval(interval time.Duration) {
+	sim.Lock()
+	defer sim.Unlock()
+	sim.advInterval = interval
+}
+
+// Scan delivers one advertisement of each virtual peripheral to the handler in the order they were added,
+// and repeats it at the advertising interval until StopScan is called if the interval is set.
+func (sim *simulator) Scan(handler ble.BackendScanHandler) error {
+	sim.Lock()
+	sim.scanning = true
+	stop := make(chan struct{})
+	sim.scanStop = stop
+	interval := sim.advInterval
+	sim.Unlock()
+
+	defer func() {
+		sim.Lock()
+		sim.scanning = false
+		sim.scanStop = nil
+		sim.Unlock()
+	}()
+
+	for {
+		sim.Lock()
+		peripherals := append([]*virtualPeripheral{}, sim.peripherals...)
+		sim.Unlock()
+		for _, p := range peripherals {
+			if !sim.isScanning() {
+				return nil
+			}
+			if handler != nil {
+				handler(p.scanResult())
+			}
+		}
+		if interval <= 0 {
+			return nil
+		}
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// StopScan stops scanning.
+func (sim *simulator) StopScan() error {
+	sim.Lock()
+	defer sim.Unlock()
+	sim.scanning = false
+	if sim.scanStop != nil {
+		close(sim.scanStop)
+		sim.scanStop = nil
+	}
+	return nil
+}
+
+// Connect connects to the virtual peripheral with the specified address using the parameters.
+func (sim *simulator) Connect(ctx context.Context, addr ble.Address, params ble.ConnectionParameters) (ble.BackendConnection, error) {
+	p, ok := sim.lookupPeripheral(addr)
+	if !ok {
+		return nil, fmt.Errorf("peripheral %w: %s", ble.ErrNotFound, addr)
+	}
+	return p.connect(params)
+}
+
+func (sim *simulator) isScanning() bool {
+	sim.Lock()
+	defer sim.Unlock()
+	return sim.scanning
+}
+
+func (sim *simulator) lookupPeripheral(addr ble.Address) (*virtualPeripheral, bool) {
+	sim.Lock()
+	defer sim.Unlock()
+	for _, p := range sim.peripherals {
+		if p.addr.String() == addr.String() {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// AddService registers the local service and returns a backend handle for each characteristic.
+func (sim *simulator) AddService(service ble.LocalService) ([]ble.BackendLocalCharacteristic, error) {
+	sim.Lock()
+	defer sim.Unlock()
+	chars := service.Characteristics()
+	backendChars := make([]ble.BackendLocalCharacteristic, 0, len(chars))
+	for _, char := range chars {
+		localChar := &simulatorLocalCharacteristic{
+			Mutex:      sync.Mutex{},
+			notifyFunc: nil,
+		}
+		sim.localChars[char.UUID()] = localChar
+		backendChars = append(backendChars, localChar)
+	}
+	sim.localServices = append(sim.localServices, service)
+	return backendChars, nil
+}
+
+// LocalServices returns the local services registered by a peripheral.
+func (sim *simulator) LocalServices() []ble.LocalService {
+	sim.Lock()
+	defer sim.Unlock()
+	return append([]ble.LocalService{}, sim.localServices...)
+}
+
+// SubscribeLocalCharacteristic subscribes to notifications of the registered local characteristic as a virtual central.
+func (sim *simulator) SubscribeLocalCharacteristic(uuid ble.UUID, callback func([]byte)) error {
+	sim.Lock()
+	localChar, ok := sim.localChars[uuid]
+	sim.Unlock()
+	if !ok {
+		return fmt.Errorf("characteristic %w: %s", ble.ErrNotFound, uuid)
+	}
+	localChar.Lock()
+	defer localChar.Unlock()
+	localChar.notifyFunc = callback
+	return nil
+}
+
+// StartAdvertising starts advertising the specified advertising data with the parameters.
+func (sim *simulator) StartAdvertising(data ble.AdvertisingData, params ble.AdvertisingParameters) error {
+	sim.Lock()
+	defer sim.Unlock()
+	sim.advData = data
+	sim.advParams = params
+	return nil
+}
+
+// StopAdvertising stops advertising.
+func (sim *simulator) StopAdvertising() error {
+	sim.Lock()
+	defer sim.Unlock()
+	sim.advData = nil
+	return nil
+}
+
+// Advertisement returns the advertising data and parameters of the running advertisement.
+func (sim *simulator) Advertisement() (ble.AdvertisingData, ble.AdvertisingParameters, bool) {
+	sim.Lock()
+	defer sim.Unlock()
+	return sim.advData, sim.advParams, sim.advData != nil
+}
+
+type simulatorLocalCharacteristic struct {
+	sync.Mutex
+	notifyFunc func([]byte)
+}
+
+// SetValue updates the characteristic value served by the backend.
+func (char *simulatorLocalCharacteristic) SetValue(data []byte) error {
+	return nil
+}
+
+// Notify sends the characteristic value to the subscribed virtual central.
+func (char *simulatorLocalCharacteristic) Notify(data []byte) error {
+	char.Lock()
+	notifyFunc := char.notifyFunc
+	char.Unlock()
+	if notifyFunc != nil {
+		notifyFunc(copyBytes(data))
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+var (
+	testMatterServiceUUID = ble.NewUUIDFromUUID16(0xFFF6)
+	testMatterC1UUID      = ble.MustUUIDFromString("18EE2EF5-263D-4559-959F-4F9C429F9D11")
+	testMatterC2UUID      = ble.MustUUIDFromString("18EE2EF5-263D-4559-959F-4F9C429F9D12")
+)
+
+func newTestMatterPeripheral(addr ble.Address) (VirtualPeripheral, VirtualCharacteristic, VirtualCharacteristic) {
+	c2 := NewVirtualCharacteristic(testMatterC2UUID,
+		WithCharacteristicReadable(),
+		WithCharacteristicNotifying(),
+	)
+	echo := func(char VirtualCharacteristic, data []byte) {
+		c2.NotifyValue(data)
+	}
+	c1 := NewVirtualCharacteristic(testMatterC1UUID,
+		WithCharacteristicWritable(),
+		WithCharacteristicWriteHandler(echo),
+	)
+	p := NewVirtualPeripheral(addr,
+		WithLocalName("matter-test"),
+		WithServiceData(testMatterServiceUUID, []byte{0x00, 0xE4, 0x0F, 0xF1, 0xFF, 0x01, 0x80, 0x00}),
+		WithServices(NewVirtualService(testMatterServiceUUID, c1, c2)),
+	)
+	return p, c1, c2
+}
+
+func scanOnce(t *testing.T, scanner ble.Scanner, opts ...ble.ScannerOption) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := scanner.Scan(ctx, opts...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSimulatorScan(t *testing.T) {
+	addr := ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}
+	p := NewVirtualPeripheral(addr,
+		WithLocalName("sim"),
+		WithRSSI(-70),
+		WithManufacturerData(0x0001, []byte{0x01}),
+		WithManufacturerData(0x0059, []byte{0x02}),
+	)
+	other := NewVirtualPeripheral(ble.Address{0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F})
+	scanner := ble.NewScannerWithBackend(NewSimulator(p, other))
+
+	handled := 0
+	scanOnce(t, scanner, ble.ScanHandler(func(dev ble.Device) {
+		handled++
+	}))
+	if handled != 2 {
+		t.Errorf("expected 2 handled advertisements, got %d", handled)
+	}
+	if devs := scanner.Devices(); len(devs) != 2 {
+		t.Fatalf("expected 2 devices, got %d", len(devs))
+	}
+
+	// A later advertisement of the same device is merged into the discovered device.
+	p.SetRSSI(-40)
+	p.AddServiceData(testMatterServiceUUID, []byte{0x00})
+	scanOnce(t, scanner)
+
+	devs := scanner.Devices()
+	if len(devs) != 2 {
+		t.Fatalf("expected 2 devices, got %d", len(devs))
+	}
+	for _, dev := range devs {
+		if dev.Address().String() != addr.String() {
+			continue
+		}
+		if dev.LocalName() != "sim" {
+			t.Errorf("expected local name 'sim', got '%s'", dev.LocalName())
+		}
+		if dev.RSSI() != -40 {
+			t.Errorf("expected RSSI -40, got %d", dev.RSSI())
+		}
+		if dev.Manufacturer().ID() != 0x0059 {
+			t.Errorf("expected manufacturer 0x0059, got 0x%04X", dev.Manufacturer().ID())
+		}
+		if n := len(dev.Manufacturers()); n != 2 {
+			t.Errorf("expected 2 manufacturers, got %d", n)
+		}
+		if m, ok := dev.LookupManufacturer(0x0059); !ok || !bytes.Equal(m.Data(), []byte{0x02}) {
+			t.Errorf("expected manufacturer 0x0059")
+		}
+		if _, ok := dev.LookupManufacturer(0xFFFF); ok {
+			t.Errorf("expected manufacturer 0xFFFF to not be found")
+		}
+		if _, ok := dev.LookupService(testMatterServiceUUID); !ok {
+			t.Errorf("expected merged service %s", testMatterServiceUUID)
+		}
+	}
+}
+
+func TestSimulatorLookupService(t *testing.T) {
+	p, _, _ := newTestMatterPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06})
+	central := ble.NewCentralWithBackend(NewSimulator(p))
+	scanOnce(t, central)
+
+	devs := central.Devices()
+	if len(devs) != 1 {
+		t.Fatalf("expected 1 device, got %d", len(devs))
+	}
+	dev := devs[0]
+
+	// Before connecting, only the advertised service data is available.
+	service, ok := dev.LookupService(0xFFF6)
+	if !ok {
+		t.Fatalf("expected advertised service %s", testMatterServiceUUID)
+	}
+	if len(service.Data()) != 8 {
+		t.Errorf("expected 8 bytes of service data, got %d", len(service.Data()))
+	}
+	if len(service.Characteristics()) != 0 {
+		t.Errorf("expected no characteristics, got %d", len(service.Characteristics()))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := central.Connect(ctx, dev); err != nil {
+		t.Fatal(err)
+	}
+	if !p.IsConnected() {
+		t.Errorf("expected peripheral to be connected")
+	}
+
+	// After connecting, the service is discovered with the characteristics.
+	service, ok = dev.LookupService(0xFFF6)
+	if !ok {
+		t.Fatalf("expected discovered service %s", testMatterServiceUUID)
+	}
+	if len(service.Data()) != 8 {
+		t.Errorf("expected advertised service data to be kept, got %d bytes", len(service.Data()))
+	}
+	for _, uuid := range []ble.UUID{testMatterC1UUID, testMatterC2UUID} {
+		if _, ok := service.LookupCharacteristic(uuid); !ok {
+			t.Errorf("expected characteristic %s", uuid)
+		}
+	}
+
+	if err := dev.Disconnect(); err != nil {
+		t.Fatal(err)
+	}
+	if p.IsConnected() {
+		t.Errorf("expected peripheral to be disconnected")
+	}
+}
+
+func TestSimulatorTransport(t *testing.T) {
+	p, c1, c2 := newTestMatterPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06})
+	central := ble.NewCentralWithBackend(NewSimulator(p))
+	scanOnce(t, central)
+
+	dev := central.Devices()[0]
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := central.Connect(ctx, dev); err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Disconnect()
+
+	service, ok := dev.LookupService(0xFFF6)
+	if !ok {
+		t.Fatalf("expected discovered service %s", testMatterServiceUUID)
+	}
+	transport, err := service.Open(
+		ble.WithTransportWriteUUID(testMatterC1UUID),
+		ble.WithTransportNotifyUUID(testMatterC2UUID),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer transport.Close()
+
+	if !c2.IsSubscribed() {
+		t.Errorf("expected notifications to be enabled")
+	}
+
+	msgs := [][]byte{{0x65, 0x6C, 0x00}, {0x01, 0x02, 0x03, 0x04}}
+	for _, msg := range msgs {
+		if _, err := transport.Write(ctx, msg); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(c1.Value(), msg) {
+			t.Errorf("expected written value %X, got %X", msg, c1.Value())
+		}
+	}
+	for _, msg := range msgs {
+		b, err := transport.Read(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, msg) {
+			t.Errorf("expected notification %X, got %X", msg, b)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestSnapshot(t *testing.T) {
+	batteryUUID := ble.NewUUIDFromUUID16(0x180F)
+	batteryLevelUUID := ble.NewUUIDFromUUID16(0x2A19)
+	userDescUUID := ble.NewUUIDFromUUID16(0x2901)
+
+	c1 := NewVirtualCharacteristic(testMatterC1UUID, WithCharacteristicWritable())
+	c2 := NewVirtualCharacteristic(testMatterC2UUID,
+		WithCharacteristicReadable(),
+		WithCharacteristicNotifying(),
+		WithCharacteristicValue([]byte{0x01, 0x02}),
+		WithCharacteristicDescriptors(NewVirtualDescriptor(userDescUUID, WithDescriptorValue([]byte("c2")))),
+	)
+	battery := NewVirtualService(batteryUUID,
+		NewVirtualCharacteristic(batteryLevelUUID,
+			WithCharacteristicReadable(),
+			WithCharacteristicValue([]byte{100}),
+			WithCharacteristicUnreportedProperties(),
+		),
+	)
+	p := NewVirtualPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+		WithLocalName("snapshot"),
+		WithServices(NewVirtualService(testMatterServiceUUID, c1, c2), battery),
+	)
+	central := ble.NewCentralWithBackend(NewSimulator(p))
+	scanOnce(t, central)
+	dev := central.Devices()[0]
+
+	ctx := context.Background()
+	if _, err := ble.NewSnapshot(ctx, dev); !errors.Is(err, ble.ErrNotConnected) {
+		t.Errorf("expected ErrNotConnected, got %v", err)
+	}
+	takeSnapshot := func() ble.Snapshot {
+		t.Helper()
+		if err := central.Connect(ctx, dev); err != nil {
+			t.Fatal(err)
+		}
+		defer dev.Disconnect()
+		snapshot, err := ble.NewSnapshot(ctx, dev, ble.WithSnapshotValues())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return snapshot
+	}
+	v1 := takeSnapshot()
+
+	t.Run("export", func(t *testing.T) {
+		b, err := v1.JSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		fromJSON, err := ble.NewSnapshotFromJSON(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err = v1.YAML()
+		if err != nil {
+			t.Fatal(err)
+		}
+		fromYAML, err := ble.NewSnapshotFromYAML(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, snapshot := range []ble.Snapshot{fromJSON, fromYAML} {
+			if snapshot.String() != v1.String() {
+				t.Errorf("expected %s, got %s", v1.String(), snapshot.String())
+			}
+			if changes := v1.Diff(snapshot); len(changes) != 0 {
+				t.Errorf("expected no changes, got %v", changes)
+			}
+		}
+		if _, err := ble.NewSnapshotFromJSON([]byte(`{"address":"010203040506","takenAt":"2025-01-01T00:00:00Z","services":[{"uuid":"xyz"}]}`)); err == nil {
+			t.Errorf("expected an error for the invalid service UUID")
+		}
+	})
+
+	t.Run("device", func(t *testing.T) {
+		snapshotDev := v1.Device()
+		if snapshotDev.Address().String() != p.Address().String() || snapshotDev.LocalName() != "snapshot" {
+			t.Errorf("unexpected device %s", snapshotDev.String())
+		}
+		if err := snapshotDev.Connect(ctx); !errors.Is(err, ble.ErrNotPermitted) {
+			t.Errorf("expected ErrNotPermitted, got %v", err)
+		}
+		if n := len(snapshotDev.Services()); n != 2 {
+			t.Fatalf("expected 2 services, got %d", n)
+		}
+		service, ok := snapshotDev.LookupService(testMatterServiceUUID)
+		if !ok {
+			t.Fatalf("expected service %s", testMatterServiceUUID)
+		}
+		char, ok := service.LookupCharacteristic(testMatterC2UUID)
+		if !ok {
+			t.Fatalf("expected characteristic %s", testMatterC2UUID)
+		}
+		if b, err := char.Read(); err != nil || !bytes.Equal(b, []byte{0x01, 0x02}) {
+			t.Errorf("expected the captured value, got %X (%v)", b, err)
+		}
+		if _, err := char.Write([]byte{0x00}); !errors.Is(err, ble.ErrNotPermitted) {
+			t.Errorf("expected ErrNotPermitted, got %v", err)
+		}
+		desc, ok := char.LookupDescriptor(userDescUUID)
+		if !ok {
+			t.Fatalf("expected descriptor %s", userDescUUID)
+		}
+		if b, err := desc.Read(); err != nil || string(b) != "c2" {
+			t.Errorf("expected the captured descriptor value, got %q (%v)", b, err)
+		}
+		char, _ = service.LookupCharacteristic(testMatterC1UUID)
+		if _, err := char.Read(); !errors.Is(err, ble.ErrNotSet) {
+			t.Errorf("expected ErrNotSet, got %v", err)
+		}
+		// The value is captured although the backend does not report the properties of the characteristic.
+		service, _ = snapshotDev.LookupService(batteryUUID)
+		char, _ = service.LookupCharacteristic(batteryLevelUUID)
+		if b, err := char.Read(); err != nil || !bytes.Equal(b, []byte{100}) {
+			t.Errorf("expected the captured value, got %X (%v)", b, err)
+		}
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		// The next firmware drops the battery service, adds a characteristic and changes another.
+		c2.SetValue([]byte{0x03})
+		c3 := NewVirtualCharacteristic(ble.NewUUIDFromUUID16(0x2A00), WithCharacteristicReadable())
+		c1 = NewVirtualCharacteristic(testMatterC1UUID, WithCharacteristicWritable(), WithCharacteristicIndicating())
+		p.SetServices(NewVirtualService(testMatterServiceUUID, c1, c2, c3), NewVirtualService(ble.GenericAttributeServiceUUID))
+		v2 := takeSnapshot()
+
+		changes := v1.Diff(v2)
+		expected := []struct {
+			changeType ble.SnapshotChangeType
+			service    ble.UUID
+			char       ble.UUID
+		}{
+			{ble.SnapshotChangeChanged, testMatterServiceUUID, testMatterC1UUID},
+			{ble.SnapshotChangeChanged, testMatterServiceUUID, testMatterC2UUID},
+			{ble.SnapshotChangeAdded, testMatterServiceUUID, c3.UUID()},
+			{ble.SnapshotChangeRemoved, batteryUUID, ble.NewNilUUID()},
+			{ble.SnapshotChangeAdded, ble.GenericAttributeServiceUUID, ble.NewNilUUID()},
+		}
+		if len(changes) != len(expected) {
+			t.Fatalf("expected %d changes, got %v", len(expected), changes)
+		}
+		for n, change := range changes {
+			e := expected[n]
+			if change.Type != e.changeType || !change.Service.Equal(e.service) || !change.Characteristic.Equal(e.char) {
+				t.Errorf("expected %s %s %s, got %s", e.changeType, e.service, e.char, change)
+			}
+		}
+		if changes[3].ServiceName != "Battery" {
+			t.Errorf("expected the service name to be resolved, got %s", changes[3])
+		}
+		if changes[2].CharacteristicName != "Device Name" {
+			t.Errorf("expected the characteristic name to be resolved, got %s", changes[2])
+		}
+
+		// The empty value of the added characteristic is exported as captured.
+		b, err := v2.JSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		fromJSON, err := ble.NewSnapshotFromJSON(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err = v2.YAML()
+		if err != nil {
+			t.Fatal(err)
+		}
+		fromYAML, err := ble.NewSnapshotFromYAML(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, snapshot := range []ble.Snapshot{fromJSON, fromYAML} {
+			service, _ := snapshot.Device().LookupService(testMatterServiceUUID)
+			char, _ := service.LookupCharacteristic(c3.UUID())
+			if b, err := char.Read(); err != nil || len(b) != 0 {
+				t.Errorf("expected the captured empty value, got %X (%v)", b, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,253 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestSubscription(t *testing.T) {
+	lookupC2 := func(t *testing.T, c2 VirtualCharacteristic) ble.Characteristic {
+		t.Helper()
+		p := NewVirtualPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+			WithServices(NewVirtualService(testMatterServiceUUID, c2)),
+		)
+		dev := connectTestDevice(t, p)
+		service, _ := dev.LookupService(testMatterServiceUUID)
+		char, ok := service.LookupCharacteristic(testMatterC2UUID)
+		if !ok {
+			t.Fatalf("expected characteristic %s", testMatterC2UUID)
+		}
+		return char
+	}
+
+	t.Run("fanout", func(t *testing.T) {
+		c2 := NewVirtualCharacteristic(testMatterC2UUID, WithCharacteristicNotifying())
+		char := lookupC2(t, c2)
+
+		var first, second [][]byte
+		sub1, err := char.Subscribe(func(char ble.Characteristic, buf []byte) {
+			first = append(first, buf)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sub2, err := char.Subscribe(func(char ble.Characteristic, buf []byte) {
+			second = append(second, buf)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sub1.Mode() != ble.NotificationModeNotify {
+			t.Errorf("expected notify mode, got %s", sub1.Mode())
+		}
+
+		c2.NotifyValue([]byte{0x01})
+		if err := sub1.Unsubscribe(); err != nil {
+			t.Fatal(err)
+		}
+		if !c2.IsSubscribed() {
+			t.Errorf("expected notifications to stay enabled for the other subscription")
+		}
+		c2.NotifyValue([]byte{0x02})
+		if len(first) != 1 || len(second) != 2 || !bytes.Equal(second[1], []byte{0x02}) {
+			t.Errorf("unexpected notifications: %X, %X", first, second)
+		}
+
+		if err := sub2.Unsubscribe(); err != nil {
+			t.Fatal(err)
+		}
+		if err := sub2.Unsubscribe(); err != nil {
+			t.Errorf("expected repeated Unsubscribe to succeed, got %v", err)
+		}
+		if c2.IsSubscribed() {
+			t.Errorf("expected notifications to be disabled")
+		}
+	})
+
+	t.Run("indicate", func(t *testing.T) {
+		c2 := NewVirtualCharacteristic(testMatterC2UUID, WithCharacteristicIndicating())
+		char := lookupC2(t, c2)
+		if !char.Properties().IsIndicatable() {
+			t.Errorf("expected indicate property, got %s", char.Properties())
+		}
+
+		callback := func(char ble.Characteristic, buf []byte) {}
+		if _, err := char.Subscribe(callback); !errors.Is(err, ble.ErrNotPermitted) {
+			t.Errorf("expected ErrNotPermitted, got %v", err)
+		}
+
+		var confirmed []byte
+		sub, err := char.Subscribe(func(char ble.Characteristic, buf []byte) {
+			confirmed = buf
+		}, ble.WithSubscribeIndication())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sub.Mode() != ble.NotificationModeIndicate {
+			t.Errorf("expected indicate mode, got %s", sub.Mode())
+		}
+		if err := c2.IndicateValue([]byte{0x03}); err != nil {
+			t.Fatal(err)
+		}
+		// IndicateValue returns after the central confirms, so the value has been delivered.
+		if !bytes.Equal(confirmed, []byte{0x03}) {
+			t.Errorf("expected confirmed indication 03, got %X", confirmed)
+		}
+		if err := c2.NotifyValue([]byte{0x04}); !errors.Is(err, ErrNotSubscribed) {
+			t.Errorf("expected ErrNotSubscribed for notifications, got %v", err)
+		}
+		if _, err := char.Subscribe(callback); !errors.Is(err, ble.ErrInvalid) {
+			t.Errorf("expected ErrInvalid for a different mode, got %v", err)
+		}
+		if err := sub.Unsubscribe(); err != nil {
+			t.Fatal(err)
+		}
+		if c2.IsSubscribed() {
+			t.Errorf("expected indications to be disabled")
+		}
+	})
+
+	t.Run("channel", func(t *testing.T) {
+		c2 := NewVirtualCharacteristic(testMatterC2UUID, WithCharacteristicNotifying())
+		char := lookupC2(t, c2)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		ch, err := char.SubscribeChannel(ctx, ble.WithSubscribeChannelSize(2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, b := range []byte{0x01, 0x02, 0x03} {
+			c2.NotifyValue([]byte{b})
+		}
+		for _, b := range []byte{0x01, 0x02} {
+			select {
+			case buf := <-ch:
+				if !bytes.Equal(buf, []byte{b}) {
+					t.Errorf("expected %02X, got %X", b, buf)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("expected notification %02X", b)
+			}
+		}
+
+		cancel()
+		select {
+		case buf, ok := <-ch:
+			if ok {
+				t.Errorf("expected the overflowed notification to be dropped, got %X", buf)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected the channel to be closed")
+		}
+		if c2.IsSubscribed() {
+			t.Errorf("expected notifications to be disabled")
+		}
+	})
+
+	t.Run("transport", func(t *testing.T) {
+		c2 := NewVirtualCharacteristic(testMatterC2UUID, WithCharacteristicNotifying())
+		char := lookupC2(t, c2)
+
+		logged := 0
+		sub, err := char.Subscribe(func(char ble.Characteristic, buf []byte) {
+			logged++
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sub.Unsubscribe()
+
+		transport := ble.NewTransport(ble.WithTransportNotifyCharacteristic(char))
+		for range 2 {
+			if err := transport.Open(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		c2.NotifyValue([]byte{0x05})
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if b, err := transport.Read(ctx); err != nil || !bytes.Equal(b, []byte{0x05}) {
+			t.Errorf("expected 05, got %X (%v)", b, err)
+		}
+		// Opening the transport again does not subscribe twice.
+		readCtx, readCancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer readCancel()
+		if b, err := transport.Read(readCtx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected a single notification, got %X (%v)", b, err)
+		}
+		if err := transport.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if !c2.IsSubscribed() {
+			t.Errorf("expected closing the transport to keep the other subscription")
+		}
+		c2.NotifyValue([]byte{0x06})
+		if logged != 2 {
+			t.Errorf("expected 2 logged notifications, got %d", logged)
+		}
+		if err := sub.Unsubscribe(); err != nil {
+			t.Fatal(err)
+		}
+		if c2.IsSubscribed() {
+			t.Errorf("expected closing the transport to leave no subscription")
+		}
+	})
+
+	t.Run("persistent", func(t *testing.T) {
+		c2 := NewVirtualCharacteristic(testMatterC2UUID,
+			WithCharacteristicNotifying(),
+			WithCharacteristicPersistentNotifications(),
+		)
+		char := lookupC2(t, c2)
+		if err := c2.EnableNotifications(nil); !errors.Is(err, ble.ErrNotSupported) {
+			t.Errorf("expected ErrNotSupported for the nil callback, got %v", err)
+		}
+
+		notified := 0
+		sub, err := char.Subscribe(func(char ble.Characteristic, buf []byte) {
+			notified++
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		c2.NotifyValue([]byte{0x01})
+		// The backend rejects the nil callback, so the values are dropped instead.
+		if err := sub.Unsubscribe(); err != nil {
+			t.Fatal(err)
+		}
+		c2.NotifyValue([]byte{0x02})
+		if notified != 1 {
+			t.Errorf("expected 1 notification, got %d", notified)
+		}
+
+		transport := ble.NewTransport(ble.WithTransportNotifyCharacteristic(char))
+		if err := transport.Open(); err != nil {
+			t.Fatal(err)
+		}
+		if err := transport.Close(); err != nil {
+			t.Errorf("expected closing the transport to succeed, got %v", err)
+		}
+		if err := c2.NotifyValue([]byte{0x03}); err != nil {
+			t.Errorf("expected the notifications to stay enabled, got %v", err)
+		}
+	})
+}
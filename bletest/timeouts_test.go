@@ -0,0 +1,161 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestTimeouts(t *testing.T) {
+	const latency = 300 * time.Millisecond
+	c1 := NewVirtualCharacteristic(testMatterC1UUID,
+		WithCharacteristicReadable(),
+		WithCharacteristicWritable(),
+		WithCharacteristicValue([]byte{0x01}),
+		WithCharacteristicLatency(latency),
+	)
+	c2 := NewVirtualCharacteristic(testMatterC2UUID, WithCharacteristicNotifying())
+	c3 := NewVirtualCharacteristic(ble.NewUUIDFromUUID16(0x2A37),
+		WithCharacteristicNotifying(),
+		WithCharacteristicLatency(latency),
+	)
+	p := NewVirtualPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+		WithServices(
+			NewVirtualService(testMatterServiceUUID, c1, c2),
+			NewVirtualService(ble.NewUUIDFromUUID16(0x180D), c3),
+		),
+	)
+	central := ble.NewCentralWithBackend(NewSimulator(p),
+		ble.WithCentralOperationTimeout(50*time.Millisecond),
+		ble.WithCentralTransportTimeout(50*time.Millisecond),
+	)
+	if timeouts := central.Timeouts(); timeouts.Connect != ble.DefaultConnectTimeout || timeouts.Operation != 50*time.Millisecond {
+		t.Errorf("unexpected timeouts: %+v", timeouts)
+	}
+	scanOnce(t, central)
+	dev := central.Devices()[0]
+	if err := central.Connect(context.Background(), dev); err != nil {
+		t.Fatal(err)
+	}
+	defer dev.Disconnect()
+	if dev.Timeouts() != central.Timeouts() {
+		t.Errorf("expected the device to use the central timeouts, got %+v", dev.Timeouts())
+	}
+
+	ctx := context.Background()
+	if _, err := dev.LookupServiceContext(ctx, 0x180F); !errors.Is(err, ble.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	service, err := dev.LookupServiceContext(ctx, testMatterServiceUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	char, _ := service.LookupCharacteristic(testMatterC1UUID)
+
+	t.Run("default", func(t *testing.T) {
+		start := time.Now()
+		_, err := char.Read()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+		if latency <= time.Since(start) {
+			t.Errorf("expected the read to return before the peripheral responds")
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		b, err := char.ReadContext(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, []byte{0x01}) {
+			t.Errorf("expected 01, got %X", b)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+		if _, err := char.WriteContext(ctx, []byte{0x02}); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected Canceled, got %v", err)
+		}
+	})
+
+	t.Run("transport", func(t *testing.T) {
+		transport, err := service.OpenContext(ctx, ble.WithTransportNotifyUUID(testMatterC2UUID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer transport.Close()
+		if _, err := transport.Read(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("late subscription", func(t *testing.T) {
+		hrService, err := dev.LookupServiceContext(ctx, 0x180D)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hrChar, _ := hrService.LookupCharacteristic(0x2A37)
+		if _, err := hrChar.Subscribe(func(ble.Characteristic, []byte) {}); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected DeadlineExceeded, got %v", err)
+		}
+		// The next subscription waits until the late notifications are enabled and disabled again.
+		subCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		values := make(chan []byte, 1)
+		sub, err := hrChar.SubscribeContext(subCtx, func(_ ble.Characteristic, b []byte) {
+			values <- b
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !c3.IsSubscribed() {
+			t.Fatalf("expected the characteristic to be subscribed")
+		}
+		if err := c3.NotifyValue([]byte{0x03}); err != nil {
+			t.Fatal(err)
+		}
+		if b := <-values; !bytes.Equal(b, []byte{0x03}) {
+			t.Errorf("expected 03, got %X", b)
+		}
+		// Disabling notifications outlives the operation timeout but still completes.
+		if err := sub.Unsubscribe(); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected DeadlineExceeded, got %v", err)
+		}
+		for c3.IsSubscribed() {
+			if subCtx.Err() != nil {
+				t.Fatalf("expected the characteristic to be unsubscribed")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+
+	if err := dev.DisconnectContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if dev.IsConnected() {
+		t.Errorf("expected the device to be disconnected")
+	}
+}
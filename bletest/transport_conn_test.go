@@ -0,0 +1,140 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestTransportConn(t *testing.T) {
+	t.Run("stream", func(t *testing.T) {
+		c2, transport := openTestTransport(t)
+		conn := ble.NewTransportConn(transport, ble.WithTransportConnWriteSize(4))
+		defer conn.Close()
+
+		// Writes are split into chunks, and the echoed notifications are read as a byte stream.
+		if _, err := conn.Write([]byte("hello\nworld\n")); err != nil {
+			t.Fatal(err)
+		}
+		if v := string(c2.Value()); v != "rld\n" {
+			t.Errorf("expected the last chunk to be echoed, got %q", v)
+		}
+		r := bufio.NewReader(conn)
+		for _, expected := range []string{"hello\n", "world\n"} {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				t.Fatal(err)
+			}
+			if line != expected {
+				t.Errorf("expected %q, got %q", expected, line)
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		_, transport := openTestTransport(t)
+		conn := ble.NewTransportConn(transport)
+		defer conn.Close()
+
+		type message struct {
+			Method string `json:"method"`
+			Params []int  `json:"params"`
+		}
+		sent := message{Method: "echo", Params: []int{1, 2, 3}}
+		if err := json.NewEncoder(conn).Encode(sent); err != nil {
+			t.Fatal(err)
+		}
+		var received message
+		if err := json.NewDecoder(conn).Decode(&received); err != nil {
+			t.Fatal(err)
+		}
+		if received.Method != sent.Method || len(received.Params) != len(sent.Params) {
+			t.Errorf("expected %v, got %v", sent, received)
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		_, transport := openTestTransport(t)
+		conn := ble.NewTransportConn(transport)
+		defer conn.Close()
+
+		if err := conn.SetReadDeadline(time.Now().Add(20 * time.Millisecond)); err != nil {
+			t.Fatal(err)
+		}
+		_, err := conn.Read(make([]byte, 1))
+		if !errors.Is(err, os.ErrDeadlineExceeded) {
+			t.Fatalf("expected os.ErrDeadlineExceeded, got %v", err)
+		}
+		var netErr net.Error
+		if !errors.As(err, &netErr) || !netErr.Timeout() {
+			t.Errorf("expected a timeout net.Error, got %v", err)
+		}
+
+		// Setting a past deadline unblocks a pending Read.
+		if err := conn.SetReadDeadline(time.Time{}); err != nil {
+			t.Fatal(err)
+		}
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			conn.SetReadDeadline(time.Now())
+		}()
+		if _, err := conn.Read(make([]byte, 1)); !errors.Is(err, os.ErrDeadlineExceeded) {
+			t.Errorf("expected os.ErrDeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("close", func(t *testing.T) {
+		c2, transport := openTestTransport(t)
+		conn := ble.NewTransportConn(transport)
+		if conn.RemoteAddr().Network() != ble.TransportConnNetwork {
+			t.Errorf("expected network %s, got %s", ble.TransportConnNetwork, conn.RemoteAddr().Network())
+		}
+		if addr := conn.RemoteAddr().String(); addr != (ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}).String() {
+			t.Errorf("unexpected remote address %s", addr)
+		}
+
+		read := make(chan error, 1)
+		go func() {
+			_, err := conn.Read(make([]byte, 1))
+			read <- err
+		}()
+		time.Sleep(20 * time.Millisecond)
+		if err := conn.Close(); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case err := <-read:
+			if !errors.Is(err, net.ErrClosed) {
+				t.Errorf("expected net.ErrClosed, got %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected Close to unblock a pending Read")
+		}
+		if c2.IsSubscribed() {
+			t.Errorf("expected notifications to be disabled")
+		}
+		if _, err := conn.Write([]byte{0x01}); !errors.Is(err, net.ErrClosed) {
+			t.Errorf("expected net.ErrClosed, got %v", err)
+		}
+	})
+}
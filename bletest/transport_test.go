@@ -0,0 +1,217 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func openTestTransport(t *testing.T, opts ...ble.TransportOption) (VirtualCharacteristic, ble.Transport) {
+	t.Helper()
+	p, _, c2 := newTestMatterPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06})
+	central := ble.NewCentralWithBackend(NewSimulator(p))
+	scanOnce(t, central)
+	dev := central.Devices()[0]
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := central.Connect(ctx, dev); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { dev.Disconnect() })
+	service, ok := dev.LookupService(testMatterServiceUUID)
+	if !ok {
+		t.Fatalf("expected discovered service %s", testMatterServiceUUID)
+	}
+	writeChar, ok := service.LookupCharacteristic(testMatterC1UUID)
+	if !ok {
+		t.Fatalf("expected characteristic %s", testMatterC1UUID)
+	}
+	notifyChar, ok := service.LookupCharacteristic(testMatterC2UUID)
+	if !ok {
+		t.Fatalf("expected characteristic %s", testMatterC2UUID)
+	}
+	opts = append(opts,
+		ble.WithTransportWriteCharacteristic(writeChar),
+		ble.WithTransportNotifyCharacteristic(notifyChar),
+	)
+	transport := ble.NewTransport(opts...)
+	if err := transport.Open(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { transport.Close() })
+	return c2, transport
+}
+
+func TestTransportRead(t *testing.T) {
+	t.Run("wakeup", func(t *testing.T) {
+		c2, transport := openTestTransport(t)
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			c2.NotifyValue([]byte{0x01})
+		}()
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		b, err := transport.Read(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, []byte{0x01}) {
+			t.Errorf("unexpected notification: %X", b)
+		}
+		if elapsed := time.Since(start); 80*time.Millisecond < elapsed {
+			t.Errorf("expected Read to wake up on arrival, took %s", elapsed)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		_, transport := openTestTransport(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			cancel()
+		}()
+		if _, err := transport.Read(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("close", func(t *testing.T) {
+		c2, transport := openTestTransport(t)
+		if err := transport.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if c2.IsSubscribed() {
+			t.Errorf("expected notifications to be disabled")
+		}
+		if _, err := transport.Read(context.Background()); !errors.Is(err, ble.ErrClosed) {
+			t.Errorf("expected ErrClosed, got %v", err)
+		}
+		if _, err := transport.Write(context.Background(), []byte{0x01}); !errors.Is(err, ble.ErrClosed) {
+			t.Errorf("expected ErrClosed, got %v", err)
+		}
+	})
+}
+
+func TestTransportOverflow(t *testing.T) {
+	readAll := func(transport ble.Transport) ([][]byte, error) {
+		msgs := [][]byte{}
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			b, err := transport.Read(ctx)
+			cancel()
+			if errors.Is(err, context.DeadlineExceeded) {
+				return msgs, nil
+			}
+			if err != nil {
+				return msgs, err
+			}
+			msgs = append(msgs, b)
+		}
+	}
+
+	t.Run("drop oldest", func(t *testing.T) {
+		c2, transport := openTestTransport(t,
+			ble.WithTransportQueueSize(2),
+			ble.WithTransportOverflowPolicy(ble.TransportOverflowDropOldest),
+		)
+		for n := range 4 {
+			c2.NotifyValue([]byte{byte(n)})
+		}
+		msgs, err := readAll(transport)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(msgs) != 2 || msgs[0][0] != 2 || msgs[1][0] != 3 {
+			t.Errorf("expected the newest 2 notifications, got %X", msgs)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		c2, transport := openTestTransport(t,
+			ble.WithTransportQueueSize(2),
+			ble.WithTransportOverflowPolicy(ble.TransportOverflowError),
+		)
+		for n := range 4 {
+			c2.NotifyValue([]byte{byte(n)})
+		}
+		if _, err := transport.Read(context.Background()); !errors.Is(err, ble.ErrOverflow) {
+			t.Errorf("expected ErrOverflow, got %v", err)
+		}
+		msgs, err := readAll(transport)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(msgs) != 2 || msgs[0][0] != 0 || msgs[1][0] != 1 {
+			t.Errorf("expected the oldest 2 notifications, got %X", msgs)
+		}
+	})
+
+	t.Run("block", func(t *testing.T) {
+		c2, transport := openTestTransport(t,
+			ble.WithTransportQueueSize(2),
+			ble.WithTransportOverflowPolicy(ble.TransportOverflowBlock),
+		)
+		notified := make(chan struct{})
+		go func() {
+			for n := range 4 {
+				c2.NotifyValue([]byte{byte(n)})
+			}
+			close(notified)
+		}()
+		select {
+		case <-notified:
+			t.Fatalf("expected notifications to block while the queue is full")
+		case <-time.After(50 * time.Millisecond):
+		}
+		msgs, err := readAll(transport)
+		if err != nil {
+			t.Fatal(err)
+		}
+		<-notified
+		if len(msgs) != 4 {
+			t.Errorf("expected all 4 notifications, got %X", msgs)
+		}
+	})
+
+	t.Run("block close", func(t *testing.T) {
+		c2, transport := openTestTransport(t,
+			ble.WithTransportQueueSize(1),
+			ble.WithTransportOverflowPolicy(ble.TransportOverflowBlock),
+		)
+		notified := make(chan struct{})
+		go func() {
+			for n := range 2 {
+				c2.NotifyValue([]byte{byte(n)})
+			}
+			close(notified)
+		}()
+		time.Sleep(50 * time.Millisecond)
+		if err := transport.Close(); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-notified:
+		case <-time.After(time.Second):
+			t.Fatalf("expected Close to release the blocked notification")
+		}
+	})
+}
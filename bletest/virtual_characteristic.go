@@ -0,0 +1,368 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+// VirtualCharacteristicWriteHandler represents a handler function called when a virtual characteristic is written.
+type VirtualCharacteristicWriteHandler func(char VirtualCharacteristic, data []byte)
+
+// VirtualCharacteristicWriteValidator represents a function called before a virtual characteristic is written, which rejects the write by returning an error such as a ble.ATTError.
+type VirtualCharacteristicWriteValidator func(char VirtualCharacteristic, data []byte) error
+
+// VirtualCharacteristic represents a characteristic of a virtual peripheral.
+type VirtualCharacteristic interface {
+	ble.BackendCharacteristic
+	// Value returns the current value of the characteristic.
+	Value() []byte
+	// SetValue sets the current value of the characteristic.
+	SetValue(data []byte)
+	// IsSubscribed returns whether a central has enabled notifications.
+	IsSubscribed() bool
+	// NotifyValue sets the value and sends a notification to the subscribed central.
+	NotifyValue(data []byte) error
+	// IndicateValue sets the value, sends an indication to the subscribed central and returns after the central confirms it.
+	IndicateValue(data []byte) error
+	// Descriptors returns the descriptors of the characteristic.
+	Descriptors() []VirtualDescriptor
+}
+
+// VirtualCharacteristicOption represents an option for a virtual characteristic.
+type VirtualCharacteristicOption func(*virtualCharacteristic)
+
+// WithCharacteristicValue sets the initial value of the virtual characteristic.
+func WithCharacteristicValue(data []byte) VirtualCharacteristicOption {
+	return func(char *virtualCharacteristic) {
+		char.value = data
+	}
+}
+
+// WithCharacteristicReadable allows centrals to read the virtual characteristic.
+func WithCharacteristicReadable() VirtualCharacteristicOption {
+	return func(char *virtualCharacteristic) {
+		char.readable = true
+	}
+}
+
+// WithCharacteristicWritable allows centrals to write the virtual characteristic.
+func WithCharacteristicWritable() VirtualCharacteristicOption {
+	return func(char *virtualCharacteristic) {
+		char.writable = true
+	}
+}
+
+// WithCharacteristicNotifying allows centrals to subscribe to notifications of the virtual characteristic.
+func WithCharacteristicNotifying() VirtualCharacteristicOption {
+	return func(char *virtualCharacteristic) {
+		char.notifying = true
+	}
+}
+
+// WithCharacteristicIndicating allows centrals to subscribe to indications of the virtual characteristic.
+func WithCharacteristicIndicating() VirtualCharacteristicOption {
+	return func(char *virtualCharacteristic) {
+		char.indicating = true
+	}
+}
+
+// WithCharacteristicUnreportedProperties makes the virtual characteristic report no properties and enable indications for notifications if it only indicates,
+// as CoreBluetooth does through TinyGo.
+func WithCharacteristicUnreportedProperties() VirtualCharacteristicOption {
+	return func(char *virtualCharacteristic) {
+		char.unreported = true
+	}
+}
+
+// WithCharacteristicPersistentNotifications makes the virtual characteristic reject disabling notifications and indications with ble.ErrNotSupported,
+// as CoreBluetooth does through TinyGo. They are disabled only when the central disconnects.
+func WithCharacteristicPersistentNotifications() VirtualCharacteristicOption {
+	return func(char *virtualCharacteristic) {
+		char.persistent = true
+	}
+}
+
+// WithCharacteristicLatency delays the responses to reads, writes and subscriptions of the virtual characteristic to simulate a slow peripheral.
+func WithCharacteristicLatency(latency time.Duration) VirtualCharacteristicOption {
+	return func(char *virtualCharacteristic) {
+		char.latency = latency
+	}
+}
+
+// WithCharacteristicDescriptors adds the descriptors to the virtual characteristic.
+func WithCharacteristicDescriptors(descs ...VirtualDescriptor) VirtualCharacteristicOption {
+	return func(char *virtualCharacteristic) {
+		char.descs = append(char.descs, descs...)
+	}
+}
+
+// WithCharacteristicWriteHandler sets the handler called when the virtual characteristic is written.
+func WithCharacteristicWriteHandler(handler VirtualCharacteristicWriteHandler) VirtualCharacteristicOption {
+	return func(char *virtualCharacteristic) {
+		char.writeHandler = handler
+	}
+}
+
+// WithCharacteristicWriteValidator sets the validator called before the virtual characteristic is written.
+func WithCharacteristicWriteValidator(validator VirtualCharacteristicWriteValidator) VirtualCharacteristicOption {
+	return func(char *virtualCharacteristic) {
+		char.writeValidator = validator
+	}
+}
+
+type virtualCharacteristic struct {
+	sync.Mutex
+	uuid           ble.UUID
+	value          []byte
+	readable       bool
+	writable       bool
+	notifying      bool
+	indicating     bool
+	persistent     bool
+	unreported     bool
+	latency        time.Duration
+	descs          []VirtualDescriptor
+	writeValidator VirtualCharacteristicWriteValidator
+	writeHandler   VirtualCharacteristicWriteHandler
+	notifyFunc     func([]byte)
+	indicateFunc   func([]byte)
+	deliverMutex   sync.RWMutex
+}
+
+// NewVirtualCharacteristic returns a new virtual characteristic with the specified UUID.
+func NewVirtualCharacteristic(uuid ble.UUID, opts ...VirtualCharacteristicOption) VirtualCharacteristic {
+	char := &virtualCharacteristic{
+		Mutex:          sync.Mutex{},
+		uuid:           uuid,
+		value:          []byte{},
+		readable:       false,
+		writable:       false,
+		notifying:      false,
+		indicating:     false,
+		persistent:     false,
+		unreported:     false,
+		latency:        0,
+		descs:          []VirtualDescriptor{},
+		writeValidator: nil,
+		writeHandler:   nil,
+		notifyFunc:     nil,
+		indicateFunc:   nil,
+		deliverMutex:   sync.RWMutex{},
+	}
+	for _, opt := range opts {
+		opt(char)
+	}
+	return char
+}
+
+// UUID returns the characteristic UUID.
+func (char *virtualCharacteristic) UUID() ble.UUID {
+	return char.uuid
+}
+
+// Properties returns the characteristic properties derived from the options.
+func (char *virtualCharacteristic) Properties() ble.CharacteristicProperties {
+	var props ble.CharacteristicProperties
+	if char.unreported {
+		return props
+	}
+	if char.readable {
+		props |= ble.CharacteristicPropertyRead
+	}
+	if char.writable {
+		props |= ble.CharacteristicPropertyWrite | ble.CharacteristicPropertyWriteWithoutResponse
+	}
+	if char.notifying {
+		props |= ble.CharacteristicPropertyNotify
+	}
+	if char.indicating {
+		props |= ble.CharacteristicPropertyIndicate
+	}
+	return props
+}
+
+// Descriptors returns the descriptors of the characteristic.
+func (char *virtualCharacteristic) Descriptors() []VirtualDescriptor {
+	return char.descs
+}
+
+// DiscoverDescriptors discovers the specified descriptors. All descriptors are returned if no UUIDs are specified.
+func (char *virtualCharacteristic) DiscoverDescriptors(uuids []ble.UUID) ([]ble.BackendDescriptor, error) {
+	descs := []ble.BackendDescriptor{}
+	for _, desc := range char.descs {
+		if len(uuids) == 0 || containsUUID(uuids, desc.UUID()) {
+			descs = append(descs, desc)
+		}
+	}
+	return descs, nil
+}
+
+// Value returns the current value of the characteristic.
+func (char *virtualCharacteristic) Value() []byte {
+	char.Lock()
+	defer char.Unlock()
+	return copyBytes(char.value)
+}
+
+// SetValue sets the current value of the characteristic.
+func (char *virtualCharacteristic) SetValue(data []byte) {
+	char.Lock()
+	defer char.Unlock()
+	char.value = copyBytes(data)
+}
+
+// IsSubscribed returns whether a central has enabled notifications or indications.
+func (char *virtualCharacteristic) IsSubscribed() bool {
+	char.Lock()
+	defer char.Unlock()
+	return char.notifyFunc != nil || char.indicateFunc != nil
+}
+
+// NotifyValue sets the value and sends a notification to the subscribed central.
+func (char *virtualCharacteristic) NotifyValue(data []byte) error {
+	char.Lock()
+	char.value = copyBytes(data)
+	notifyFunc := char.notifyFunc
+	char.Unlock()
+	if notifyFunc == nil {
+		return fmt.Errorf("%w: %s", ErrNotSubscribed, char.uuid)
+	}
+	char.deliverMutex.RLock()
+	defer char.deliverMutex.RUnlock()
+	notifyFunc(copyBytes(data))
+	return nil
+}
+
+// IndicateValue sets the value, sends an indication to the subscribed central and returns after the central confirms it.
+func (char *virtualCharacteristic) IndicateValue(data []byte) error {
+	char.Lock()
+	char.value = copyBytes(data)
+	indicateFunc := char.indicateFunc
+	char.Unlock()
+	if indicateFunc == nil {
+		return fmt.Errorf("%w: %s", ErrNotSubscribed, char.uuid)
+	}
+	// The central confirms the indication after handling the value.
+	char.deliverMutex.RLock()
+	defer char.deliverMutex.RUnlock()
+	indicateFunc(copyBytes(data))
+	return nil
+}
+
+// Read reads the characteristic value.
+func (char *virtualCharacteristic) Read() ([]byte, error) {
+	time.Sleep(char.latency)
+	if !char.readable {
+		return nil, ble.NewATTError(ble.ATTErrorReadNotPermitted, nil)
+	}
+	return char.Value(), nil
+}
+
+// Write writes the characteristic value and returns the error which the peripheral responds with.
+func (char *virtualCharacteristic) Write(data []byte) (int, error) {
+	time.Sleep(char.latency)
+	if !char.writable {
+		return 0, ble.NewATTError(ble.ATTErrorWriteNotPermitted, nil)
+	}
+	if char.writeValidator != nil {
+		if err := char.writeValidator(char, copyBytes(data)); err != nil {
+			return 0, err
+		}
+	}
+	char.SetValue(data)
+	if char.writeHandler != nil {
+		char.writeHandler(char, copyBytes(data))
+	}
+	return len(data), nil
+}
+
+// WriteWithoutResponse writes the characteristic value without waiting for a response.
+// Since the peripheral sends no response, a rejected write is not reported to the central.
+func (char *virtualCharacteristic) WriteWithoutResponse(data []byte) (int, error) {
+	if _, err := char.Write(data); err != nil && !char.writable {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// EnableNotifications enables notifications with the specified callback, or disables them if the callback is nil.
+func (char *virtualCharacteristic) EnableNotifications(callback func(buf []byte)) error {
+	time.Sleep(char.latency)
+	if callback == nil {
+		return char.disableNotifications()
+	}
+	if !char.notifying && char.unreported && char.indicating {
+		return char.EnableIndications(callback)
+	}
+	if !char.notifying {
+		return fmt.Errorf("%w notify: %s", ble.ErrNotPermitted, char.uuid)
+	}
+	char.Lock()
+	char.notifyFunc = callback
+	char.indicateFunc = nil
+	char.Unlock()
+	return nil
+}
+
+// EnableIndications enables indications with the specified callback, or disables them if the callback is nil.
+func (char *virtualCharacteristic) EnableIndications(callback func(buf []byte)) error {
+	time.Sleep(char.latency)
+	if callback == nil {
+		return char.disableNotifications()
+	}
+	if !char.indicating {
+		return fmt.Errorf("%w indicate: %s", ble.ErrNotPermitted, char.uuid)
+	}
+	char.Lock()
+	char.indicateFunc = callback
+	char.notifyFunc = nil
+	char.Unlock()
+	return nil
+}
+
+// disableNotifications disables notifications and indications unless they are persistent.
+func (char *virtualCharacteristic) disableNotifications() error {
+	if char.persistent {
+		return fmt.Errorf("disabling notification %w: %s", ble.ErrNotSupported, char.uuid)
+	}
+	char.unsubscribe()
+	return nil
+}
+
+// unsubscribe disables notifications and indications after the notification or indication being delivered is handled.
+func (char *virtualCharacteristic) unsubscribe() {
+	char.Lock()
+	char.notifyFunc = nil
+	char.indicateFunc = nil
+	char.Unlock()
+	char.waitDelivery()
+}
+
+// waitDelivery waits until the notification or indication being delivered is handled, as real backends do when notifications are disabled.
+func (char *virtualCharacteristic) waitDelivery() {
+	char.deliverMutex.Lock()
+	defer char.deliverMutex.Unlock()
+}
+
+func copyBytes(b []byte) []byte {
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"fmt"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+// virtualConnection represents a connection to a virtual peripheral which applies the negotiated ATT MTU to the GATT operations.
+type virtualConnection struct {
+	peripheral *virtualPeripheral
+	mtu        int
+}
+
+func newVirtualConnection(p *virtualPeripheral, mtu int) *virtualConnection {
+	return &virtualConnection{
+		peripheral: p,
+		mtu:        mtu,
+	}
+}
+
+// Disconnect disconnects from the remote device.
+func (conn *virtualConnection) Disconnect() error {
+	conn.peripheral.disconnect()
+	return nil
+}
+
+// MTU returns the negotiated ATT MTU of the connection.
+func (conn *virtualConnection) MTU() int {
+	return conn.mtu
+}
+
+// DiscoverServices discovers the specified services. All services are returned if no UUIDs are specified.
+func (conn *virtualConnection) DiscoverServices(uuids []ble.UUID) ([]ble.BackendService, error) {
+	services := []ble.BackendService{}
+	for _, service := range conn.peripheral.Services() {
+		if len(uuids) == 0 || containsUUID(uuids, service.UUID()) {
+			services = append(services, &virtualConnectionService{
+				VirtualService: service,
+				conn:           conn,
+			})
+		}
+	}
+	return services, nil
+}
+
+type virtualConnectionService struct {
+	VirtualService
+	conn *virtualConnection
+}
+
+// DiscoverCharacteristics discovers the specified characteristics. All characteristics are returned if no UUIDs are specified.
+func (s *virtualConnectionService) DiscoverCharacteristics(uuids []ble.UUID) ([]ble.BackendCharacteristic, error) {
+	chars := []ble.BackendCharacteristic{}
+	for _, char := range s.Characteristics() {
+		if len(uuids) == 0 || containsUUID(uuids, char.UUID()) {
+			chars = append(chars, &virtualConnectionCharacteristic{
+				VirtualCharacteristic: char,
+				conn:                  s.conn,
+			})
+		}
+	}
+	return chars, nil
+}
+
+// virtualConnectionCharacteristic represents a characteristic accessed over a connection, which reads long values part by part like ATT Read and Read Blob requests.
+type virtualConnectionCharacteristic struct {
+	VirtualCharacteristic
+	conn *virtualConnection
+}
+
+// Read reads the first part of the characteristic value which fits in a Read Response.
+func (char *virtualConnectionCharacteristic) Read() ([]byte, error) {
+	return char.ReadBlob(0)
+}
+
+// ReadBlob reads the part of the characteristic value starting at the offset which fits in a Read Blob Response.
+func (char *virtualConnectionCharacteristic) ReadBlob(offset int) ([]byte, error) {
+	value, err := char.VirtualCharacteristic.Read()
+	if err != nil {
+		return nil, err
+	}
+	if offset < 0 || len(value) < offset {
+		return nil, fmt.Errorf("%w offset: %d", ble.ErrInvalid, offset)
+	}
+	end := min(offset+char.conn.mtu-1, len(value))
+	return value[offset:end], nil
+}
+
+// Write writes the characteristic value, as a long write if it exceeds the ATT MTU.
+func (char *virtualConnectionCharacteristic) Write(data []byte) (int, error) {
+	if ble.MaxAttributeValueSize < len(data) {
+		return 0, fmt.Errorf("%w value size: %d", ble.ErrInvalid, len(data))
+	}
+	return char.VirtualCharacteristic.Write(data)
+}
+
+// WriteWithoutResponse writes the characteristic value which must fit in a Write Command.
+func (char *virtualConnectionCharacteristic) WriteWithoutResponse(data []byte) (int, error) {
+	if char.conn.mtu-3 < len(data) {
+		return 0, fmt.Errorf("%w write command size: %d > %d", ble.ErrInvalid, len(data), char.conn.mtu-3)
+	}
+	return char.VirtualCharacteristic.WriteWithoutResponse(data)
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"sync"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+// VirtualDescriptor represents a characteristic descriptor of a virtual peripheral.
+type VirtualDescriptor interface {
+	ble.BackendDescriptor
+	// Value returns the current value of the descriptor.
+	Value() []byte
+	// SetValue sets the current value of the descriptor.
+	SetValue(data []byte)
+}
+
+// VirtualDescriptorOption represents an option for a virtual descriptor.
+type VirtualDescriptorOption func(*virtualDescriptor)
+
+// WithDescriptorValue sets the initial value of the virtual descriptor.
+func WithDescriptorValue(data []byte) VirtualDescriptorOption {
+	return func(desc *virtualDescriptor) {
+		desc.value = data
+	}
+}
+
+// WithDescriptorWritable allows centrals to write the virtual descriptor.
+func WithDescriptorWritable() VirtualDescriptorOption {
+	return func(desc *virtualDescriptor) {
+		desc.writable = true
+	}
+}
+
+type virtualDescriptor struct {
+	sync.Mutex
+	uuid     ble.UUID
+	value    []byte
+	writable bool
+}
+
+// NewVirtualDescriptor returns a new readable virtual descriptor with the specified UUID.
+func NewVirtualDescriptor(uuid ble.UUID, opts ...VirtualDescriptorOption) VirtualDescriptor {
+	desc := &virtualDescriptor{
+		Mutex:    sync.Mutex{},
+		uuid:     uuid,
+		value:    []byte{},
+		writable: false,
+	}
+	for _, opt := range opts {
+		opt(desc)
+	}
+	return desc
+}
+
+// UUID returns the descriptor UUID.
+func (desc *virtualDescriptor) UUID() ble.UUID {
+	return desc.uuid
+}
+
+// Value returns the current value of the descriptor.
+func (desc *virtualDescriptor) Value() []byte {
+	desc.Lock()
+	defer desc.Unlock()
+	return copyBytes(desc.value)
+}
+
+// SetValue sets the current value of the descriptor.
+func (desc *virtualDescriptor) SetValue(data []byte) {
+	desc.Lock()
+	defer desc.Unlock()
+	desc.value = copyBytes(data)
+}
+
+// Read reads the descriptor value.
+func (desc *virtualDescriptor) Read() ([]byte, error) {
+	return desc.Value(), nil
+}
+
+// Write writes the descriptor value and returns the error which the peripheral responds with.
+func (desc *virtualDescriptor) Write(data []byte) (int, error) {
+	if !desc.writable {
+		return 0, ble.NewATTError(ble.ATTErrorWriteNotPermitted, nil)
+	}
+	desc.SetValue(data)
+	return len(data), nil
+}
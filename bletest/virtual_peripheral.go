@@ -0,0 +1,327 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"sync"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+const (
+	// DefaultVirtualPeripheralRSSI is the default RSSI of virtual peripherals.
+	DefaultVirtualPeripheralRSSI = -60
+)
+
+// VirtualPeripheral represents a virtual peripheral which advertises and accepts connections from the simulator.
+type VirtualPeripheral interface {
+	// Address returns the Bluetooth address of the peripheral.
+	Address() ble.Address
+	// SetLocalName sets the advertised local name of the peripheral.
+	SetLocalName(name string)
+	// SetRSSI sets the RSSI reported for the advertisements of the peripheral.
+	SetRSSI(rssi int)
+	// SetServiceUUIDs replaces the advertised service UUIDs of the peripheral.
+	SetServiceUUIDs(uuids ...ble.UUID)
+	// AddServiceData adds or replaces an advertised service data element.
+	AddServiceData(uuid ble.UUID, data []byte)
+	// AddManufacturerData adds or replaces an advertised manufacturer specific data element.
+	AddManufacturerData(id int, data []byte)
+	// Services returns the GATT services of the peripheral.
+	Services() []VirtualService
+	// SetServices replaces the GATT services of the peripheral. The peripheral should indicate a Service Changed to connected centrals.
+	SetServices(services ...VirtualService)
+	// IsConnected returns whether a central is connected to the peripheral.
+	IsConnected() bool
+}
+
+// VirtualPeripheralOption represents an option for a virtual peripheral.
+type VirtualPeripheralOption func(*virtualPeripheral)
+
+// WithLocalName sets the advertised local name of the virtual peripheral.
+func WithLocalName(name string) VirtualPeripheralOption {
+	return func(p *virtualPeripheral) {
+		p.localName = name
+	}
+}
+
+// WithRSSI sets the RSSI reported for the advertisements of the virtual peripheral.
+func WithRSSI(rssi int) VirtualPeripheralOption {
+	return func(p *virtualPeripheral) {
+		p.rssi = rssi
+	}
+}
+
+// WithServiceUUIDs sets the advertised service UUIDs of the virtual peripheral.
+func WithServiceUUIDs(uuids ...ble.UUID) VirtualPeripheralOption {
+	return func(p *virtualPeripheral) {
+		p.serviceUUIDs = uuids
+	}
+}
+
+// WithServiceData adds an advertised service data element to the virtual peripheral.
+func WithServiceData(uuid ble.UUID, data []byte) VirtualPeripheralOption {
+	return func(p *virtualPeripheral) {
+		p.serviceData = append(p.serviceData, ble.NewServiceData(uuid, data))
+	}
+}
+
+// WithManufacturerData adds an advertised manufacturer specific data element to the virtual peripheral.
+func WithManufacturerData(id int, data []byte) VirtualPeripheralOption {
+	return func(p *virtualPeripheral) {
+		p.manufacturerData = append(p.manufacturerData, ble.NewManufacturer(id, data))
+	}
+}
+
+// WithRawAdvertisement sets the raw advertising data of the virtual peripheral.
+// The local name, service UUIDs, service data and manufacturer data are decoded from the raw data.
+func WithRawAdvertisement(b []byte) VirtualPeripheralOption {
+	return func(p *virtualPeripheral) {
+		p.rawAdvertisement = b
+		adv, err := ble.ParseAdvertisement(b)
+		if err != nil {
+			return
+		}
+		p.localName = adv.LocalName()
+		p.serviceUUIDs = adv.ServiceUUIDs()
+		p.serviceData = adv.ServiceData()
+		p.manufacturerData = adv.ManufacturerData()
+	}
+}
+
+// WithServices sets the GATT services of the virtual peripheral.
+func WithServices(services ...VirtualService) VirtualPeripheralOption {
+	return func(p *virtualPeripheral) {
+		p.services = services
+	}
+}
+
+// WithMTU sets the largest ATT MTU that the virtual peripheral accepts in the MTU exchange.
+func WithMTU(mtu int) VirtualPeripheralOption {
+	return func(p *virtualPeripheral) {
+		p.mtu = mtu
+	}
+}
+
+// WithNonConnectable makes the virtual peripheral reject connections.
+func WithNonConnectable() VirtualPeripheralOption {
+	return func(p *virtualPeripheral) {
+		p.connectable = false
+	}
+}
+
+type virtualPeripheral struct {
+	sync.Mutex
+	addr             ble.Address
+	localName        string
+	rssi             int
+	serviceUUIDs     []ble.UUID
+	serviceData      []ble.ServiceData
+	manufacturerData []ble.Manufacturer
+	rawAdvertisement []byte
+	services         []VirtualService
+	mtu              int
+	connectable      bool
+	connected        bool
+}
+
+// NewVirtualPeripheral returns a new virtual peripheral with the specified address.
+func NewVirtualPeripheral(addr ble.Address, opts ...VirtualPeripheralOption) VirtualPeripheral {
+	p := &virtualPeripheral{
+		Mutex:            sync.Mutex{},
+		addr:             addr,
+		localName:        "",
+		rssi:             DefaultVirtualPeripheralRSSI,
+		serviceUUIDs:     []ble.UUID{},
+		serviceData:      []ble.ServiceData{},
+		manufacturerData: []ble.Manufacturer{},
+		rawAdvertisement: nil,
+		services:         []VirtualService{},
+		mtu:              ble.MaxATTMTU,
+		connectable:      true,
+		connected:        false,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Address returns the Bluetooth address of the peripheral.
+func (p *virtualPeripheral) Address() ble.Address {
+	return p.addr
+}
+
+// SetLocalName sets the advertised local name of the peripheral.
+func (p *virtualPeripheral) SetLocalName(name string) {
+	p.Lock()
+	defer p.Unlock()
+	p.localName = name
+}
+
+// SetRSSI sets the RSSI reported for the advertisements of the peripheral.
+func (p *virtualPeripheral) SetRSSI(rssi int) {
+	p.Lock()
+	defer p.Unlock()
+	p.rssi = rssi
+}
+
+// SetServiceUUIDs replaces the advertised service UUIDs of the peripheral.
+func (p *virtualPeripheral) SetServiceUUIDs(uuids ...ble.UUID) {
+	p.Lock()
+	defer p.Unlock()
+	p.serviceUUIDs = append([]ble.UUID{}, uuids...)
+}
+
+// AddServiceData adds or replaces an advertised service data element.
+func (p *virtualPeripheral) AddServiceData(uuid ble.UUID, data []byte) {
+	p.Lock()
+	defer p.Unlock()
+	elems := make([]ble.ServiceData, 0, len(p.serviceData)+1)
+	for _, sd := range p.serviceData {
+		if !sd.UUID().Equal(uuid) {
+			elems = append(elems, sd)
+		}
+	}
+	p.serviceData = append(elems, ble.NewServiceData(uuid, data))
+}
+
+// AddManufacturerData adds or replaces an advertised manufacturer specific data element.
+func (p *virtualPeripheral) AddManufacturerData(id int, data []byte) {
+	p.Lock()
+	defer p.Unlock()
+	elems := make([]ble.Manufacturer, 0, len(p.manufacturerData)+1)
+	for _, md := range p.manufacturerData {
+		if md.ID() != id {
+			elems = append(elems, md)
+		}
+	}
+	p.manufacturerData = append(elems, ble.NewManufacturer(id, data))
+}
+
+// Services returns the GATT services of the peripheral.
+func (p *virtualPeripheral) Services() []VirtualService {
+	p.Lock()
+	defer p.Unlock()
+	return append([]VirtualService{}, p.services...)
+}
+
+// SetServices replaces the GATT services of the peripheral. The peripheral should indicate a Service Changed to connected centrals.
+func (p *virtualPeripheral) SetServices(services ...VirtualService) {
+	p.Lock()
+	defer p.Unlock()
+	p.services = services
+}
+
+// IsConnected returns whether a central is connected to the peripheral.
+func (p *virtualPeripheral) IsConnected() bool {
+	p.Lock()
+	defer p.Unlock()
+	return p.connected
+}
+
+func (p *virtualPeripheral) scanResult() ble.ScanResult {
+	p.Lock()
+	defer p.Unlock()
+	return &virtualScanResult{
+		addr:             p.addr,
+		localName:        p.localName,
+		rssi:             p.rssi,
+		serviceUUIDs:     append([]ble.UUID{}, p.serviceUUIDs...),
+		serviceData:      append([]ble.ServiceData{}, p.serviceData...),
+		manufacturerData: append([]ble.Manufacturer{}, p.manufacturerData...),
+		rawAdvertisement: p.rawAdvertisement,
+	}
+}
+
+func (p *virtualPeripheral) connect(params ble.ConnectionParameters) (ble.BackendConnection, error) {
+	p.Lock()
+	defer p.Unlock()
+	if !p.connectable {
+		return nil, ErrNotConnectable
+	}
+	p.connected = true
+	// The MTU exchange settles on the smaller of the MTUs of the central and the peripheral.
+	mtu := params.MTU
+	if mtu == 0 {
+		mtu = ble.MaxATTMTU
+	}
+	mtu = max(min(mtu, p.mtu), ble.DefaultATTMTU)
+	return newVirtualConnection(p, mtu), nil
+}
+
+func (p *virtualPeripheral) disconnect() {
+	p.Lock()
+	p.connected = false
+	p.Unlock()
+	for _, service := range p.Services() {
+		for _, char := range service.Characteristics() {
+			if !char.IsSubscribed() {
+				continue
+			}
+			// Persistent notifications are also disabled by the disconnection.
+			if vchar, ok := char.(*virtualCharacteristic); ok {
+				vchar.unsubscribe()
+			} else {
+				char.EnableNotifications(nil)
+			}
+		}
+	}
+}
+
+type virtualScanResult struct {
+	addr             ble.Address
+	localName        string
+	rssi             int
+	serviceUUIDs     []ble.UUID
+	serviceData      []ble.ServiceData
+	manufacturerData []ble.Manufacturer
+	rawAdvertisement []byte
+}
+
+// Address returns the Bluetooth address of the advertiser.
+func (res *virtualScanResult) Address() ble.Address {
+	return res.addr
+}
+
+// RSSI returns the received signal strength indicator of the advertisement.
+func (res *virtualScanResult) RSSI() int {
+	return res.rssi
+}
+
+// LocalName returns the local name in the advertisement.
+func (res *virtualScanResult) LocalName() string {
+	return res.localName
+}
+
+// ServiceUUIDs returns the service UUIDs in the advertisement.
+func (res *virtualScanResult) ServiceUUIDs() []ble.UUID {
+	return res.serviceUUIDs
+}
+
+// ServiceData returns the service data elements in the advertisement.
+func (res *virtualScanResult) ServiceData() []ble.ServiceData {
+	return res.serviceData
+}
+
+// ManufacturerData returns the manufacturer specific data elements in the advertisement.
+func (res *virtualScanResult) ManufacturerData() []ble.Manufacturer {
+	return res.manufacturerData
+}
+
+// Bytes returns the raw advertising data, or nil if the peripheral has no raw advertising data.
+func (res *virtualScanResult) Bytes() []byte {
+	return res.rawAdvertisement
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"github.com/cybergarage/go-ble/ble"
+)
+
+// VirtualService represents a GATT service of a virtual peripheral.
+type VirtualService interface {
+	ble.BackendService
+	// Characteristics returns the characteristics of the service.
+	Characteristics() []VirtualCharacteristic
+	// IncludeServices adds the included services of the service.
+	IncludeServices(services ...VirtualService)
+}
+
+type virtualService struct {
+	uuid     ble.UUID
+	primary  bool
+	chars    []VirtualCharacteristic
+	included []VirtualService
+}
+
+// NewVirtualService returns a new virtual primary service with the specified UUID and characteristics.
+func NewVirtualService(uuid ble.UUID, chars ...VirtualCharacteristic) VirtualService {
+	return &virtualService{
+		uuid:     uuid,
+		primary:  true,
+		chars:    chars,
+		included: []VirtualService{},
+	}
+}
+
+// NewVirtualSecondaryService returns a new virtual secondary service with the specified UUID and characteristics.
+func NewVirtualSecondaryService(uuid ble.UUID, chars ...VirtualCharacteristic) VirtualService {
+	return &virtualService{
+		uuid:     uuid,
+		primary:  false,
+		chars:    chars,
+		included: []VirtualService{},
+	}
+}
+
+// UUID returns the service UUID.
+func (s *virtualService) UUID() ble.UUID {
+	return s.uuid
+}
+
+// IsPrimary returns whether the service is a primary service.
+func (s *virtualService) IsPrimary() bool {
+	return s.primary
+}
+
+// IncludeServices adds the included services of the service.
+func (s *virtualService) IncludeServices(services ...VirtualService) {
+	s.included = append(s.included, services...)
+}
+
+// IncludedServiceUUIDs returns the UUIDs of the included services.
+func (s *virtualService) IncludedServiceUUIDs() []ble.UUID {
+	uuids := make([]ble.UUID, 0, len(s.included))
+	for _, service := range s.included {
+		uuids = append(uuids, service.UUID())
+	}
+	return uuids
+}
+
+// Characteristics returns the characteristics of the service.
+func (s *virtualService) Characteristics() []VirtualCharacteristic {
+	return s.chars
+}
+
+// DiscoverCharacteristics discovers the specified characteristics. All characteristics are returned if no UUIDs are specified.
+func (s *virtualService) DiscoverCharacteristics(uuids []ble.UUID) ([]ble.BackendCharacteristic, error) {
+	chars := []ble.BackendCharacteristic{}
+	for _, char := range s.chars {
+		if len(uuids) == 0 || containsUUID(uuids, char.UUID()) {
+			chars = append(chars, char)
+		}
+	}
+	return chars, nil
+}
+
+func containsUUID(uuids []ble.UUID, uuid ble.UUID) bool {
+	for _, u := range uuids {
+		if u.Equal(uuid) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2025 The go-ble Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bletest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cybergarage/go-ble/ble"
+)
+
+func TestWritePacer(t *testing.T) {
+	waitN := func(t *testing.T, pacer ble.WritePacer, n int) time.Duration {
+		t.Helper()
+		start := time.Now()
+		for range n {
+			if err := pacer.Wait(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return time.Since(start)
+	}
+
+	t.Run("unpaced", func(t *testing.T) {
+		pacer := ble.NewWritePacer(ble.WritePacing{})
+		if elapsed := waitN(t, pacer, 100); 20*time.Millisecond < elapsed {
+			t.Errorf("expected no throttling, took %s", elapsed)
+		}
+	})
+
+	t.Run("gap", func(t *testing.T) {
+		pacer := ble.NewWritePacer(ble.WritePacing{Gap: 10 * time.Millisecond})
+		if elapsed := waitN(t, pacer, 5); elapsed < 40*time.Millisecond {
+			t.Errorf("expected at least 40ms for 5 writes, took %s", elapsed)
+		}
+	})
+
+	t.Run("credits", func(t *testing.T) {
+		pacer := ble.NewWritePacer(ble.WritePacing{Burst: 3, Credits: 3, Interval: 50 * time.Millisecond})
+		if elapsed := waitN(t, pacer, 3); 20*time.Millisecond < elapsed {
+			t.Errorf("expected the burst to be sent back-to-back, took %s", elapsed)
+		}
+		if elapsed := waitN(t, pacer, 3); elapsed < 40*time.Millisecond {
+			t.Errorf("expected the credits to be replenished over the interval, took %s", elapsed)
+		}
+	})
+
+	t.Run("ack", func(t *testing.T) {
+		pacer := ble.NewWritePacer(ble.WritePacing{AckWindow: 2, AckTimeout: 50 * time.Millisecond})
+		waitN(t, pacer, 2)
+		if pacer.Outstanding() != 2 {
+			t.Errorf("expected 2 outstanding writes, got %d", pacer.Outstanding())
+		}
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			pacer.Ack(1)
+		}()
+		if elapsed := waitN(t, pacer, 1); elapsed < 15*time.Millisecond {
+			t.Errorf("expected the write to wait for the acknowledgement, took %s", elapsed)
+		}
+		if err := pacer.Wait(context.Background()); !errors.Is(err, ble.ErrTimeout) {
+			t.Errorf("expected ErrTimeout, got %v", err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := pacer.Wait(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+
+		// A writer queued behind another one waiting for the acknowledgement stops waiting when its context is done.
+		go pacer.Wait(context.Background())
+		time.Sleep(10 * time.Millisecond)
+		ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		if err := pacer.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+		if elapsed := time.Since(start); 30*time.Millisecond <= elapsed {
+			t.Errorf("expected the queued write to be cancelled, took %s", elapsed)
+		}
+	})
+
+	t.Run("connection", func(t *testing.T) {
+		p, c1, _ := newTestMatterPeripheral(ble.Address{0x01, 0x02, 0x03, 0x04, 0x05, 0x06})
+		dev := connectTestDevice(t, p,
+			ble.WithConnectMTU(ble.DefaultATTMTU),
+			ble.WithConnectWritePacing(ble.WritePacing{Gap: 10 * time.Millisecond}),
+		)
+		if dev.WritePacer().Pacing().Gap != 10*time.Millisecond {
+			t.Errorf("expected the connection pacing, got %+v", dev.WritePacer().Pacing())
+		}
+		service, _ := dev.LookupService(testMatterServiceUUID)
+		transport, err := service.Open(ble.WithTransportWriteUUID(testMatterC1UUID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer transport.Close()
+
+		start := time.Now()
+		if _, err := transport.WriteWithoutResponse(context.Background(), make([]byte, 50)); err != nil {
+			t.Fatal(err)
+		}
+		if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+			t.Errorf("expected 3 paced writes to take at least 20ms, took %s", elapsed)
+		}
+		if len(c1.Value()) != 10 {
+			t.Errorf("expected the last write of 10 bytes, got %d", len(c1.Value()))
+		}
+
+		// A canceled context stops the queued writes.
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := transport.WriteWithoutResponse(ctx, make([]byte, 50)); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}